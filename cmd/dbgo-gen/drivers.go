@@ -0,0 +1,17 @@
+package main
+
+// The installed dbgo-gen links no database/sql drivers, because the drivers are not dependencies of db-go;
+// only the -schema mode works out of the box. The live database mode needs a dbgo-gen built with the drivers:
+// clone db-go, add a file to this directory with the blank imports of the drivers you need, fetch them and
+// install the command from the clone:
+//
+//	// drivers_local.go
+//	package main
+//
+//	import (
+//		_ "github.com/go-sql-driver/mysql"
+//		_ "github.com/lib/pq"
+//	)
+//
+//	go get github.com/go-sql-driver/mysql github.com/lib/pq
+//	go install ./cmd/dbgo-gen
@@ -0,0 +1,97 @@
+// Command dbgo-gen generates entity structs and typed repositories from a database schema.
+//
+// The schema is read from a schema JSON file or from a live database:
+//
+//	//go:generate dbgo-gen -schema schema.json -package models -out models_gen.go
+//	dbgo-gen -driver mysql -dsn "user:pass@tcp(localhost:3306)/shop" -tables users,orders
+//
+// The installed command links no database/sql drivers. The live mode needs a dbgo-gen built with
+// the driver, see drivers.go.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	db "github.com/fatihtatoglu/db-go"
+	db_generator "github.com/fatihtatoglu/db-go/generator"
+)
+
+func main() {
+	err := run(os.Args[1:], os.Stdout)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "dbgo-gen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("dbgo-gen", flag.ContinueOnError)
+	schemaPath := flags.String("schema", "", "the schema JSON file")
+	driver := flags.String("driver", "", "the database/sql driver name of the live database, which must be linked into dbgo-gen")
+	dsn := flags.String("dsn", "", "the data source name of the live database")
+	packageName := flags.String("package", "models", "the package name of the generated file")
+	out := flags.String("out", "", "the output file, the standard output when empty")
+	tables := flags.String("tables", "", "the comma separated table names, all the tables when empty")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	schema, err := readSchema(*schemaPath, *driver, *dsn)
+	if err != nil {
+		return err
+	}
+
+	if *tables != "" {
+		names := strings.Split(*tables, ",")
+		schema.Tables = slices.DeleteFunc(schema.Tables, func(table db_generator.Table) bool {
+			return !slices.Contains(names, table.Name)
+		})
+	}
+
+	source, err := db_generator.Generate(schema, *packageName)
+	if err != nil {
+		return err
+	}
+
+	if *out == "" {
+		_, err = stdout.Write(source)
+		return err
+	}
+
+	return os.WriteFile(*out, source, 0644)
+}
+
+func readSchema(schemaPath string, driver string, dsn string) (*db_generator.Schema, error) {
+	if schemaPath != "" {
+		file, err := os.Open(schemaPath)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+
+		return db_generator.ReadSchemaJSON(file)
+	}
+
+	if driver == "" {
+		return nil, fmt.Errorf("either -schema or -driver and -dsn must be given")
+	}
+
+	connection, err := db.CreateNewDBConnection(driver, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("%w: the %q driver must be linked into dbgo-gen, build it with the driver as described in cmd/dbgo-gen/drivers.go", err, driver)
+	}
+
+	command, err := db.CreateNewDBCommand(connection)
+	if err != nil {
+		return nil, err
+	}
+
+	return db_generator.ReadSchema(command)
+}
@@ -0,0 +1,95 @@
+package db_criteria
+
+import (
+	"reflect"
+)
+
+// Column is a column holding the values of type V. Its predicates only accept values of that type,
+// so a mismatch between a column and its value is caught by the compiler:
+//
+//	var Age = db_criteria.NewColumn[int]("age")
+//
+//	users.Find(db_criteria.And(Age.Gte(18), Age.Lt(65)))
+type Column[V any] struct {
+	name string
+}
+
+func NewColumn[V any](name string) Column[V] {
+	return Column[V]{name: name}
+}
+
+func (c Column[V]) GetName() string {
+	return c.name
+}
+
+// Eq matches the rows whose column equals the value. A nil pointer matches NULL.
+func (c Column[V]) Eq(value V) Criteria {
+	return Eq(c.name, value)
+}
+
+// NotEq matches the rows whose column differs from the value. A nil pointer matches NOT NULL.
+func (c Column[V]) NotEq(value V) Criteria {
+	return NotEq(c.name, value)
+}
+
+func (c Column[V]) Gt(value V) Criteria {
+	return Gt(c.name, value)
+}
+
+func (c Column[V]) Gte(value V) Criteria {
+	return Gte(c.name, value)
+}
+
+func (c Column[V]) Lt(value V) Criteria {
+	return Lt(c.name, value)
+}
+
+func (c Column[V]) Lte(value V) Criteria {
+	return Lte(c.name, value)
+}
+
+func (c Column[V]) Like(pattern string) Criteria {
+	return Like(c.name, pattern)
+}
+
+func (c Column[V]) In(values ...V) Criteria {
+	return In(c.name, values...)
+}
+
+func (c Column[V]) NotIn(values ...V) Criteria {
+	return NotIn(c.name, values...)
+}
+
+func (c Column[V]) Between(from V, to V) Criteria {
+	return Between(c.name, from, to)
+}
+
+func (c Column[V]) IsNull() Criteria {
+	return IsNull(c.name)
+}
+
+func (c Column[V]) IsNotNull() Criteria {
+	return IsNotNull(c.name)
+}
+
+func (c Column[V]) Asc() Sort {
+	return Asc(c.name)
+}
+
+func (c Column[V]) Desc() Sort {
+	return Desc(c.name)
+}
+
+func isNil(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice:
+		return v.IsNil()
+	}
+
+	return false
+}
@@ -0,0 +1,62 @@
+package db_criteria
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestColumn(t *testing.T) {
+	// Arrange
+	age := NewColumn[int]("age")
+	name := NewColumn[string]("name")
+	deletedAt := NewColumn[*time.Time]("deleted_at")
+	now := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	// Test cases
+	testCases := []struct {
+		testName     string
+		criteria     Criteria
+		expectedSQL  string
+		expectedArgs []interface{}
+	}{
+		{"Eq", name.Eq("Fatih"), `"name" = ?`, []interface{}{"Fatih"}},
+		{"Eq nil pointer", deletedAt.Eq(nil), `"deleted_at" IS NULL`, nil},
+		{"Eq pointer", deletedAt.Eq(&now), `"deleted_at" = ?`, []interface{}{&now}},
+		{"NotEq nil pointer", deletedAt.NotEq(nil), `"deleted_at" IS NOT NULL`, nil},
+		{"Lt", age.Lt(65), `"age" < ?`, []interface{}{65}},
+		{"Like", name.Like("F%"), `"name" LIKE ?`, []interface{}{"F%"}},
+		{"In", age.In(18, 21), `"age" IN (?, ?)`, []interface{}{18, 21}},
+		{"Between", age.Between(18, 65), `"age" BETWEEN ? AND ?`, []interface{}{18, 65}},
+		{"IsNull", name.IsNull(), `"name" IS NULL`, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			// Act
+			sql, args := tc.criteria.Build(quote)
+
+			// Assert
+			if sql != tc.expectedSQL {
+				t.Errorf("Expected SQL: %s, got: %s", tc.expectedSQL, sql)
+			}
+
+			if len(args) != len(tc.expectedArgs) || (len(args) > 0 && !reflect.DeepEqual(args, tc.expectedArgs)) {
+				t.Errorf("Expected args: %v, got: %v", tc.expectedArgs, args)
+			}
+		})
+	}
+}
+
+func TestColumn_Sort(t *testing.T) {
+	// Arrange
+	age := NewColumn[int]("age")
+
+	// Act
+	sort := age.Desc()
+
+	// Assert
+	if sort.GetColumn() != "age" || !sort.IsDescending() {
+		t.Errorf("Unexpected sort: %v", sort)
+	}
+}
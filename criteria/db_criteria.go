@@ -0,0 +1,200 @@
+package db_criteria
+
+import (
+	"strings"
+)
+
+// Criteria is a composable predicate on the columns of a table.
+type Criteria interface {
+	// Build writes the predicate with ? placeholders and returns its arguments.
+	// The quote function is used for the column names.
+	Build(quote func(string) string) (string, []interface{})
+}
+
+type comparison struct {
+	column   string
+	operator string
+	value    interface{}
+}
+
+type list struct {
+	column string
+	negate bool
+	values []interface{}
+}
+
+type between struct {
+	column string
+	from   interface{}
+	to     interface{}
+}
+
+type nullCheck struct {
+	column string
+	negate bool
+}
+
+type junction struct {
+	operator string
+	criteria []Criteria
+}
+
+type negation struct {
+	criteria Criteria
+}
+
+// Eq matches the rows whose column equals the value. A nil value or a nil pointer matches NULL.
+func Eq(column string, value interface{}) Criteria {
+	if isNil(value) {
+		return IsNull(column)
+	}
+
+	return comparison{column: column, operator: "=", value: value}
+}
+
+// NotEq matches the rows whose column differs from the value. A nil value or a nil pointer matches NOT NULL.
+func NotEq(column string, value interface{}) Criteria {
+	if isNil(value) {
+		return IsNotNull(column)
+	}
+
+	return comparison{column: column, operator: "<>", value: value}
+}
+
+func Gt(column string, value interface{}) Criteria {
+	return comparison{column: column, operator: ">", value: value}
+}
+
+func Gte(column string, value interface{}) Criteria {
+	return comparison{column: column, operator: ">=", value: value}
+}
+
+func Lt(column string, value interface{}) Criteria {
+	return comparison{column: column, operator: "<", value: value}
+}
+
+func Lte(column string, value interface{}) Criteria {
+	return comparison{column: column, operator: "<=", value: value}
+}
+
+// Like matches the rows whose column matches the pattern.
+func Like(column string, pattern string) Criteria {
+	return comparison{column: column, operator: "LIKE", value: pattern}
+}
+
+// In matches the rows whose column is one of the values. No values match no rows.
+func In[V any](column string, values ...V) Criteria {
+	return list{column: column, values: toInterfaces(values)}
+}
+
+// NotIn matches the rows whose column is none of the values. No values match all rows.
+func NotIn[V any](column string, values ...V) Criteria {
+	return list{column: column, negate: true, values: toInterfaces(values)}
+}
+
+// Between matches the rows whose column is in the inclusive range.
+func Between[V any](column string, from V, to V) Criteria {
+	return between{column: column, from: from, to: to}
+}
+
+func IsNull(column string) Criteria {
+	return nullCheck{column: column}
+}
+
+func IsNotNull(column string) Criteria {
+	return nullCheck{column: column, negate: true}
+}
+
+// And matches the rows matched by all the criteria. Nil criteria are skipped.
+func And(criteria ...Criteria) Criteria {
+	return junction{operator: "AND", criteria: criteria}
+}
+
+// Or matches the rows matched by any of the criteria. Nil criteria are skipped.
+func Or(criteria ...Criteria) Criteria {
+	return junction{operator: "OR", criteria: criteria}
+}
+
+func Not(criteria Criteria) Criteria {
+	return negation{criteria: criteria}
+}
+
+func (c comparison) Build(quote func(string) string) (string, []interface{}) {
+	return quote(c.column) + " " + c.operator + " ?", []interface{}{c.value}
+}
+
+func (c list) Build(quote func(string) string) (string, []interface{}) {
+	if len(c.values) == 0 {
+		if c.negate {
+			return "1 = 1", nil
+		}
+
+		return "1 = 0", nil
+	}
+
+	operator := " IN ("
+	if c.negate {
+		operator = " NOT IN ("
+	}
+
+	placeholders := strings.Repeat("?, ", len(c.values))
+	return quote(c.column) + operator + placeholders[:len(placeholders)-2] + ")", c.values
+}
+
+func (c between) Build(quote func(string) string) (string, []interface{}) {
+	return quote(c.column) + " BETWEEN ? AND ?", []interface{}{c.from, c.to}
+}
+
+func (c nullCheck) Build(quote func(string) string) (string, []interface{}) {
+	if c.negate {
+		return quote(c.column) + " IS NOT NULL", nil
+	}
+
+	return quote(c.column) + " IS NULL", nil
+}
+
+func (c junction) Build(quote func(string) string) (string, []interface{}) {
+	parts := make([]string, 0, len(c.criteria))
+	args := make([]interface{}, 0)
+	for _, criteria := range c.criteria {
+		if criteria == nil {
+			continue
+		}
+
+		sql, criteriaArgs := criteria.Build(quote)
+		parts = append(parts, "("+sql+")")
+		args = append(args, criteriaArgs...)
+	}
+
+	if len(parts) == 0 {
+		if c.operator == "OR" {
+			return "1 = 0", nil
+		}
+
+		return "1 = 1", nil
+	}
+
+	if len(parts) == 1 {
+		return parts[0], args
+	}
+
+	return strings.Join(parts, " "+c.operator+" "), args
+}
+
+func (c negation) Build(quote func(string) string) (string, []interface{}) {
+	if c.criteria == nil {
+		return "1 = 0", nil
+	}
+
+	sql, args := c.criteria.Build(quote)
+	return "NOT (" + sql + ")", args
+}
+
+func toInterfaces[V any](values []V) []interface{} {
+	result := make([]interface{}, len(values))
+	for i, value := range values {
+		result[i] = value
+	}
+
+	return result
+}
@@ -0,0 +1,67 @@
+package db_criteria
+
+import (
+	"reflect"
+	"testing"
+)
+
+func quote(name string) string {
+	return `"` + name + `"`
+}
+
+func TestCriteria(t *testing.T) {
+	// Test cases
+	testCases := []struct {
+		testName     string
+		criteria     Criteria
+		expectedSQL  string
+		expectedArgs []interface{}
+	}{
+		{"Eq", Eq("name", "Fatih"), `"name" = ?`, []interface{}{"Fatih"}},
+		{"Eq nil", Eq("deleted_at", nil), `"deleted_at" IS NULL`, nil},
+		{"Eq nil pointer", Eq("deleted_at", (*int)(nil)), `"deleted_at" IS NULL`, nil},
+		{"NotEq", NotEq("age", 39), `"age" <> ?`, []interface{}{39}},
+		{"Gte", Gte("age", 18), `"age" >= ?`, []interface{}{18}},
+		{"Like", Like("name", "F%"), `"name" LIKE ?`, []interface{}{"F%"}},
+		{"In", In("id", 1, 2, 3), `"id" IN (?, ?, ?)`, []interface{}{1, 2, 3}},
+		{"In without values", In[int]("id"), `1 = 0`, nil},
+		{"NotIn", NotIn("id", "a"), `"id" NOT IN (?)`, []interface{}{"a"}},
+		{"Between", Between("age", 18, 65), `"age" BETWEEN ? AND ?`, []interface{}{18, 65}},
+		{"IsNotNull", IsNotNull("email"), `"email" IS NOT NULL`, nil},
+		{"And", And(Eq("a", 1), nil, Eq("b", 2)), `("a" = ?) AND ("b" = ?)`, []interface{}{1, 2}},
+		{"Or with single criteria", Or(Eq("a", 1)), `("a" = ?)`, []interface{}{1}},
+		{"Empty Or", Or(), `1 = 0`, nil},
+		{"Not", Not(Or(Eq("a", 1), IsNull("b"))), `NOT (("a" = ?) OR ("b" IS NULL))`, []interface{}{1}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			// Act
+			sql, args := tc.criteria.Build(quote)
+
+			// Assert
+			if sql != tc.expectedSQL {
+				t.Errorf("Expected SQL: %s, got: %s", tc.expectedSQL, sql)
+			}
+
+			if len(args) != len(tc.expectedArgs) || (len(args) > 0 && !reflect.DeepEqual(args, tc.expectedArgs)) {
+				t.Errorf("Expected args: %v, got: %v", tc.expectedArgs, args)
+			}
+		})
+	}
+}
+
+func TestSort(t *testing.T) {
+	// Act
+	asc := Asc("name").Build(quote)
+	desc := Desc("age").Build(quote)
+
+	// Assert
+	if asc != `"name" ASC` {
+		t.Errorf("Unexpected sort: %s", asc)
+	}
+
+	if desc != `"age" DESC` {
+		t.Errorf("Unexpected sort: %s", desc)
+	}
+}
@@ -0,0 +1,32 @@
+package db_criteria
+
+// Sort is a sort item of a query.
+type Sort struct {
+	column     string
+	descending bool
+}
+
+func Asc(column string) Sort {
+	return Sort{column: column}
+}
+
+func Desc(column string) Sort {
+	return Sort{column: column, descending: true}
+}
+
+func (s Sort) GetColumn() string {
+	return s.column
+}
+
+func (s Sort) IsDescending() bool {
+	return s.descending
+}
+
+// Build writes the sort item; the quote function is used for the column name.
+func (s Sort) Build(quote func(string) string) string {
+	if s.descending {
+		return quote(s.column) + " DESC"
+	}
+
+	return quote(s.column) + " ASC"
+}
@@ -0,0 +1,72 @@
+package db
+
+import (
+	"context"
+	"reflect"
+)
+
+// UserProvider returns the current user of the context, which is written into the createdby and updatedby fields.
+type UserProvider interface {
+	GetUser(ctx context.Context) (interface{}, error)
+}
+
+// UserProviderFunc is a function adapter of UserProvider.
+type UserProviderFunc func(ctx context.Context) (interface{}, error)
+
+func (f UserProviderFunc) GetUser(ctx context.Context) (interface{}, error) {
+	return f(ctx)
+}
+
+// audit returns a copy of the entity with the audit fields set for an insert or an update.
+// The audit values are written back when T is a pointer. Without a user provider, or when the
+// provider returns nil, the createdby and updatedby fields are kept as they are.
+func (r *dbRepository[T, K]) audit(sv reflect.Value, insert bool) (reflect.Value, error) {
+	audited := reflect.New(sv.Type()).Elem()
+	audited.Set(sv)
+
+	timeOptions := []string{tagOptionUpdatedAt}
+	userOptions := []string{tagOptionUpdatedBy}
+	if insert {
+		timeOptions = append(timeOptions, tagOptionCreatedAt)
+		userOptions = append(userOptions, tagOptionCreatedBy)
+	}
+
+	now := r.options.now()
+	err := r.setAuditFields(sv, audited, timeOptions, now)
+	if err != nil {
+		return audited, err
+	}
+
+	if r.options.userProvider == nil {
+		return audited, nil
+	}
+
+	user, err := r.options.userProvider.GetUser(r.command.GetContext())
+	if err != nil || user == nil {
+		return audited, err
+	}
+
+	return audited, r.setAuditFields(sv, audited, userOptions, user)
+}
+
+func (r *dbRepository[T, K]) setAuditFields(sv reflect.Value, audited reflect.Value, options []string, value interface{}) error {
+	for _, f := range r.metadata.fields {
+		for _, option := range options {
+			if !f.hasOption(option) {
+				continue
+			}
+
+			err := assignReflectValue(audited.FieldByIndex(f.index), value)
+			if err != nil {
+				return err
+			}
+
+			err = r.writeBack(sv, f, value)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package db
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type testArticle struct {
+	ID        int64     `db:"id,pk"`
+	Title     string    `db:"title"`
+	CreatedAt time.Time `db:"created_at,created"`
+	CreatedBy string    `db:"created_by,createdby"`
+	UpdatedAt time.Time `db:"updated_at,updated"`
+	UpdatedBy *string   `db:"updated_by,updatedby"`
+}
+
+type testUserKey struct{}
+
+func createAuditRepository(command *recordingCommand, now time.Time) Repository[*testArticle, int64] {
+	provider := UserProviderFunc(func(ctx context.Context) (interface{}, error) {
+		return ctx.Value(testUserKey{}), nil
+	})
+
+	repository, _ := CreateNewRepository[*testArticle, int64](command, "articles",
+		WithClock(func() time.Time { return now }),
+		WithUserProvider(provider))
+
+	return repository
+}
+
+func TestRepository_Insert_Audit(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 2, 3, 4, 5, 6, 0, time.UTC)
+	command := &recordingCommand{dialect: DialectMySQL}
+	sut := createAuditRepository(command, now).WithContext(context.WithValue(context.Background(), testUserKey{}, "fatih"))
+	article := &testArticle{ID: 1, Title: "Hello"}
+
+	// Act
+	_, err := sut.Insert(article)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	user := "fatih"
+	expectedArgs := []interface{}{int64(1), "Hello", now, "fatih", now, &user}
+	if !reflect.DeepEqual(command.args[0], expectedArgs) {
+		t.Errorf("Expected args: %v, got: %v", expectedArgs, command.args[0])
+	}
+
+	if article.CreatedBy != "fatih" || !article.UpdatedAt.Equal(now) || article.UpdatedBy == nil || *article.UpdatedBy != "fatih" {
+		t.Errorf("Expected the audit fields to be written back, got: %+v", article)
+	}
+}
+
+func TestRepository_Update_Audit(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 2, 3, 4, 5, 6, 0, time.UTC)
+	created := now.Add(-time.Hour)
+	command := &recordingCommand{dialect: DialectMySQL}
+	sut := createAuditRepository(command, now)
+	article := &testArticle{ID: 1, Title: "Hello", CreatedAt: created, CreatedBy: "ali"}
+
+	// Act
+	_, err := sut.Update(1, article)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expectedQuery := "UPDATE `articles` SET `title` = ?, `updated_at` = ?, `updated_by` = ? WHERE `id` = ?"
+	if command.queries[0] != expectedQuery {
+		t.Errorf("Expected query: %s, got: %s", expectedQuery, command.queries[0])
+	}
+
+	// Without a user in the context the updatedby field is kept.
+	expectedArgs := []interface{}{"Hello", now, (*string)(nil), int64(1)}
+	if !reflect.DeepEqual(command.args[0], expectedArgs) {
+		t.Errorf("Expected args: %v, got: %v", expectedArgs, command.args[0])
+	}
+
+	if !article.CreatedAt.Equal(created) || article.CreatedBy != "ali" {
+		t.Errorf("Expected the created fields to be kept, got: %+v", article)
+	}
+}
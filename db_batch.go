@@ -0,0 +1,177 @@
+package db
+
+import (
+	"fmt"
+)
+
+const (
+	batchSavepointName = "dbgo_batch_row"
+)
+
+// BatchOption configures ExecuteBatch.
+type BatchOption func(*batchOptions)
+
+type batchOptions struct {
+	chunkSize int
+	rowErrors bool
+}
+
+// WithBatchChunkSize commits the batch every size parameter sets in a separate transaction.
+// The chunks committed before a failing one are kept.
+func WithBatchChunkSize(size int) BatchOption {
+	return func(o *batchOptions) {
+		o.chunkSize = size
+	}
+}
+
+// WithBatchRowErrors reports the failing parameter sets in the result instead of
+// rolling back the whole batch. Every parameter set runs inside its own savepoint.
+func WithBatchRowErrors() BatchOption {
+	return func(o *batchOptions) {
+		o.rowErrors = true
+	}
+}
+
+// BatchResult is the outcome of ExecuteBatch.
+type BatchResult struct {
+	rowsAffected int64
+	rowErrors    []BatchRowError
+}
+
+func (r *BatchResult) GetRowsAffected() int64 {
+	return r.rowsAffected
+}
+
+func (r *BatchResult) GetRowErrors() []BatchRowError {
+	return r.rowErrors
+}
+
+func (r *BatchResult) HasRowErrors() bool {
+	return len(r.rowErrors) > 0
+}
+
+// BatchRowError is the error of the parameter set at the index.
+type BatchRowError struct {
+	index int
+	err   error
+}
+
+func (e BatchRowError) GetIndex() int {
+	return e.index
+}
+
+func (e BatchRowError) Error() string {
+	return fmt.Sprintf("batch: parameter set %d failed: %v", e.index, e.err)
+}
+
+func (e BatchRowError) Unwrap() error {
+	return e.err
+}
+
+// ExecuteBatch executes the query once per parameter set with a single prepared statement.
+// The batch runs in one transaction unless a chunk size is given; when the command is already
+// bound to a transaction, that transaction is used and chunking is ignored.
+func (cmd *dbCommand) ExecuteBatch(query string, paramSets [][]interface{}, options ...BatchOption) (*BatchResult, error) {
+	opts := batchOptions{}
+	for _, option := range options {
+		option(&opts)
+	}
+
+	result := &BatchResult{
+		rowErrors: make([]BatchRowError, 0),
+	}
+
+	if cmd.tx != nil {
+		affected, err := cmd.executeBatchChunk(query, paramSets, 0, opts, result)
+		result.rowsAffected += affected
+		return result, err
+	}
+
+	chunkSize := opts.chunkSize
+	if chunkSize <= 0 {
+		chunkSize = len(paramSets)
+	}
+
+	for start := 0; start < len(paramSets); start += chunkSize {
+		end := min(start+chunkSize, len(paramSets))
+
+		tx, err := cmd.Begin()
+		if err != nil {
+			return result, err
+		}
+
+		t := tx.(*dbTransaction)
+		affected, err := t.executeBatchChunk(query, paramSets[start:end], start, opts, result)
+		if err != nil {
+			t.Rollback()
+			return result, err
+		}
+
+		err = t.Commit()
+		if err != nil {
+			return result, err
+		}
+
+		result.rowsAffected += affected
+	}
+
+	return result, nil
+}
+
+// executeBatchChunk runs the parameter sets on the transaction of the command and returns the affected row count.
+func (cmd *dbCommand) executeBatchChunk(query string, paramSets [][]interface{}, offset int, opts batchOptions, result *BatchResult) (int64, error) {
+	stmt, release := cmd.statement(query)
+	if stmt == nil {
+		var err error
+		stmt, err = cmd.tx.PrepareContext(cmd.ctx, query)
+		if err != nil {
+			return 0, err
+		}
+
+		release = func() { stmt.Close() }
+	}
+	defer release()
+
+	dialect := cmd.connection.GetDialect()
+
+	var affected int64
+	for i, params := range paramSets {
+		if opts.rowErrors {
+			_, err := cmd.tx.ExecContext(cmd.ctx, dialect.savepointSQL(batchSavepointName))
+			if err != nil {
+				return affected, err
+			}
+		}
+
+		res, err := stmt.ExecContext(cmd.ctx, params...)
+		if err != nil {
+			rowError := BatchRowError{index: offset + i, err: err}
+			if !opts.rowErrors {
+				return affected, rowError
+			}
+
+			_, err = cmd.tx.ExecContext(cmd.ctx, dialect.rollbackToSavepointSQL(batchSavepointName))
+			if err != nil {
+				return affected, err
+			}
+
+			result.rowErrors = append(result.rowErrors, rowError)
+			continue
+		}
+
+		releaseSQL := dialect.releaseSavepointSQL(batchSavepointName)
+		if opts.rowErrors && releaseSQL != "" {
+			_, err = cmd.tx.ExecContext(cmd.ctx, releaseSQL)
+			if err != nil {
+				return affected, err
+			}
+		}
+
+		n, err := res.RowsAffected()
+		if err == nil {
+			affected += n
+		}
+	}
+
+	return affected, nil
+}
@@ -0,0 +1,151 @@
+package db
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestBatchOptions(t *testing.T) {
+	// Arrange
+	opts := batchOptions{}
+
+	// Act
+	for _, option := range []BatchOption{WithBatchChunkSize(500), WithBatchRowErrors()} {
+		option(&opts)
+	}
+
+	// Assert
+	if opts.chunkSize != 500 {
+		t.Errorf("Expected chunk size: 500, got: %d", opts.chunkSize)
+	}
+
+	if !opts.rowErrors {
+		t.Error("Expected row errors to be enabled")
+	}
+}
+
+func TestBatchRowError(t *testing.T) {
+	// Arrange
+	cause := errors.New("duplicate entry")
+
+	// Act
+	err := BatchRowError{index: 3, err: cause}
+
+	// Assert
+	if err.GetIndex() != 3 {
+		t.Errorf("Expected index: 3, got: %d", err.GetIndex())
+	}
+
+	if !errors.Is(err, cause) {
+		t.Error("Expected the row error to wrap the cause")
+	}
+
+	expectedMessage := "batch: parameter set 3 failed: duplicate entry"
+	if err.Error() != expectedMessage {
+		t.Errorf("Expected error message: %s, got: %s", expectedMessage, err.Error())
+	}
+}
+
+func TestSavepointSQL(t *testing.T) {
+	// Test cases
+	testCases := []struct {
+		dialect          Dialect
+		expectedSave     string
+		expectedRollback string
+		expectedRelease  string
+	}{
+		{DialectMySQL, "SAVEPOINT sp", "ROLLBACK TO SAVEPOINT sp", "RELEASE SAVEPOINT sp"},
+		{DialectSQLServer, "SAVE TRANSACTION sp", "ROLLBACK TRANSACTION sp", ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.dialect), func(t *testing.T) {
+			// Act & Assert
+			if s := tc.dialect.savepointSQL("sp"); s != tc.expectedSave {
+				t.Errorf("Expected: %s, got: %s", tc.expectedSave, s)
+			}
+
+			if s := tc.dialect.rollbackToSavepointSQL("sp"); s != tc.expectedRollback {
+				t.Errorf("Expected: %s, got: %s", tc.expectedRollback, s)
+			}
+
+			if s := tc.dialect.releaseSavepointSQL("sp"); s != tc.expectedRelease {
+				t.Errorf("Expected: %s, got: %s", tc.expectedRelease, s)
+			}
+		})
+	}
+}
+
+func TestDBCommand_ExecuteBatch_Chunks(t *testing.T) {
+	// Arrange
+	command, mock := createTestMockCommand(t, DialectMySQL)
+	query := "INSERT INTO users (name) VALUES (?)"
+	failure := errors.New("Duplicate entry 'Ann'")
+
+	mock.ExpectBegin()
+	mock.ExpectExec(query).WithArgs("Ann").WillReturnResult(1, 1)
+	mock.ExpectExec(query).WithArgs("Bob").WillReturnResult(2, 1)
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec(query).WithArgs("Cid").WillReturnResult(3, 1)
+	mock.ExpectExec(query).WithArgs("Ann").WillReturnError(failure)
+	mock.ExpectRollback()
+
+	// Act
+	result, err := command.ExecuteBatch(query, [][]interface{}{{"Ann"}, {"Bob"}, {"Cid"}, {"Ann"}}, WithBatchChunkSize(2))
+
+	// Assert
+	var rowError BatchRowError
+	if !errors.As(err, &rowError) || rowError.GetIndex() != 3 || !errors.Is(err, failure) {
+		t.Errorf("Expected the error of the parameter set 3, got: %v", err)
+	}
+
+	if result.GetRowsAffected() != 2 {
+		t.Errorf("Expected the rows of the committed chunk: 2, got: %d", result.GetRowsAffected())
+	}
+
+	assertExpectationsWereMet(t, mock)
+}
+
+func TestDBCommand_ExecuteBatch_RowErrors(t *testing.T) {
+	// Arrange
+	command, mock := createTestMockCommand(t, DialectPostgres)
+	query := `INSERT INTO "users" ("name") VALUES ($1)`
+	failure := errors.New(`duplicate key value violates unique constraint "users_name_key"`)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("SAVEPOINT dbgo_batch_row")
+	mock.ExpectExec(query).WithArgs("Ann").WillReturnResult(0, 1)
+	mock.ExpectExec("RELEASE SAVEPOINT dbgo_batch_row")
+	mock.ExpectExec("SAVEPOINT dbgo_batch_row")
+	mock.ExpectExec(query).WithArgs("Ann").WillReturnError(failure)
+	mock.ExpectExec("ROLLBACK TO SAVEPOINT dbgo_batch_row")
+	mock.ExpectExec("SAVEPOINT dbgo_batch_row")
+	mock.ExpectExec(query).WithArgs("Bob").WillReturnResult(0, 1)
+	mock.ExpectExec("RELEASE SAVEPOINT dbgo_batch_row")
+	mock.ExpectCommit()
+
+	// Act
+	result, err := command.ExecuteBatch(query, [][]interface{}{{"Ann"}, {"Ann"}, {"Bob"}}, WithBatchRowErrors())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if result.GetRowsAffected() != 2 {
+		t.Errorf("Expected rows affected: 2, got: %d", result.GetRowsAffected())
+	}
+
+	indexes := make([]int, 0)
+	for _, rowError := range result.GetRowErrors() {
+		indexes = append(indexes, rowError.GetIndex())
+	}
+
+	if !reflect.DeepEqual(indexes, []int{1}) || !errors.Is(result.GetRowErrors()[0], failure) {
+		t.Errorf("Expected the error of the parameter set 1, got: %v", result.GetRowErrors())
+	}
+
+	assertExpectationsWereMet(t, mock)
+}
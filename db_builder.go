@@ -0,0 +1,487 @@
+package db
+
+import (
+	"database/sql"
+	"strings"
+
+	db_criteria "github.com/fatihtatoglu/db-go/criteria"
+	db_errors "github.com/fatihtatoglu/db-go/error"
+)
+
+// SQLBuilder builds a statement and its arguments for a dialect.
+//
+// Conditions and expressions are written with ? placeholders, which are rebound to the placeholder
+// style of the dialect when the statement is built. Table and column names given to Insert, Update
+// and Delete are quoted; the select list, FROM, JOIN and ORDER BY items are written as they are so
+// they can carry aliases and expressions.
+type SQLBuilder interface {
+	Build(dialect Dialect) (string, []interface{}, error)
+}
+
+type builderOrder struct {
+	item string
+	sort *db_criteria.Sort
+}
+
+type builderClause struct {
+	sql      string
+	args     []interface{}
+	criteria db_criteria.Criteria
+}
+
+// SelectBuilder builds SELECT statements.
+type SelectBuilder struct {
+	distinct bool
+	columns  []string
+	from     string
+	joins    []builderClause
+	where    []builderClause
+	groupBy  []string
+	having   []builderClause
+	orderBy  []builderOrder
+	limit    int
+	offset   int
+}
+
+// Select starts a SELECT statement. No columns select all the columns.
+func Select(columns ...string) *SelectBuilder {
+	return &SelectBuilder{
+		columns: columns,
+		limit:   -1,
+		offset:  -1,
+	}
+}
+
+func (b *SelectBuilder) Distinct() *SelectBuilder {
+	b.distinct = true
+	return b
+}
+
+func (b *SelectBuilder) From(table string) *SelectBuilder {
+	b.from = table
+	return b
+}
+
+func (b *SelectBuilder) Join(table string, on string, args ...interface{}) *SelectBuilder {
+	return b.join("JOIN", table, on, args)
+}
+
+func (b *SelectBuilder) LeftJoin(table string, on string, args ...interface{}) *SelectBuilder {
+	return b.join("LEFT JOIN", table, on, args)
+}
+
+func (b *SelectBuilder) RightJoin(table string, on string, args ...interface{}) *SelectBuilder {
+	return b.join("RIGHT JOIN", table, on, args)
+}
+
+// Where adds a condition; multiple conditions are combined with AND.
+func (b *SelectBuilder) Where(condition string, args ...interface{}) *SelectBuilder {
+	b.where = append(b.where, builderClause{sql: condition, args: args})
+	return b
+}
+
+// WhereCriteria adds the criteria as a condition; nil criteria are skipped.
+func (b *SelectBuilder) WhereCriteria(criteria db_criteria.Criteria) *SelectBuilder {
+	if criteria != nil {
+		b.where = append(b.where, builderClause{criteria: criteria})
+	}
+
+	return b
+}
+
+func (b *SelectBuilder) GroupBy(columns ...string) *SelectBuilder {
+	b.groupBy = append(b.groupBy, columns...)
+	return b
+}
+
+// Having adds a group condition; multiple conditions are combined with AND.
+func (b *SelectBuilder) Having(condition string, args ...interface{}) *SelectBuilder {
+	b.having = append(b.having, builderClause{sql: condition, args: args})
+	return b
+}
+
+// OrderBy adds sort items such as "name" or "created_at DESC".
+func (b *SelectBuilder) OrderBy(items ...string) *SelectBuilder {
+	for _, item := range items {
+		b.orderBy = append(b.orderBy, builderOrder{item: item})
+	}
+
+	return b
+}
+
+// OrderBySort adds the sort items; their column names are quoted.
+func (b *SelectBuilder) OrderBySort(sort ...db_criteria.Sort) *SelectBuilder {
+	for i := range sort {
+		b.orderBy = append(b.orderBy, builderOrder{sort: &sort[i]})
+	}
+
+	return b
+}
+
+func (b *SelectBuilder) Limit(limit int) *SelectBuilder {
+	b.limit = limit
+	return b
+}
+
+func (b *SelectBuilder) Offset(offset int) *SelectBuilder {
+	b.offset = offset
+	return b
+}
+
+func (b *SelectBuilder) Build(dialect Dialect) (string, []interface{}, error) {
+	var sb strings.Builder
+	args := make([]interface{}, 0)
+
+	sb.WriteString("SELECT ")
+	if b.distinct {
+		sb.WriteString("DISTINCT ")
+	}
+
+	if len(b.columns) == 0 {
+		sb.WriteString("*")
+	} else {
+		sb.WriteString(strings.Join(b.columns, ", "))
+	}
+
+	if b.from != "" {
+		sb.WriteString(" FROM " + b.from)
+	}
+
+	for _, join := range b.joins {
+		sb.WriteString(" " + join.sql)
+		args = append(args, join.args...)
+	}
+
+	args = writeConditions(&sb, dialect, " WHERE ", b.where, args)
+
+	if len(b.groupBy) > 0 {
+		sb.WriteString(" GROUP BY " + strings.Join(b.groupBy, ", "))
+	}
+
+	args = writeConditions(&sb, dialect, " HAVING ", b.having, args)
+
+	paged := b.limit >= 0 || b.offset > 0
+	if len(b.orderBy) > 0 {
+		sb.WriteString(" ORDER BY ")
+		for i, order := range b.orderBy {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+
+			if order.sort != nil {
+				sb.WriteString(order.sort.Build(dialect.QuoteIdentifier))
+			} else {
+				sb.WriteString(order.item)
+			}
+		}
+	} else if paged && dialect == DialectSQLServer {
+		sb.WriteString(" ORDER BY (SELECT NULL)")
+	}
+
+	if paged {
+		paging, pagingArgs := pagingSQL(dialect, b.limit, max(b.offset, 0))
+		sb.WriteString(" " + paging)
+		args = append(args, pagingArgs...)
+	}
+
+	return dialect.Rebind(sb.String()), args, nil
+}
+
+// Query runs the statement on the command with the dialect of the command.
+func (b *SelectBuilder) Query(command DBCommandInterface) (*DBTable, error) {
+	query, args, err := b.Build(command.GetDialect())
+	if err != nil {
+		return nil, err
+	}
+
+	return command.Query(query, args...)
+}
+
+// QueryFirst runs the statement on the command and returns the first row.
+func (b *SelectBuilder) QueryFirst(command DBCommandInterface) (*DBRow, error) {
+	query, args, err := b.Build(command.GetDialect())
+	if err != nil {
+		return nil, err
+	}
+
+	return command.QueryFirst(query, args...)
+}
+
+func (b *SelectBuilder) join(kind string, table string, on string, args []interface{}) *SelectBuilder {
+	b.joins = append(b.joins, builderClause{sql: kind + " " + table + " ON " + on, args: args})
+	return b
+}
+
+// InsertBuilder builds INSERT statements with one or more rows.
+type InsertBuilder struct {
+	table     string
+	columns   []string
+	rows      [][]interface{}
+	returning []string
+}
+
+func Insert(table string) *InsertBuilder {
+	return &InsertBuilder{
+		table: table,
+	}
+}
+
+func (b *InsertBuilder) Columns(columns ...string) *InsertBuilder {
+	b.columns = append(b.columns, columns...)
+	return b
+}
+
+// Values adds a row; the values are in the order of the columns.
+func (b *InsertBuilder) Values(values ...interface{}) *InsertBuilder {
+	b.rows = append(b.rows, values)
+	return b
+}
+
+// Returning reads the columns of the inserted rows. It is written as RETURNING for PostgreSQL and SQLite
+// and OUTPUT INSERTED for SQL Server; MySQL does not support it.
+func (b *InsertBuilder) Returning(columns ...string) *InsertBuilder {
+	b.returning = append(b.returning, columns...)
+	return b
+}
+
+func (b *InsertBuilder) Build(dialect Dialect) (string, []interface{}, error) {
+	if b.table == "" {
+		return "", nil, db_errors.TableEmptyNameError()
+	}
+
+	if len(b.columns) == 0 || len(b.rows) == 0 {
+		return "", nil, db_errors.BuilderEmptyColumnsError()
+	}
+
+	args := make([]interface{}, 0, len(b.rows)*len(b.columns))
+	for _, row := range b.rows {
+		if len(row) != len(b.columns) {
+			return "", nil, db_errors.BuilderValueCountMismatchError()
+		}
+
+		args = append(args, row...)
+	}
+
+	if len(b.returning) > 0 && dialect != DialectPostgres && dialect != DialectSQLite && dialect != DialectSQLServer {
+		return "", nil, db_errors.BuilderReturningNotSupportedError()
+	}
+
+	var sb strings.Builder
+	sb.WriteString("INSERT INTO " + dialect.QuoteIdentifier(b.table) + " (" + joinQuoted(dialect, b.columns, "") + ")")
+	if len(b.returning) > 0 && dialect == DialectSQLServer {
+		sb.WriteString(" OUTPUT " + joinQuoted(dialect, b.returning, "INSERTED."))
+	}
+
+	sb.WriteString(" VALUES ")
+	writeValuesList(&sb, dialect, len(b.columns), len(b.rows), 0)
+
+	if len(b.returning) > 0 && dialect != DialectSQLServer {
+		sb.WriteString(" RETURNING " + joinQuoted(dialect, b.returning, ""))
+	}
+
+	return sb.String(), args, nil
+}
+
+func (b *InsertBuilder) Execute(command DBCommandInterface) (*sql.Result, error) {
+	query, args, err := b.Build(command.GetDialect())
+	if err != nil {
+		return nil, err
+	}
+
+	return command.Execute(query, args...)
+}
+
+// Query runs the statement and returns the Returning columns of the inserted rows.
+func (b *InsertBuilder) Query(command DBCommandInterface) (*DBTable, error) {
+	query, args, err := b.Build(command.GetDialect())
+	if err != nil {
+		return nil, err
+	}
+
+	return command.Query(query, args...)
+}
+
+// UpdateBuilder builds UPDATE statements.
+type UpdateBuilder struct {
+	table string
+	set   []builderAssignment
+	where []builderClause
+}
+
+type builderAssignment struct {
+	column     string
+	expression string
+	args       []interface{}
+}
+
+func Update(table string) *UpdateBuilder {
+	return &UpdateBuilder{
+		table: table,
+	}
+}
+
+// Set assigns the value to the column.
+func (b *UpdateBuilder) Set(column string, value interface{}) *UpdateBuilder {
+	b.set = append(b.set, builderAssignment{column: column, expression: "?", args: []interface{}{value}})
+	return b
+}
+
+// SetExpr assigns an expression such as "counter + ?" to the column.
+func (b *UpdateBuilder) SetExpr(column string, expression string, args ...interface{}) *UpdateBuilder {
+	b.set = append(b.set, builderAssignment{column: column, expression: expression, args: args})
+	return b
+}
+
+// Where adds a condition; multiple conditions are combined with AND.
+func (b *UpdateBuilder) Where(condition string, args ...interface{}) *UpdateBuilder {
+	b.where = append(b.where, builderClause{sql: condition, args: args})
+	return b
+}
+
+// WhereCriteria adds the criteria as a condition; nil criteria are skipped.
+func (b *UpdateBuilder) WhereCriteria(criteria db_criteria.Criteria) *UpdateBuilder {
+	if criteria != nil {
+		b.where = append(b.where, builderClause{criteria: criteria})
+	}
+
+	return b
+}
+
+func (b *UpdateBuilder) Build(dialect Dialect) (string, []interface{}, error) {
+	if b.table == "" {
+		return "", nil, db_errors.TableEmptyNameError()
+	}
+
+	if len(b.set) == 0 {
+		return "", nil, db_errors.BuilderEmptySetError()
+	}
+
+	var sb strings.Builder
+	args := make([]interface{}, 0)
+
+	sb.WriteString("UPDATE " + dialect.QuoteIdentifier(b.table) + " SET ")
+	for i, assignment := range b.set {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+
+		sb.WriteString(dialect.QuoteIdentifier(assignment.column) + " = " + assignment.expression)
+		args = append(args, assignment.args...)
+	}
+
+	args = writeConditions(&sb, dialect, " WHERE ", b.where, args)
+
+	return dialect.Rebind(sb.String()), args, nil
+}
+
+func (b *UpdateBuilder) Execute(command DBCommandInterface) (*sql.Result, error) {
+	query, args, err := b.Build(command.GetDialect())
+	if err != nil {
+		return nil, err
+	}
+
+	return command.Execute(query, args...)
+}
+
+// DeleteBuilder builds DELETE statements.
+type DeleteBuilder struct {
+	table string
+	where []builderClause
+}
+
+func Delete(table string) *DeleteBuilder {
+	return &DeleteBuilder{
+		table: table,
+	}
+}
+
+// Where adds a condition; multiple conditions are combined with AND.
+func (b *DeleteBuilder) Where(condition string, args ...interface{}) *DeleteBuilder {
+	b.where = append(b.where, builderClause{sql: condition, args: args})
+	return b
+}
+
+// WhereCriteria adds the criteria as a condition; nil criteria are skipped.
+func (b *DeleteBuilder) WhereCriteria(criteria db_criteria.Criteria) *DeleteBuilder {
+	if criteria != nil {
+		b.where = append(b.where, builderClause{criteria: criteria})
+	}
+
+	return b
+}
+
+func (b *DeleteBuilder) Build(dialect Dialect) (string, []interface{}, error) {
+	if b.table == "" {
+		return "", nil, db_errors.TableEmptyNameError()
+	}
+
+	var sb strings.Builder
+	sb.WriteString("DELETE FROM " + dialect.QuoteIdentifier(b.table))
+	args := writeConditions(&sb, dialect, " WHERE ", b.where, make([]interface{}, 0))
+
+	return dialect.Rebind(sb.String()), args, nil
+}
+
+func (b *DeleteBuilder) Execute(command DBCommandInterface) (*sql.Result, error) {
+	query, args, err := b.Build(command.GetDialect())
+	if err != nil {
+		return nil, err
+	}
+
+	return command.Execute(query, args...)
+}
+
+// writeConditions writes the conditions combined with AND after the keyword and appends their arguments.
+func writeConditions(sb *strings.Builder, dialect Dialect, keyword string, conditions []builderClause, args []interface{}) []interface{} {
+	if len(conditions) == 0 {
+		return args
+	}
+
+	sb.WriteString(keyword)
+	for i, condition := range conditions {
+		if i > 0 {
+			sb.WriteString(" AND ")
+		}
+
+		sql, conditionArgs := condition.sql, condition.args
+		if condition.criteria != nil {
+			sql, conditionArgs = condition.criteria.Build(dialect.QuoteIdentifier)
+		}
+
+		if len(conditions) > 1 {
+			sb.WriteString("(" + sql + ")")
+		} else {
+			sb.WriteString(sql)
+		}
+
+		args = append(args, conditionArgs...)
+	}
+
+	return args
+}
+
+// pagingSQL returns the paging clause with ? placeholders and its arguments; a negative limit means no limit.
+// SQL Server requires the clause to follow an ORDER BY clause.
+func pagingSQL(dialect Dialect, limit int, offset int) (string, []interface{}) {
+	if dialect == DialectSQLServer {
+		if limit < 0 {
+			return "OFFSET ? ROWS", []interface{}{offset}
+		}
+
+		return "OFFSET ? ROWS FETCH NEXT ? ROWS ONLY", []interface{}{offset, limit}
+	}
+
+	if limit < 0 {
+		// MySQL and SQLite require a limit before an offset.
+		switch dialect {
+		case DialectPostgres:
+			return "OFFSET ?", []interface{}{offset}
+		case DialectSQLite:
+			return "LIMIT -1 OFFSET ?", []interface{}{offset}
+		default:
+			return "LIMIT 18446744073709551615 OFFSET ?", []interface{}{offset}
+		}
+	}
+
+	return "LIMIT ? OFFSET ?", []interface{}{limit, offset}
+}
@@ -0,0 +1,156 @@
+package db
+
+import (
+	"reflect"
+	"testing"
+
+	db_errors "github.com/fatihtatoglu/db-go/error"
+)
+
+func TestSelectBuilder(t *testing.T) {
+	// Arrange
+	builder := Select("u.name", "COUNT(o.id) AS order_count").
+		From("users u").
+		LeftJoin("orders o", "o.user_id = u.id AND o.status = ?", "paid").
+		Where("u.age > ?", 18).
+		Where("u.name LIKE ?", "F%").
+		GroupBy("u.name").
+		Having("COUNT(o.id) > ?", 2).
+		OrderBy("order_count DESC").
+		Limit(10).
+		Offset(20)
+
+	// Test cases
+	testCases := []struct {
+		dialect       Dialect
+		expectedQuery string
+		expectedArgs  []interface{}
+	}{
+		{DialectMySQL, "SELECT u.name, COUNT(o.id) AS order_count FROM users u LEFT JOIN orders o ON o.user_id = u.id AND o.status = ?" +
+			" WHERE (u.age > ?) AND (u.name LIKE ?) GROUP BY u.name HAVING COUNT(o.id) > ? ORDER BY order_count DESC LIMIT ? OFFSET ?", []interface{}{"paid", 18, "F%", 2, 10, 20}},
+		{DialectPostgres, "SELECT u.name, COUNT(o.id) AS order_count FROM users u LEFT JOIN orders o ON o.user_id = u.id AND o.status = $1" +
+			" WHERE (u.age > $2) AND (u.name LIKE $3) GROUP BY u.name HAVING COUNT(o.id) > $4 ORDER BY order_count DESC LIMIT $5 OFFSET $6", []interface{}{"paid", 18, "F%", 2, 10, 20}},
+		{DialectSQLServer, "SELECT u.name, COUNT(o.id) AS order_count FROM users u LEFT JOIN orders o ON o.user_id = u.id AND o.status = @p1" +
+			" WHERE (u.age > @p2) AND (u.name LIKE @p3) GROUP BY u.name HAVING COUNT(o.id) > @p4 ORDER BY order_count DESC OFFSET @p5 ROWS FETCH NEXT @p6 ROWS ONLY", []interface{}{"paid", 18, "F%", 2, 20, 10}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.dialect), func(t *testing.T) {
+			// Act
+			query, args, err := builder.Build(tc.dialect)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if query != tc.expectedQuery {
+				t.Errorf("Expected query: %s, got: %s", tc.expectedQuery, query)
+			}
+
+			if !reflect.DeepEqual(args, tc.expectedArgs) {
+				t.Errorf("Expected args: %v, got: %v", tc.expectedArgs, args)
+			}
+		})
+	}
+}
+
+func TestSelectBuilder_Paging_Without_Order(t *testing.T) {
+	// Act
+	sqlServer, sqlServerArgs, _ := Select().From("users").Limit(5).Build(DialectSQLServer)
+	sqlite, sqliteArgs, _ := Select().From("users").Offset(5).Build(DialectSQLite)
+
+	// Assert
+	if sqlServer != "SELECT * FROM users ORDER BY (SELECT NULL) OFFSET @p1 ROWS FETCH NEXT @p2 ROWS ONLY" || !reflect.DeepEqual(sqlServerArgs, []interface{}{0, 5}) {
+		t.Errorf("Unexpected SQL Server query: %s %v", sqlServer, sqlServerArgs)
+	}
+
+	if sqlite != "SELECT * FROM users LIMIT -1 OFFSET ?" || !reflect.DeepEqual(sqliteArgs, []interface{}{5}) {
+		t.Errorf("Unexpected SQLite query: %s %v", sqlite, sqliteArgs)
+	}
+}
+
+func TestInsertBuilder(t *testing.T) {
+	// Arrange
+	builder := Insert("users").Columns("name", "age").Values("Fatih", 39).Values("Ali", 20).Returning("id")
+
+	// Act
+	postgres, args, err := builder.Build(DialectPostgres)
+	sqlServer, _, _ := builder.Build(DialectSQLServer)
+	_, _, mysqlErr := builder.Build(DialectMySQL)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if postgres != `INSERT INTO "users" ("name", "age") VALUES ($1, $2), ($3, $4) RETURNING "id"` {
+		t.Errorf("Unexpected PostgreSQL query: %s", postgres)
+	}
+
+	if sqlServer != "INSERT INTO [users] ([name], [age]) OUTPUT INSERTED.[id] VALUES (@p1, @p2), (@p3, @p4)" {
+		t.Errorf("Unexpected SQL Server query: %s", sqlServer)
+	}
+
+	if len(args) != 4 {
+		t.Errorf("Expected 4 arguments, got: %d", len(args))
+	}
+
+	assertError(t, mysqlErr, db_errors.Builder_ReturningNotSupportedErrorMessage)
+}
+
+func TestUpdateBuilder(t *testing.T) {
+	// Arrange
+	builder := Update("users").Set("name", "Fatih").SetExpr("visits", "visits + ?", 1).Where("id = ?", 7)
+
+	// Act
+	query, args, err := builder.Build(DialectPostgres)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if query != `UPDATE "users" SET "name" = $1, "visits" = visits + $2 WHERE id = $3` {
+		t.Errorf("Unexpected query: %s", query)
+	}
+
+	if !reflect.DeepEqual(args, []interface{}{"Fatih", 1, 7}) {
+		t.Errorf("Unexpected args: %v", args)
+	}
+}
+
+func TestDeleteBuilder(t *testing.T) {
+	// Act
+	query, args, _ := Delete("users").Where("id = ?", 7).Build(DialectMySQL)
+
+	// Assert
+	if query != "DELETE FROM `users` WHERE id = ?" || len(args) != 1 {
+		t.Errorf("Unexpected query: %s %v", query, args)
+	}
+}
+
+func TestBuilder_Errors(t *testing.T) {
+	// Test cases
+	testCases := []struct {
+		testName       string
+		builder        SQLBuilder
+		expectedErrMsg string
+	}{
+		{"Insert without table", Insert("").Columns("name").Values("x"), db_errors.Table_EmptyNameErrorMessage},
+		{"Insert without columns", Insert("users"), db_errors.Builder_EmptyColumnsErrorMessage},
+		{"Insert with missing values", Insert("users").Columns("name", "age").Values("x"), db_errors.Builder_ValueCountMismatchErrorMessage},
+		{"Update without set", Update("users"), db_errors.Builder_EmptySetErrorMessage},
+		{"Delete without table", Delete(""), db_errors.Table_EmptyNameErrorMessage},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			// Act
+			_, _, err := tc.builder.Build(DialectMySQL)
+
+			// Assert
+			assertError(t, err, tc.expectedErrMsg)
+		})
+	}
+}
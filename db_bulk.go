@@ -0,0 +1,274 @@
+package db
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+
+	db_errors "github.com/fatihtatoglu/db-go/error"
+)
+
+const (
+	// copyInDriver is the driver name of lib/pq, which streams COPY ... FROM STDIN through a prepared statement.
+	copyInDriver = "postgres"
+)
+
+// BulkOption configures BulkInsert.
+type BulkOption func(*bulkOptions)
+
+type bulkOptions struct {
+	chunkSize       int
+	disableFastPath bool
+}
+
+// WithBulkChunkSize limits the number of rows sent by a single INSERT statement.
+// The rows are always chunked under the placeholder limit of the dialect.
+func WithBulkChunkSize(rows int) BulkOption {
+	return func(o *bulkOptions) {
+		o.chunkSize = rows
+	}
+}
+
+// WithoutBulkFastPath disables the dialect specific loaders and always uses multi-row INSERT statements.
+func WithoutBulkFastPath() BulkOption {
+	return func(o *bulkOptions) {
+		o.disableFastPath = true
+	}
+}
+
+// bulkData is the column names and row values of a bulk source.
+type bulkData struct {
+	columns []string
+	rows    [][]interface{}
+	// autoColumns are the auto increment columns kept in the columns to match the existing rows.
+	autoColumns []string
+}
+
+// BulkInsert inserts the rows of a *DBTable, a struct or a slice of structs into the table and returns the
+// number of inserted rows. The rows are sent by multi-row INSERT statements inside one transaction.
+func (cmd *dbCommand) BulkInsert(tableName string, source interface{}, options ...BulkOption) (int64, error) {
+	if tableName == "" {
+		return 0, db_errors.TableEmptyNameError()
+	}
+
+	opts := bulkOptions{}
+	for _, option := range options {
+		option(&opts)
+	}
+
+	data, err := readBulkData(source, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(data.rows) == 0 {
+		return 0, nil
+	}
+
+	dialect := cmd.connection.GetDialect()
+	useCopy := !opts.disableFastPath && cmd.connection.getDriverName() == copyInDriver
+
+	var affected int64
+	err = cmd.inTransaction(func(t *dbCommand) error {
+		if useCopy {
+			return t.copyIn(tableName, data, &affected)
+		}
+
+		rowsPerStatement := bulkRowsPerStatement(dialect, len(data.columns), opts.chunkSize)
+		for start := 0; start < len(data.rows); start += rowsPerStatement {
+			end := min(start+rowsPerStatement, len(data.rows))
+
+			query := buildInsertSQL(dialect, tableName, data.columns, end-start)
+			args := make([]interface{}, 0, (end-start)*len(data.columns))
+			for _, row := range data.rows[start:end] {
+				args = append(args, row...)
+			}
+
+			res, err := t.Execute(query, args...)
+			if err != nil {
+				return err
+			}
+
+			n, err := (*res).RowsAffected()
+			if err == nil {
+				affected += n
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return affected, nil
+}
+
+func (cmd *dbCommand) copyIn(tableName string, data *bulkData, affected *int64) error {
+	dialect := cmd.connection.GetDialect()
+
+	quoted := make([]string, len(data.columns))
+	for i, column := range data.columns {
+		quoted[i] = dialect.QuoteIdentifier(column)
+	}
+
+	stmt, err := cmd.tx.PrepareContext(cmd.ctx, "COPY "+dialect.QuoteIdentifier(tableName)+" ("+strings.Join(quoted, ", ")+") FROM STDIN")
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, row := range data.rows {
+		_, err = stmt.ExecContext(cmd.ctx, row...)
+		if err != nil {
+			return err
+		}
+	}
+
+	// An empty exec flushes the buffered rows.
+	_, err = stmt.ExecContext(cmd.ctx)
+	if err != nil {
+		return err
+	}
+
+	*affected = int64(len(data.rows))
+	return nil
+}
+
+func bulkRowsPerStatement(dialect Dialect, columnCount int, chunkSize int) int {
+	rows := dialect.maxParameters() / max(columnCount, 1)
+	if maxRows := dialect.maxInsertRows(); maxRows > 0 && rows > maxRows {
+		rows = maxRows
+	}
+
+	if chunkSize > 0 && chunkSize < rows {
+		rows = chunkSize
+	}
+
+	return max(rows, 1)
+}
+
+func buildInsertSQL(dialect Dialect, tableName string, columns []string, rowCount int) string {
+	var b strings.Builder
+	b.WriteString("INSERT INTO ")
+	b.WriteString(dialect.QuoteIdentifier(tableName))
+	b.WriteString(" (")
+	for i, column := range columns {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+
+		b.WriteString(dialect.QuoteIdentifier(column))
+	}
+
+	b.WriteString(") VALUES ")
+	writeValuesList(&b, dialect, len(columns), rowCount, 0)
+
+	return b.String()
+}
+
+// writeValuesList writes rowCount parenthesized placeholder groups, numbering the placeholders after offset.
+func writeValuesList(b *strings.Builder, dialect Dialect, columnCount int, rowCount int, offset int) {
+	index := offset
+	for r := 0; r < rowCount; r++ {
+		if r > 0 {
+			b.WriteString(", ")
+		}
+
+		b.WriteString("(")
+		for c := 0; c < columnCount; c++ {
+			if c > 0 {
+				b.WriteString(", ")
+			}
+
+			index++
+			b.WriteString(dialect.Placeholder(index))
+		}
+		b.WriteString(")")
+	}
+}
+
+// readBulkData reads the columns and rows of a DBTable, a struct or a slice of structs.
+// Fields marked with the auto option are left to the database unless their columns are kept.
+func readBulkData(source interface{}, keepColumns []string) (*bulkData, error) {
+	switch dt := source.(type) {
+	case *DBTable:
+		return readBulkDataFromTable(dt), nil
+	case DBTable:
+		return readBulkDataFromTable(&dt), nil
+	}
+
+	v := reflect.ValueOf(source)
+	if !v.IsValid() {
+		return nil, db_errors.BulkInvalidSourceError()
+	}
+
+	elementType := v.Type()
+	if v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+		elementType = v.Type().Elem()
+	}
+
+	metadata, err := getEntityMetadata(elementType)
+	if err != nil {
+		return nil, db_errors.BulkInvalidSourceError()
+	}
+
+	fields := make([]*entityField, 0, len(metadata.fields))
+	autoColumns := make([]string, 0)
+	for _, f := range metadata.fields {
+		if !f.isAutoIncrement() {
+			fields = append(fields, f)
+			continue
+		}
+
+		if slices.Contains(keepColumns, f.column) {
+			fields = append(fields, f)
+			autoColumns = append(autoColumns, f.column)
+		}
+	}
+
+	data := &bulkData{
+		columns:     columnNames(fields),
+		rows:        make([][]interface{}, 0),
+		autoColumns: autoColumns,
+	}
+
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		sv, err := metadata.structValue(source)
+		if err != nil {
+			return nil, err
+		}
+
+		data.rows = append(data.rows, metadata.values(sv, fields))
+		return data, nil
+	}
+
+	for i := 0; i < v.Len(); i++ {
+		sv, err := metadata.structValue(v.Index(i).Interface())
+		if err != nil {
+			return nil, err
+		}
+
+		data.rows = append(data.rows, metadata.values(sv, fields))
+	}
+
+	return data, nil
+}
+
+func readBulkDataFromTable(dt *DBTable) *bulkData {
+	data := &bulkData{
+		columns: make([]string, len(dt.columns)),
+		rows:    make([][]interface{}, len(dt.rows)),
+	}
+
+	for i, column := range dt.columns {
+		data.columns[i] = column.name
+	}
+
+	for i, row := range dt.rows {
+		data.rows[i] = row.itemArray
+	}
+
+	return data
+}
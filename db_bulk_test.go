@@ -0,0 +1,195 @@
+package db
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	db_errors "github.com/fatihtatoglu/db-go/error"
+)
+
+func TestBuildInsertSQL(t *testing.T) {
+	// Test cases
+	testCases := []struct {
+		dialect       Dialect
+		expectedQuery string
+	}{
+		{DialectMySQL, "INSERT INTO `users` (`name`, `age`) VALUES (?, ?), (?, ?)"},
+		{DialectPostgres, `INSERT INTO "users" ("name", "age") VALUES ($1, $2), ($3, $4)`},
+		{DialectSQLServer, "INSERT INTO [users] ([name], [age]) VALUES (@p1, @p2), (@p3, @p4)"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.dialect), func(t *testing.T) {
+			// Act
+			query := buildInsertSQL(tc.dialect, "users", []string{"name", "age"}, 2)
+
+			// Assert
+			if query != tc.expectedQuery {
+				t.Errorf("Expected query: %s, got: %s", tc.expectedQuery, query)
+			}
+		})
+	}
+}
+
+func TestBulkRowsPerStatement(t *testing.T) {
+	// Test cases
+	testCases := []struct {
+		testName     string
+		dialect      Dialect
+		columnCount  int
+		chunkSize    int
+		expectedRows int
+	}{
+		{"MySQL placeholder limit", DialectMySQL, 10, 0, 6553},
+		{"SQL Server placeholder limit", DialectSQLServer, 10, 0, 209},
+		{"SQL Server placeholder boundary", DialectSQLServer, 3, 0, 699},
+		{"SQL Server row limit", DialectSQLServer, 1, 0, 1000},
+		{"Chunk size", DialectPostgres, 10, 100, 100},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			// Act
+			rows := bulkRowsPerStatement(tc.dialect, tc.columnCount, tc.chunkSize)
+
+			// Assert
+			if rows != tc.expectedRows {
+				t.Errorf("Expected rows: %d, got: %d", tc.expectedRows, rows)
+			}
+		})
+	}
+}
+
+func TestReadBulkData_Structs(t *testing.T) {
+	// Arrange
+	users := []*testUser{
+		{ID: 1, FirstName: "Fatih", Age: 39},
+		{ID: 2, FirstName: "Ali", Age: 20},
+	}
+
+	// Act
+	data, err := readBulkData(users, nil)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(data.columns, []string{"created_at", "first_name", "age"}) {
+		t.Errorf("Unexpected columns: %v", data.columns)
+	}
+
+	if len(data.rows) != 2 || data.rows[1][1] != "Ali" || data.rows[1][2] != 20 {
+		t.Errorf("Unexpected rows: %v", data.rows)
+	}
+}
+
+func TestReadBulkData_Table(t *testing.T) {
+	// Arrange
+	dt := createTestTableWithColumns([]string{"name", "age"})
+	row := dt.CreateNewDBRow()
+	row.SetItemByIndex(0, "Fatih")
+	row.SetItemByIndex(1, 39)
+	dt.AddDBRow(row)
+
+	// Act
+	data, err := readBulkData(dt, nil)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(data.columns, []string{"name", "age"}) || len(data.rows) != 1 {
+		t.Errorf("Unexpected data: %v %v", data.columns, data.rows)
+	}
+}
+
+func TestReadBulkData_Invalid_Source(t *testing.T) {
+	// Act
+	_, err := readBulkData([]int{1, 2}, nil)
+
+	// Assert
+	assertError(t, err, db_errors.Bulk_InvalidSourceErrorMessage)
+}
+
+func TestDBCommand_BulkInsert(t *testing.T) {
+	// Arrange
+	type user struct {
+		Name string `db:"name"`
+		Age  int    `db:"age"`
+	}
+
+	command, mock := createTestMockCommand(t, DialectMySQL)
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `users` (`name`, `age`) VALUES (?, ?), (?, ?)").WithArgs("Ann", 30, "Bob", 40).WillReturnResult(0, 2)
+	mock.ExpectExec("INSERT INTO `users` (`name`, `age`) VALUES (?, ?)").WithArgs("Cid", 50).WillReturnResult(0, 1)
+	mock.ExpectCommit()
+
+	// Act
+	affected, err := command.BulkInsert("users", []user{{"Ann", 30}, {"Bob", 40}, {"Cid", 50}}, WithBulkChunkSize(2))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if affected != 3 {
+		t.Errorf("Expected inserted rows: 3, got: %d", affected)
+	}
+
+	assertExpectationsWereMet(t, mock)
+}
+
+func TestDBCommand_BulkInsert_Rollback(t *testing.T) {
+	// Arrange
+	command, mock := createTestMockCommand(t, DialectPostgres)
+	failure := errors.New(`relation "users" does not exist`)
+	source := createTestTable([]string{"name"}, []interface{}{"Ann"})
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO "users" ("name") VALUES ($1)`).WithArgs("Ann").WillReturnError(failure)
+	mock.ExpectRollback()
+
+	// Act
+	affected, err := command.BulkInsert("users", source)
+
+	// Assert
+	if err != failure || affected != 0 {
+		t.Errorf("Expected the error of the driver, got: %d %v", affected, err)
+	}
+
+	assertExpectationsWereMet(t, mock)
+}
+
+func TestDBCommand_BulkInsert_SQLServer_Parameter_Limit(t *testing.T) {
+	// Arrange
+	rows := make([][]interface{}, 0, 700)
+	for i := 0; i < 700; i++ {
+		rows = append(rows, []interface{}{i, i, i})
+	}
+
+	// 700 rows of 3 columns need 2100 parameters, which is over the limit of a single statement.
+	source := createTestTable([]string{"a", "b", "c"}, rows...)
+
+	command, mock := createTestMockCommand(t, DialectSQLServer)
+	mock.ExpectBegin()
+	mock.ExpectExecRegexp(`^INSERT INTO \[t\] .* VALUES \(@p1, @p2, @p3\), .*\(@p2095, @p2096, @p2097\)$`).WillReturnResult(0, 699)
+	mock.ExpectExecRegexp(`^INSERT INTO \[t\] \(\[a\], \[b\], \[c\]\) VALUES \(@p1, @p2, @p3\)$`).WillReturnResult(0, 1)
+	mock.ExpectCommit()
+
+	// Act
+	affected, err := command.BulkInsert("t", source)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if affected != 700 {
+		t.Errorf("Expected inserted rows: 700, got: %d", affected)
+	}
+
+	assertExpectationsWereMet(t, mock)
+}
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"reflect"
+	"time"
 )
 
 type DBColumn struct {
@@ -15,7 +16,35 @@ type DBColumn struct {
 	scale     int64
 	length    int64
 	nullable  bool
-	Table     *DBTable
+	// defaultValue is the default expression of a schema column, nil when the column has no default.
+	defaultValue  *string
+	autoIncrement bool
+	Table         *DBTable
+}
+
+// DBColumnOption sets the optional schema metadata of a column.
+type DBColumnOption func(*DBColumn)
+
+// WithColumnPrecision sets the precision and the scale of a numeric column.
+func WithColumnPrecision(precision int64, scale int64) DBColumnOption {
+	return func(c *DBColumn) {
+		c.precision = precision
+		c.scale = scale
+	}
+}
+
+// WithColumnDefault sets the default expression of the column as it is written in the schema.
+func WithColumnDefault(value string) DBColumnOption {
+	return func(c *DBColumn) {
+		c.defaultValue = &value
+	}
+}
+
+// WithColumnAutoIncrement marks the column as generated by the database.
+func WithColumnAutoIncrement() DBColumnOption {
+	return func(c *DBColumn) {
+		c.autoIncrement = true
+	}
 }
 
 func CreateNewDBColumn(name string, ordinal int) DBColumn {
@@ -54,6 +83,23 @@ func CreateNewDBColumnFromColumnType(ct sql.ColumnType, ordinal int) DBColumn {
 	return col
 }
 
+// CreateNewDBColumnFromSchema creates a column described by the schema metadata of a table.
+func CreateNewDBColumnFromSchema(name string, ordinal int, dbType string, length int64, nullable bool, options ...DBColumnOption) DBColumn {
+	col := DBColumn{
+		name:     name,
+		ordinal:  ordinal,
+		dBType:   dbType,
+		length:   length,
+		nullable: nullable,
+	}
+
+	for _, option := range options {
+		option(&col)
+	}
+
+	return col
+}
+
 func (cd *DBColumn) GetName() string {
 	return cd.name
 }
@@ -86,11 +132,29 @@ func (cd *DBColumn) GetNullable() bool {
 	return cd.nullable
 }
 
+// GetDefaultValue returns the default expression of a schema column and whether the column has one.
+func (cd *DBColumn) GetDefaultValue() (string, bool) {
+	if cd.defaultValue == nil {
+		return "", false
+	}
+
+	return *cd.defaultValue, true
+}
+
+func (cd *DBColumn) GetAutoIncrement() bool {
+	return cd.autoIncrement
+}
+
 func (dc *DBColumn) MarshalJSON() ([]byte, error) {
+	appType := ""
+	if dc.appType != nil {
+		appType = dc.appType.String()
+	}
+
 	columnMap := map[string]interface{}{
 		"name":      dc.name,
 		"ordinal":   dc.ordinal,
-		"appType":   dc.appType.String(),
+		"appType":   appType,
 		"dBType":    dc.dBType,
 		"precision": dc.precision,
 		"scale":     dc.scale,
@@ -98,7 +162,66 @@ func (dc *DBColumn) MarshalJSON() ([]byte, error) {
 		"nullable":  dc.nullable,
 	}
 
+	if dc.defaultValue != nil {
+		columnMap["defaultValue"] = *dc.defaultValue
+	}
+
+	if dc.autoIncrement {
+		columnMap["autoIncrement"] = true
+	}
+
 	return json.Marshal(columnMap)
 }
 
-// TODO: UnmarshalJSON methodunu ekle.
+// UnmarshalJSON reads the column written by MarshalJSON. The appType is resolved for the
+// types scanned by the common drivers; other types are left empty.
+func (dc *DBColumn) UnmarshalJSON(data []byte) error {
+	var column struct {
+		Name      string `json:"name"`
+		Ordinal   int    `json:"ordinal"`
+		AppType   string `json:"appType"`
+		DBType    string `json:"dBType"`
+		Precision int64  `json:"precision"`
+		Scale     int64  `json:"scale"`
+		Length    int64  `json:"length"`
+		Nullable  bool   `json:"nullable"`
+
+		DefaultValue  *string `json:"defaultValue"`
+		AutoIncrement bool    `json:"autoIncrement"`
+	}
+
+	err := json.Unmarshal(data, &column)
+	if err != nil {
+		return err
+	}
+
+	dc.name = column.Name
+	dc.ordinal = column.Ordinal
+	dc.appType = columnAppTypes[column.AppType]
+	dc.dBType = column.DBType
+	dc.precision = column.Precision
+	dc.scale = column.Scale
+	dc.length = column.Length
+	dc.nullable = column.Nullable
+	dc.defaultValue = column.DefaultValue
+	dc.autoIncrement = column.AutoIncrement
+
+	return nil
+}
+
+var columnAppTypes = map[string]reflect.Type{}
+
+func init() {
+	types := []interface{}{
+		int8(0), int16(0), int32(0), int64(0), int(0),
+		uint8(0), uint16(0), uint32(0), uint64(0), uint(0),
+		float32(0), float64(0), false, "", []byte(nil), time.Time{},
+		sql.NullBool{}, sql.NullByte{}, sql.NullFloat64{}, sql.NullInt16{}, sql.NullInt32{},
+		sql.NullInt64{}, sql.NullString{}, sql.NullTime{}, sql.RawBytes(nil), new(interface{}),
+	}
+
+	for _, value := range types {
+		t := reflect.TypeOf(value)
+		columnAppTypes[t.String()] = t
+	}
+}
@@ -0,0 +1,57 @@
+package db
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestDBColumn_JSON(t *testing.T) {
+	// Arrange
+	column := CreateNewDBColumn("email", 2)
+	column.appType = reflect.TypeOf("")
+	column.dBType = "VARCHAR"
+	column.length = 255
+	column.nullable = true
+
+	// Act
+	data, err := json.Marshal(&column)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var actual DBColumn
+	err = json.Unmarshal(data, &actual)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(actual, column) {
+		t.Errorf("Expected: %+v, got: %+v", column, actual)
+	}
+}
+
+func TestDBColumn_MarshalJSONWithoutAppType(t *testing.T) {
+	// Arrange
+	column := CreateNewDBColumnFromSchema("id", 1, "bigint", 0, false, WithColumnDefault("0"), WithColumnAutoIncrement())
+
+	// Act
+	data, err := json.Marshal(&column)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var actual DBColumn
+	err = json.Unmarshal(data, &actual)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(actual, column) || actual.GetType() != nil {
+		t.Errorf("Expected the schema column, got: %+v", actual)
+	}
+}
@@ -3,7 +3,9 @@ package db
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"errors"
+	"io"
 )
 
 const (
@@ -11,14 +13,46 @@ const (
 )
 
 type DBCommandInterface interface {
+	GetDialect() Dialect
+	GetContext() context.Context
+	WithContext(ctx context.Context) DBCommandInterface
 	Execute(query string, params ...interface{}) (*sql.Result, error)
 	Query(query string, params ...interface{}) (*DBTable, error)
 	QueryFirst(query string, params ...interface{}) (*DBRow, error)
+	CallProcedure(name string, params ...ProcedureParameter) (*ProcedureResult, error)
+	Prepare(query string) (DBPreparedCommandInterface, error)
+	Begin() (DBTransactionInterface, error)
+	ExecuteBatch(query string, paramSets [][]interface{}, options ...BatchOption) (*BatchResult, error)
+	ExecuteScript(script io.Reader, options ...ScriptOption) (*ScriptResult, error)
+	BulkInsert(tableName string, source interface{}, options ...BulkOption) (int64, error)
+	Upsert(tableName string, conflictColumns []string, updateColumns []string, values interface{}) (int64, error)
 }
 
 type dbCommand struct {
 	connection DBConnectionInterface
 	ctx        context.Context
+	tx         *sql.Tx
+	conn       *sql.Conn
+}
+
+// sessionPinner is implemented by the commands which can bind one connection of the pool, so the state
+// of the session like locks and variables is kept between the statements.
+type sessionPinner interface {
+	// pin returns a command bound to a single connection and the function which releases the connection.
+	pin() (DBCommandInterface, func() error, error)
+}
+
+// sessionDiscarder is implemented by the pinned commands whose connection can be dropped instead of being
+// returned to the pool, e.g. when the connection may still hold a session lock.
+type sessionDiscarder interface {
+	// discard marks the pinned connection as broken, so its release closes it.
+	discard() error
+}
+
+// sqlExecutor is satisfied by *sql.DB, *sql.Conn and *sql.Tx.
+type sqlExecutor interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
 }
 
 func CreateNewDBCommand(con DBConnectionInterface) (DBCommandInterface, error) {
@@ -32,14 +66,40 @@ func CreateNewDBCommand(con DBConnectionInterface) (DBCommandInterface, error) {
 	}, nil
 }
 
+func (cmd *dbCommand) GetDialect() Dialect {
+	return cmd.connection.GetDialect()
+}
+
+func (cmd *dbCommand) GetContext() context.Context {
+	return cmd.ctx
+}
+
+// WithContext returns a command which runs the statements with the context.
+func (cmd *dbCommand) WithContext(ctx context.Context) DBCommandInterface {
+	return &dbCommand{
+		connection: cmd.connection,
+		ctx:        ctx,
+		tx:         cmd.tx,
+		conn:       cmd.conn,
+	}
+}
+
 func (cmd *dbCommand) Execute(query string, params ...interface{}) (*sql.Result, error) {
-	err := cmd.connection.Open()
+	err := cmd.open()
 	if err != nil {
 		return nil, err
 	}
-	defer cmd.connection.Close()
+	defer cmd.close()
+
+	var res sql.Result
+	stmt, release := cmd.statement(query)
+	if stmt != nil {
+		res, err = stmt.ExecContext(cmd.ctx, params...)
+		release()
+	} else {
+		res, err = cmd.executor().ExecContext(cmd.ctx, query, params...)
+	}
 
-	res, err := cmd.connection.getConnection().ExecContext(cmd.ctx, query, params...)
 	if err != nil {
 		return nil, err
 	}
@@ -67,18 +127,135 @@ func (cmd *dbCommand) QueryFirst(query string, params ...interface{}) (*DBRow, e
 
 // Ref: https://stackoverflow.com/a/17885636
 func (cmd *dbCommand) query(returnSingleRow bool, query string, params ...interface{}) (*DBTable, error) {
-	err := cmd.connection.Open()
+	err := cmd.open()
 	if err != nil {
 		return nil, err
 	}
-	defer cmd.connection.Close()
+	defer cmd.close()
+
+	var rows *sql.Rows
+	stmt, release := cmd.statement(query)
+	if stmt != nil {
+		defer release()
+		rows, err = stmt.QueryContext(cmd.ctx, params...)
+	} else {
+		rows, err = cmd.executor().QueryContext(cmd.ctx, query, params...)
+	}
 
-	rows, err := cmd.connection.getConnection().QueryContext(cmd.ctx, query, params...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
+	return readTable(rows, returnSingleRow)
+}
+
+// pin binds a connection of the pool to a new command. A transaction is already bound to a single
+// connection and returns itself.
+func (cmd *dbCommand) pin() (DBCommandInterface, func() error, error) {
+	if cmd.tx != nil || cmd.conn != nil {
+		return cmd, func() error { return nil }, nil
+	}
+
+	err := cmd.connection.Open()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	conn, err := cmd.connection.getConnection().Conn(cmd.ctx)
+	if err != nil {
+		cmd.connection.Close()
+		return nil, nil, err
+	}
+
+	pinned := &dbCommand{
+		connection: cmd.connection,
+		ctx:        cmd.ctx,
+		conn:       conn,
+	}
+
+	release := func() error {
+		defer cmd.connection.Close()
+		return conn.Close()
+	}
+
+	return pinned, release, nil
+}
+
+// discard makes database/sql close the pinned connection when it is released: a driver.ErrBadConn
+// returned from Raw marks the connection as broken.
+func (cmd *dbCommand) discard() error {
+	if cmd.conn == nil {
+		return nil
+	}
+
+	err := cmd.conn.Raw(func(interface{}) error {
+		return driver.ErrBadConn
+	})
+
+	if errors.Is(err, driver.ErrBadConn) {
+		return nil
+	}
+
+	return err
+}
+
+// open opens the connection unless the command is bound to a transaction or a pinned connection,
+// which keep the connection open until they are finished.
+func (cmd *dbCommand) open() error {
+	if cmd.tx != nil || cmd.conn != nil {
+		return nil
+	}
+
+	return cmd.connection.Open()
+}
+
+func (cmd *dbCommand) close() error {
+	if cmd.tx != nil || cmd.conn != nil {
+		return nil
+	}
+
+	return cmd.connection.Close()
+}
+
+func (cmd *dbCommand) executor() sqlExecutor {
+	if cmd.tx != nil {
+		return cmd.tx
+	}
+
+	if cmd.conn != nil {
+		return cmd.conn
+	}
+
+	return cmd.connection.getConnection()
+}
+
+// statement returns the cached prepared statement of the query and the function which must be called
+// once the statement is no longer used. Inside a transaction the cached statement is rebound to the transaction.
+// When the cache is disabled, the command is bound to a pinned connection or the query cannot be prepared,
+// nil is returned and the query is sent to the database as is.
+func (cmd *dbCommand) statement(query string) (*sql.Stmt, func()) {
+	if cmd.conn != nil {
+		return nil, nil
+	}
+
+	stmt, release, err := cmd.connection.prepare(cmd.ctx, query)
+	if err != nil || stmt == nil {
+		return nil, nil
+	}
+
+	if cmd.tx != nil {
+		txStmt := cmd.tx.StmtContext(cmd.ctx, stmt)
+		return txStmt, func() {
+			txStmt.Close()
+			release()
+		}
+	}
+
+	return stmt, release
+}
+
+func readTable(rows *sql.Rows, returnSingleRow bool) (*DBTable, error) {
 	columnTypes, _ := rows.ColumnTypes()
 
 	dt := CreateNewDBTable()
@@ -112,5 +289,30 @@ func (cmd *dbCommand) query(returnSingleRow bool, query string, params ...interf
 		}
 	}
 
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
 	return &dt, nil
 }
+
+// readResultSets reads every result set of the rows, skipping the ones without columns.
+func readResultSets(rows *sql.Rows) ([]*DBTable, error) {
+	resultSets := make([]*DBTable, 0)
+	for {
+		dt, err := readTable(rows, false)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(dt.columns) > 0 {
+			resultSets = append(resultSets, dt)
+		}
+
+		if !rows.NextResultSet() {
+			break
+		}
+	}
+
+	return resultSets, rows.Err()
+}
@@ -0,0 +1,272 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	db_errors "github.com/fatihtatoglu/db-go/error"
+	db_mock "github.com/fatihtatoglu/db-go/mock"
+)
+
+// createTestMockCommand returns a command of the dialect on the fake driver of a new mock.
+func createTestMockCommand(t *testing.T, dialect Dialect) (DBCommandInterface, *db_mock.Mock) {
+	mock := db_mock.CreateNewMock()
+	RegisterDriverDialect(mock.GetDriverName(), dialect)
+
+	connection, err := CreateNewDBConnection(mock.GetDriverName(), mock.GetDSN())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	command, err := CreateNewDBCommand(connection)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	return command, mock
+}
+
+func assertExpectationsWereMet(t *testing.T, mock *db_mock.Mock) {
+	t.Helper()
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestDBCommand_Query(t *testing.T) {
+	// Arrange
+	command, mock := createTestMockCommand(t, DialectPostgres)
+	mock.ExpectQuery(`SELECT "id", "name" FROM "users" WHERE "active" = $1`).
+		WithArgs(true).
+		WillReturnRows(db_mock.CreateNewRows("id", "name").WithDatabaseTypes("INT8", "TEXT").AddRow(1, []byte("Ann")).AddRow(2, "Bob"))
+
+	// Act
+	table, err := command.Query(`SELECT "id", "name" FROM "users" WHERE "active" = $1`, true)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(table.GetColumns()) != 2 || table.GetColumns()[0].GetName() != "id" || table.GetColumns()[0].GetDBType() != "INT8" {
+		t.Errorf("Unexpected columns: %v", table.GetColumns())
+	}
+
+	values := make([][]interface{}, 0)
+	for _, row := range table.GetRows() {
+		values = append(values, row.itemArray)
+	}
+
+	expected := [][]interface{}{{int64(1), "Ann"}, {int64(2), "Bob"}}
+	if !reflect.DeepEqual(values, expected) {
+		t.Errorf("Expected %v, got: %v", expected, values)
+	}
+
+	assertExpectationsWereMet(t, mock)
+}
+
+func TestDBCommand_Query_RowError(t *testing.T) {
+	// Arrange
+	command, mock := createTestMockCommand(t, DialectPostgres)
+	failure := errors.New("canceling statement due to conflict with recovery")
+	mock.ExpectQuery(`SELECT "id" FROM "users"`).
+		WillReturnRows(db_mock.CreateNewRows("id").AddRow(1).AddRow(2).RowError(1, failure))
+
+	// Act
+	table, err := command.Query(`SELECT "id" FROM "users"`)
+
+	// Assert
+	if err != failure {
+		t.Errorf("Expected the row error, got: %v", err)
+	}
+
+	if table != nil {
+		t.Errorf("Expected no rows, got: %v", table.GetRows())
+	}
+
+	assertExpectationsWereMet(t, mock)
+}
+
+func TestDBCommand_QueryFirst(t *testing.T) {
+	// Arrange
+	command, mock := createTestMockCommand(t, DialectMySQL)
+	mock.ExpectQuery("SELECT name FROM users ORDER BY name").
+		WillReturnRows(db_mock.CreateNewRows("name").AddRow("Ann").AddRow("Bob"))
+
+	// Act
+	row, err := command.QueryFirst("SELECT name FROM users ORDER BY name")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(row.itemArray, []interface{}{"Ann"}) {
+		t.Errorf("Expected the first row, got: %v", row.itemArray)
+	}
+}
+
+func TestDBCommand_Execute(t *testing.T) {
+	// Arrange
+	command, mock := createTestMockCommand(t, DialectMySQL)
+	mock.ExpectExec("INSERT INTO users (name, age) VALUES (?, ?)").WithArgs("Ann", 30).WillReturnResult(7, 1)
+
+	// Act
+	result, err := command.Execute("INSERT INTO users (name, age) VALUES (?, ?)", "Ann", 30)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	id, _ := (*result).LastInsertId()
+	affected, _ := (*result).RowsAffected()
+	if id != 7 || affected != 1 {
+		t.Errorf("Expected the result of the driver, got: %d %d", id, affected)
+	}
+
+	assertExpectationsWereMet(t, mock)
+}
+
+func TestDBCommand_Execute_Error(t *testing.T) {
+	// Arrange
+	command, mock := createTestMockCommand(t, DialectSQLite)
+	failure := errors.New("UNIQUE constraint failed: users.name")
+	mock.ExpectExecRegexp(`^INSERT INTO users`).WillReturnError(failure)
+
+	// Act
+	_, err := command.Execute("INSERT INTO users (name) VALUES (?)", "Ann")
+	_, unexpectedErr := command.Execute("DELETE FROM users")
+
+	// Assert
+	if err != failure {
+		t.Errorf("Expected the error of the driver, got: %v", err)
+	}
+
+	if unexpectedErr == nil || !strings.HasPrefix(unexpectedErr.Error(), db_errors.Mock_UnexpectedCallErrorMessage) {
+		t.Errorf("Expected an unexpected call error, got: %v", unexpectedErr)
+	}
+}
+
+func TestDBCommand_Transaction(t *testing.T) {
+	// Arrange
+	command, mock := createTestMockCommand(t, DialectPostgres)
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "accounts" SET "balance" = "balance" - $1 WHERE "id" = $2`).WithArgs(10, 1).WillReturnResult(0, 1)
+	mock.ExpectQuery(`SELECT "balance" FROM "accounts" WHERE "id" = $1`).WithArgs(1).WillReturnRows(db_mock.CreateNewRows("balance").AddRow(-5))
+	mock.ExpectRollback()
+
+	// Act
+	tx, err := command.Begin()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	_, err = tx.Execute(`UPDATE "accounts" SET "balance" = "balance" - $1 WHERE "id" = $2`, 10, 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	row, err := tx.QueryFirst(`SELECT "balance" FROM "accounts" WHERE "id" = $1`, 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	err = tx.Rollback()
+
+	// Assert
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	if row.itemArray[0] != int64(-5) {
+		t.Errorf("Expected the balance in the transaction, got: %v", row.itemArray[0])
+	}
+
+	assertExpectationsWereMet(t, mock)
+}
+
+func TestDBCommand_Transaction_WithContext(t *testing.T) {
+	// Arrange
+	command, mock := createTestMockCommand(t, DialectPostgres)
+	connection := command.(*dbCommand).connection
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+
+	connection.Open()
+	defer connection.Close()
+
+	tx, err := command.Begin()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Act
+	commitErr := tx.WithContext(context.Background()).(DBTransactionInterface).Commit()
+	rollbackErr := tx.Rollback()
+
+	// Assert
+	if commitErr != nil {
+		t.Errorf("Unexpected error: %v", commitErr)
+	}
+
+	if rollbackErr != sql.ErrTxDone {
+		t.Errorf("Expected %v, got: %v", sql.ErrTxDone, rollbackErr)
+	}
+
+	if connection.getConnection() == nil {
+		t.Error("Expected the connection of the other reference to stay open")
+	}
+
+	assertExpectationsWereMet(t, mock)
+}
+
+func TestDBCommand_ExecuteScript(t *testing.T) {
+	// Arrange
+	command, mock := createTestMockCommand(t, DialectPostgres)
+	mock.ExpectBegin()
+	mock.ExpectExec(`CREATE TABLE "t" ("a" text)`)
+	mock.ExpectExec(`INSERT INTO "t" VALUES ('x;y')`).WillReturnResult(0, 1)
+	mock.ExpectCommit()
+
+	// Act
+	result, err := command.ExecuteScript(strings.NewReader("CREATE TABLE \"t\" (\"a\" text);\n-- seed\nINSERT INTO \"t\" VALUES ('x;y');\n"),
+		WithScriptTransaction())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if result.GetExecuted() != 2 || result.GetRowsAffected() != 1 {
+		t.Errorf("Unexpected result: %d %d", result.GetExecuted(), result.GetRowsAffected())
+	}
+
+	assertExpectationsWereMet(t, mock)
+}
+
+func TestDBCommand_ExecuteScript_Error(t *testing.T) {
+	// Arrange
+	command, mock := createTestMockCommand(t, DialectSQLite)
+	failure := errors.New("no such table: u")
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO t VALUES (1)")
+	mock.ExpectExec("INSERT INTO u VALUES (2)").WillReturnError(failure)
+	mock.ExpectRollback()
+
+	// Act
+	_, err := command.ExecuteScript(strings.NewReader("INSERT INTO t VALUES (1);\n\nINSERT INTO u VALUES (2);"), WithScriptTransaction())
+
+	// Assert
+	var scriptErr ScriptError
+	if !errors.As(err, &scriptErr) || scriptErr.GetLine() != 3 || !errors.Is(err, failure) {
+		t.Errorf("Expected the error of the statement on line 3, got: %v", err)
+	}
+
+	assertExpectationsWereMet(t, mock)
+}
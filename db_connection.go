@@ -1,22 +1,38 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 	"slices"
+	"sync"
 
 	db_errors "github.com/fatihtatoglu/db-go/error"
 )
 
+const (
+	DEFAULT_STATEMENT_CACHE_SIZE = 64
+)
+
 type DBConnectionInterface interface {
 	Open() error
 	Close() error
+	GetDialect() Dialect
+	SetStatementCacheSize(size int)
 	getConnection() *sql.DB
+	getDriverName() string
+	prepare(ctx context.Context, query string) (*sql.Stmt, func(), error)
 }
 
+// dbConnection shares one *sql.DB between nested Open and Close calls.
+// The database handle and its prepared statement cache live until the last Close.
 type dbConnection struct {
-	dsn    string
-	driver string
-	db     *sql.DB
+	dsn        string
+	driver     string
+	dialect    Dialect
+	db         *sql.DB
+	references int
+	statements *statementCache
+	mutex      sync.Mutex
 }
 
 func CreateNewDBConnection(driver string, dsn string) (DBConnectionInterface, error) {
@@ -33,12 +49,22 @@ func CreateNewDBConnection(driver string, dsn string) (DBConnectionInterface, er
 	}
 
 	return &dbConnection{
-		dsn:    dsn,
-		driver: driver,
+		dsn:        dsn,
+		driver:     driver,
+		dialect:    GetDialectByDriver(driver),
+		statements: newStatementCache(DEFAULT_STATEMENT_CACHE_SIZE),
 	}, nil
 }
 
 func (c *dbConnection) Open() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.db != nil {
+		c.references++
+		return nil
+	}
+
 	connection, err := sql.Open(c.driver, c.dsn)
 	if err != nil {
 		return err
@@ -46,21 +72,105 @@ func (c *dbConnection) Open() error {
 
 	err = connection.Ping()
 	if err != nil {
+		connection.Close()
 		return err
 	}
 
 	c.db = connection
+	c.references = 1
 	return nil
 }
 
 func (c *dbConnection) Close() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
 	if c.db == nil {
 		return nil
 	}
 
-	return c.db.Close()
+	c.references--
+	if c.references > 0 {
+		return nil
+	}
+
+	c.statements.clear()
+
+	db := c.db
+	c.db = nil
+	c.references = 0
+	return db.Close()
+}
+
+func (c *dbConnection) GetDialect() Dialect {
+	return c.dialect
+}
+
+// SetStatementCacheSize sets the number of prepared statements kept by the connection.
+// Zero disables the cache.
+func (c *dbConnection) SetStatementCacheSize(size int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.statements.resize(size)
 }
 
 func (c *dbConnection) getConnection() *sql.DB {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
 	return c.db
 }
+
+func (c *dbConnection) getDriverName() string {
+	return c.driver
+}
+
+// prepare returns the cached prepared statement of the query, preparing it on a cache miss, and the
+// function which ends its use. A statement evicted while it is in use is closed when it is released.
+// It returns nil without an error when the cache is disabled.
+//
+// The statement is prepared without holding the lock, so a slow prepare does not block the other commands;
+// when another caller cached the query meanwhile, its statement is used and the new one is closed.
+func (c *dbConnection) prepare(ctx context.Context, query string) (*sql.Stmt, func(), error) {
+	c.mutex.Lock()
+	db := c.db
+	if db == nil || c.statements.size <= 0 {
+		c.mutex.Unlock()
+		return nil, nil, nil
+	}
+
+	entry, ok := c.statements.acquire(query)
+	c.mutex.Unlock()
+
+	if !ok {
+		stmt, err := db.PrepareContext(ctx, query)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		c.mutex.Lock()
+		if c.db != db {
+			c.mutex.Unlock()
+			stmt.Close()
+			return nil, nil, nil
+		}
+
+		entry, ok = c.statements.acquire(query)
+		if ok {
+			stmt.Close()
+		} else {
+			entry = c.statements.put(query, stmt)
+		}
+		c.mutex.Unlock()
+	}
+
+	release := func() {
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+
+		c.statements.release(entry)
+	}
+
+	return entry.stmt, release, nil
+}
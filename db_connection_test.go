@@ -1,32 +1,31 @@
 package db
 
 import (
-	"database/sql"
-	"os"
+	"errors"
 	"testing"
 
 	db_errors "github.com/fatihtatoglu/db-go/error"
+	db_mock "github.com/fatihtatoglu/db-go/mock"
 )
 
-var mockDriver *MockDriver
-var driverName string
+// createTestMockConnection returns a connection on the fake driver of a new mock.
+func createTestMockConnection(t *testing.T) (DBConnectionInterface, *db_mock.Mock) {
+	mock := db_mock.CreateNewMock()
 
-func TestMain(m *testing.M) {
-	driverName = "mysql"
-	mockDriver = &MockDriver{}
-	sql.Register(driverName, mockDriver)
+	connection, err := CreateNewDBConnection(mock.GetDriverName(), mock.GetDSN())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
 
-	exitVal := m.Run()
-	os.Exit(exitVal)
+	return connection, mock
 }
 
 func TestCreateNewDBConnection(t *testing.T) {
 	// Arrange
-	driverName := "mysql"
-	dsn := "testuser:testpass@tcp(localhost:3306)/testdb"
+	mock := db_mock.CreateNewMock()
 
 	// Act
-	connection, err := CreateNewDBConnection(driverName, dsn)
+	connection, err := CreateNewDBConnection(mock.GetDriverName(), mock.GetDSN())
 
 	// Assert
 	if err != nil {
@@ -74,7 +73,7 @@ func TestCreateNewDBConnection_Empty_DSN(t *testing.T) {
 	dsn := ""
 
 	// Act
-	_, err := CreateNewDBConnection("mysql", dsn)
+	_, err := CreateNewDBConnection(db_mock.CreateNewMock().GetDriverName(), dsn)
 
 	// Assert
 	if err == nil {
@@ -86,41 +85,35 @@ func TestOpen(t *testing.T) {
 	// Arrange
 	tests := []struct {
 		name                 string
-		driver               *MockDriver
 		expcetedErrorMessage string
-		arrangeFunc          func()
+		arrangeFunc          func(mock *db_mock.Mock)
 	}{
 		{
 			name:                 "Success",
-			driver:               mockDriver,
 			expcetedErrorMessage: "",
-			arrangeFunc:          func() {},
+			arrangeFunc:          func(mock *db_mock.Mock) {},
 		},
 		{
-			name:                 "Failed sql.Open",
-			driver:               mockDriver,
+			name:                 "Failed driver Open",
 			expcetedErrorMessage: "connection open failed",
-			arrangeFunc: func() {
-				mockDriver.SetConnectorErrorMessage("connection open failed")
+			arrangeFunc: func(mock *db_mock.Mock) {
+				mock.ExpectOpen().WillReturnError(errors.New("connection open failed"))
 			},
 		},
 		{
 			name:                 "Failed sql.Ping",
-			driver:               mockDriver,
 			expcetedErrorMessage: "ping failed",
-			arrangeFunc: func() {
-				mockDriver.SetPingerErrorMessage("ping failed")
+			arrangeFunc: func(mock *db_mock.Mock) {
+				mock.ExpectPing().WillReturnError(errors.New("ping failed"))
 			},
 		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			dsn := "testuser:testpass@tcp(localhost:3306)/testdb"
+			connection, mock := createTestMockConnection(t)
 
-			connection, _ := CreateNewDBConnection(driverName, dsn)
-
-			test.arrangeFunc()
+			test.arrangeFunc(mock)
 
 			// Act
 			err := connection.Open()
@@ -137,17 +130,21 @@ func TestOpen(t *testing.T) {
 			if test.expcetedErrorMessage != "" && err != nil && test.expcetedErrorMessage != err.Error() {
 				t.Errorf("Expected error: %v, but got: %v", test.expcetedErrorMessage, err.Error())
 			}
-		})
 
-		mockDriver.CleanErrorMessage()
+			if test.expcetedErrorMessage != "" && connection.getConnection() != nil {
+				t.Error("Expected nil connection after a failed Open, but got non-nil")
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
 	}
 }
 
 func TestClose(t *testing.T) {
 	// Arrange
-	dsn := "testuser:testpass@tcp(localhost:3306)/testdb"
-
-	connection, _ := CreateNewDBConnection(driverName, dsn)
+	connection, _ := createTestMockConnection(t)
 
 	connection.Open()
 
@@ -162,11 +159,9 @@ func TestClose(t *testing.T) {
 
 func TestClose_With_Error(t *testing.T) {
 	// Arrange
-	dsn := "testuser:testpass@tcp(localhost:3306)/testdb"
+	connection, mock := createTestMockConnection(t)
 
-	connection, _ := CreateNewDBConnection(driverName, dsn)
-
-	mockDriver.SetCloseErrorMessage("close failed")
+	mock.ExpectClose().WillReturnError(errors.New("close failed"))
 
 	connection.Open()
 
@@ -181,9 +176,7 @@ func TestClose_With_Error(t *testing.T) {
 
 func Test_getConnection(t *testing.T) {
 	// Arrange
-	dsn := "testuser:testpass@tcp(localhost:3306)/testdb"
-
-	connection, _ := CreateNewDBConnection(driverName, dsn)
+	connection, _ := createTestMockConnection(t)
 
 	connection.Open()
 
@@ -198,9 +191,7 @@ func Test_getConnection(t *testing.T) {
 
 func Test_getConnection_Without_Open(t *testing.T) {
 	// Arrange
-	dsn := "testuser:testpass@tcp(localhost:3306)/testdb"
-
-	connection, _ := CreateNewDBConnection(driverName, dsn)
+	connection, _ := createTestMockConnection(t)
 
 	// Act
 	db := connection.getConnection()
@@ -213,15 +204,40 @@ func Test_getConnection_Without_Open(t *testing.T) {
 
 func TestClose_Without_Opening(t *testing.T) {
 	// Arrange
-	dsn := "testuser:testpass@tcp(localhost:3306)/testdb"
+	connection, _ := createTestMockConnection(t)
 
-	connection, _ := CreateNewDBConnection(driverName, dsn)
+	// Act
+	err := connection.Close()
+
+	// Assert
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestOpen_Nested(t *testing.T) {
+	// Arrange
+	connection, _ := createTestMockConnection(t)
+
+	connection.Open()
+	db := connection.getConnection()
 
 	// Act
+	connection.Open()
 	err := connection.Close()
 
 	// Assert
 	if err != nil {
 		t.Errorf("Unexpected error: %v", err)
 	}
+
+	if connection.getConnection() != db {
+		t.Error("Expected the connection to stay open until the last Close")
+	}
+
+	connection.Close()
+
+	if connection.getConnection() != nil {
+		t.Error("Expected nil connection after the last Close, but got non-nil")
+	}
 }
@@ -0,0 +1,472 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+
+	db_errors "github.com/fatihtatoglu/db-go/error"
+)
+
+// defaultKeySize is the length of the string key and index columns without a size on MySQL and SQL Server,
+// which cannot index their unbounded text types.
+const defaultKeySize = 255
+
+// nullTypes maps the sql.Null types to the types of their values.
+var nullTypes = map[reflect.Type]reflect.Type{
+	reflect.TypeOf(sql.NullBool{}):    reflect.TypeOf(false),
+	reflect.TypeOf(sql.NullByte{}):    reflect.TypeOf(byte(0)),
+	reflect.TypeOf(sql.NullFloat64{}): reflect.TypeOf(float64(0)),
+	reflect.TypeOf(sql.NullInt16{}):   reflect.TypeOf(int16(0)),
+	reflect.TypeOf(sql.NullInt32{}):   reflect.TypeOf(int32(0)),
+	reflect.TypeOf(sql.NullInt64{}):   reflect.TypeOf(int64(0)),
+	reflect.TypeOf(sql.NullString{}):  reflect.TypeOf(""),
+	reflect.TypeOf(sql.NullTime{}):    timeType,
+}
+
+// CreateTableSQL returns the CREATE TABLE statement of the entity for the dialect, followed by the
+// CREATE INDEX statements of its indexes.
+//
+// The table name is taken from the TableName method of the entity or its snake_case type name. The column
+// types are derived from the field types unless they are set with the type option; the pointer and sql.Null
+// fields are nullable. The primary key, the ref foreign keys, the defaults and the indexes are taken from
+// the db tags, see the tag options.
+func CreateTableSQL[T any](dialect Dialect) ([]string, error) {
+	table, err := entityTableSchema(reflect.TypeOf((*T)(nil)).Elem(), dialect)
+	if err != nil {
+		return nil, err
+	}
+
+	return createTableStatements(dialect, table), nil
+}
+
+// AutoMigrate creates the table of the entity when it does not exist. The columns and the indexes missing
+// from an existing table are added; the existing columns are never changed or dropped. A NOT NULL column
+// added to a table with rows needs a default.
+func AutoMigrate[T any](command DBCommandInterface) error {
+	inspector, err := CreateNewInspector(command)
+	if err != nil {
+		return err
+	}
+
+	dialect := command.GetDialect()
+	table, err := entityTableSchema(reflect.TypeOf((*T)(nil)).Elem(), dialect)
+	if err != nil {
+		return err
+	}
+
+	var statements []string
+
+	existing, err := inspector.GetTable(table.name)
+	switch {
+	case errors.Is(err, db_errors.ErrInspectorTableNotFound):
+		statements = createTableStatements(dialect, table)
+	case err != nil:
+		return err
+	default:
+		for i := range table.columns {
+			if _, ok := existing.GetColumn(table.columns[i].name); !ok {
+				statements = append(statements, addColumnStatement(dialect, table.name, &table.columns[i]))
+			}
+		}
+
+		for i := range table.indexes {
+			if !hasIndex(existing, table.indexes[i].name) {
+				statements = append(statements, createIndexStatement(dialect, table.name, &table.indexes[i]))
+			}
+		}
+	}
+
+	for _, statement := range statements {
+		_, err = command.Execute(statement)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// entityTableSchema derives the table schema of the entity type. The DBType of the columns is the SQL type
+// of the dialect.
+func entityTableSchema(t reflect.Type, dialect Dialect) (*DBTableSchema, error) {
+	switch dialect {
+	case DialectMySQL, DialectPostgres, DialectSQLite, DialectSQLServer:
+	default:
+		return nil, db_errors.DDLUnsupportedDialectError()
+	}
+
+	metadata, err := getEntityMetadata(t)
+	if err != nil {
+		return nil, err
+	}
+
+	table := &DBTableSchema{
+		name:        tableName(metadata.entityType),
+		columns:     make([]DBColumn, 0, len(metadata.fields)),
+		primaryKey:  make([]string, 0),
+		indexes:     make([]DBIndex, 0),
+		foreignKeys: make([]DBForeignKey, 0),
+	}
+
+	for i, f := range metadata.fields {
+		column, err := entityColumn(dialect, f, i+1)
+		if err != nil {
+			return nil, err
+		}
+
+		table.columns = append(table.columns, column)
+
+		if f.isPrimaryKey() {
+			table.primaryKey = append(table.primaryKey, f.column)
+		}
+
+		for _, option := range []string{tagOptionIndex, tagOptionUnique} {
+			name, ok := f.option(option)
+			if !ok {
+				continue
+			}
+
+			if name == "" {
+				prefix := "ix_"
+				if option == tagOptionUnique {
+					prefix = "ux_"
+				}
+
+				name = prefix + table.name + "_" + f.column
+			}
+
+			table.addIndexColumn(name, f.column, option == tagOptionUnique)
+		}
+
+		if referenced, referencedColumn, ok := f.reference(); ok && referencedColumn != "" {
+			table.foreignKeys = append(table.foreignKeys, CreateNewDBForeignKey("fk_"+table.name+"_"+f.column,
+				[]string{f.column}, referenced, []string{referencedColumn}))
+		}
+	}
+
+	return table, nil
+}
+
+func (t *DBTableSchema) addIndexColumn(name string, column string, unique bool) {
+	for i := range t.indexes {
+		if t.indexes[i].name == name {
+			t.indexes[i].columns = append(t.indexes[i].columns, column)
+			return
+		}
+	}
+
+	t.indexes = append(t.indexes, CreateNewDBIndex(name, []string{column}, unique))
+}
+
+// entityColumn derives the column of the field. The primary key columns are never nullable.
+func entityColumn(dialect Dialect, f *entityField, ordinal int) (DBColumn, error) {
+	t := f.fieldType
+	nullable := f.hasOption(tagOptionNull)
+	if t.Kind() == reflect.Pointer {
+		nullable = true
+		t = t.Elem()
+	}
+
+	if valueType, ok := nullTypes[t]; ok {
+		nullable = true
+		t = valueType
+	}
+
+	if f.isPrimaryKey() {
+		nullable = false
+	}
+
+	var size int64
+	if value, ok := f.option(tagOptionSize); ok {
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || parsed <= 0 {
+			return DBColumn{}, db_errors.DDLInvalidSizeError()
+		}
+
+		size = parsed
+	}
+
+	keyed := f.isPrimaryKey() || f.hasOption(tagOptionIndex) || f.hasOption(tagOptionUnique)
+	sqlType, length, ok := columnSQLType(dialect, t, size, keyed, f.isAutoIncrement())
+	if value, hasType := f.option(tagOptionType); hasType && value != "" {
+		sqlType, length, ok = value, size, true
+	}
+
+	if !ok {
+		return DBColumn{}, db_errors.DDLUnsupportedTypeError()
+	}
+
+	options := make([]DBColumnOption, 0, 2)
+	if value, ok := f.option(tagOptionDefault); ok {
+		options = append(options, WithColumnDefault(value))
+	}
+
+	if f.isAutoIncrement() {
+		options = append(options, WithColumnAutoIncrement())
+	}
+
+	return CreateNewDBColumnFromSchema(f.column, ordinal, sqlType, length, nullable, options...), nil
+}
+
+// columnSQLType returns the SQL type of the Go type for the dialect and the length of the sized types.
+// The generated columns are the auto increment ones.
+func columnSQLType(dialect Dialect, t reflect.Type, size int64, keyed bool, generated bool) (string, int64, bool) {
+	if t == timeType {
+		switch dialect {
+		case DialectMySQL:
+			return "DATETIME(6)", 0, true
+		case DialectSQLServer:
+			return "DATETIME2", 0, true
+		default:
+			return "TIMESTAMP", 0, true
+		}
+	}
+
+	if size == 0 && keyed && (dialect == DialectMySQL || dialect == DialectSQLServer) {
+		size = defaultKeySize
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		if dialect == DialectSQLServer {
+			return "BIT", 0, true
+		}
+
+		return "BOOLEAN", 0, true
+	case reflect.Int8, reflect.Int16, reflect.Uint8:
+		return integerType(dialect, "SMALLINT"), 0, true
+	case reflect.Int32, reflect.Uint16:
+		return integerType(dialect, "INT"), 0, true
+	case reflect.Int, reflect.Int64, reflect.Uint32:
+		return integerType(dialect, "BIGINT"), 0, true
+	case reflect.Uint, reflect.Uint64:
+		// BIGINT cannot hold the upper half of the range; the generated keys stay in it, and the identity
+		// and the INTEGER PRIMARY KEY columns must be integers.
+		switch {
+		case dialect == DialectMySQL:
+			return "BIGINT UNSIGNED", 0, true
+		case generated:
+			return integerType(dialect, "BIGINT"), 0, true
+		default:
+			return "NUMERIC(20)", 0, true
+		}
+	case reflect.Float32:
+		if dialect == DialectMySQL {
+			return "FLOAT", 0, true
+		}
+
+		return "REAL", 0, true
+	case reflect.Float64:
+		switch dialect {
+		case DialectMySQL:
+			return "DOUBLE", 0, true
+		case DialectSQLServer:
+			return "FLOAT", 0, true
+		case DialectSQLite:
+			return "REAL", 0, true
+		default:
+			return "DOUBLE PRECISION", 0, true
+		}
+	case reflect.String:
+		switch {
+		case dialect == DialectSQLServer && size > 0:
+			return "NVARCHAR(" + strconv.FormatInt(size, 10) + ")", size, true
+		case dialect == DialectSQLServer:
+			return "NVARCHAR(MAX)", 0, true
+		case size > 0:
+			return "VARCHAR(" + strconv.FormatInt(size, 10) + ")", size, true
+		default:
+			return "TEXT", 0, true
+		}
+	case reflect.Slice:
+		if t.Elem().Kind() != reflect.Uint8 {
+			return "", 0, false
+		}
+
+		switch {
+		case dialect == DialectMySQL && size > 0, dialect == DialectSQLServer && size > 0:
+			return "VARBINARY(" + strconv.FormatInt(size, 10) + ")", size, true
+		case dialect == DialectMySQL:
+			return "LONGBLOB", 0, true
+		case dialect == DialectSQLServer:
+			return "VARBINARY(MAX)", 0, true
+		case dialect == DialectPostgres:
+			return "BYTEA", 0, true
+		default:
+			return "BLOB", 0, true
+		}
+	}
+
+	return "", 0, false
+}
+
+// integerType returns INTEGER for all the integer sizes of SQLite, whose INTEGER PRIMARY KEY is the rowid.
+func integerType(dialect Dialect, sqlType string) string {
+	if dialect == DialectSQLite {
+		return "INTEGER"
+	}
+
+	return sqlType
+}
+
+// createTableStatements returns the CREATE TABLE statement of the table followed by its CREATE INDEX statements.
+func createTableStatements(dialect Dialect, table *DBTableSchema) []string {
+	// SQLite generates the values of the INTEGER PRIMARY KEY column, which is declared inline.
+	inlineKey := dialect == DialectSQLite && len(table.primaryKey) == 1
+	if inlineKey {
+		column, _ := table.GetColumn(table.primaryKey[0])
+		inlineKey = column.autoIncrement
+	}
+
+	definitions := make([]string, 0, len(table.columns)+len(table.foreignKeys)+1)
+	for i := range table.columns {
+		definition := columnDefinition(dialect, &table.columns[i])
+		if inlineKey && table.columns[i].name == table.primaryKey[0] {
+			definition += " PRIMARY KEY AUTOINCREMENT"
+		}
+
+		definitions = append(definitions, definition)
+	}
+
+	if len(table.primaryKey) > 0 && !inlineKey {
+		definitions = append(definitions, "PRIMARY KEY ("+quoteIdentifiers(dialect, table.primaryKey)+")")
+	}
+
+	for i := range table.foreignKeys {
+		definitions = append(definitions, foreignKeyDefinition(dialect, &table.foreignKeys[i]))
+	}
+
+	statements := []string{"CREATE TABLE " + dialect.QuoteIdentifier(table.name) + " (" + strings.Join(definitions, ", ") + ")"}
+	for i := range table.indexes {
+		statements = append(statements, createIndexStatement(dialect, table.name, &table.indexes[i]))
+	}
+
+	return statements
+}
+
+// columnDefinition returns the definition of the column in a CREATE TABLE or an ALTER TABLE statement.
+// The auto increment clause of SQLite is a part of the inline primary key. The default of an auto increment
+// column, e.g. the nextval of a PostgreSQL serial column, is replaced by the auto increment clause.
+func columnDefinition(dialect Dialect, column *DBColumn) string {
+	var b strings.Builder
+	b.WriteString(dialect.QuoteIdentifier(column.name) + " " + columnType(column))
+
+	if column.nullable {
+		b.WriteString(" NULL")
+	} else {
+		b.WriteString(" NOT NULL")
+	}
+
+	if column.defaultValue != nil && !column.autoIncrement {
+		b.WriteString(" DEFAULT " + *column.defaultValue)
+	}
+
+	if column.autoIncrement {
+		switch dialect {
+		case DialectMySQL:
+			b.WriteString(" AUTO_INCREMENT")
+		case DialectPostgres:
+			b.WriteString(" GENERATED BY DEFAULT AS IDENTITY")
+		case DialectSQLServer:
+			b.WriteString(" IDENTITY(1,1)")
+		}
+	}
+
+	return b.String()
+}
+
+// sizedTypes are the types whose length is a part of the type.
+var sizedTypes = map[string]bool{
+	"binary": true, "char": true, "character": true, "character varying": true,
+	"nchar": true, "nvarchar": true, "varbinary": true, "varchar": true,
+}
+
+// numericTypes are the types whose precision and scale are a part of the type.
+var numericTypes = map[string]bool{
+	"decimal": true, "numeric": true,
+}
+
+// columnType returns the SQL type of the column. The inspected columns keep the length, the precision and
+// the scale apart from the type name; they are added back, a length of -1 is the MAX length of SQL Server.
+func columnType(column *DBColumn) string {
+	if strings.Contains(column.dBType, "(") {
+		return column.dBType
+	}
+
+	name := strings.ToLower(column.dBType)
+	switch {
+	case sizedTypes[name] && column.length > 0:
+		return column.dBType + "(" + strconv.FormatInt(column.length, 10) + ")"
+	case sizedTypes[name] && column.length < 0:
+		return column.dBType + "(MAX)"
+	case numericTypes[name] && column.precision > 0:
+		return column.dBType + "(" + strconv.FormatInt(column.precision, 10) + "," + strconv.FormatInt(column.scale, 10) + ")"
+	}
+
+	return column.dBType
+}
+
+// foreignKeyDefinition returns the constraint of the foreign key; the unnamed foreign keys inspected from
+// SQLite are written without the constraint name.
+func foreignKeyDefinition(dialect Dialect, foreignKey *DBForeignKey) string {
+	definition := "FOREIGN KEY (" + quoteIdentifiers(dialect, foreignKey.columns) + ") REFERENCES " +
+		dialect.QuoteIdentifier(foreignKey.referencedTable) + " (" + quoteIdentifiers(dialect, foreignKey.referencedColumns) + ")"
+	if foreignKey.name != "" {
+		definition = "CONSTRAINT " + dialect.QuoteIdentifier(foreignKey.name) + " " + definition
+	}
+
+	if foreignKey.onDelete != "" {
+		definition += " ON DELETE " + foreignKey.onDelete
+	}
+
+	if foreignKey.onUpdate != "" {
+		definition += " ON UPDATE " + foreignKey.onUpdate
+	}
+
+	return definition
+}
+
+func addForeignKeyStatement(dialect Dialect, table string, foreignKey *DBForeignKey) string {
+	return "ALTER TABLE " + dialect.QuoteIdentifier(table) + " ADD " + foreignKeyDefinition(dialect, foreignKey)
+}
+
+func addColumnStatement(dialect Dialect, table string, column *DBColumn) string {
+	add := " ADD COLUMN "
+	if dialect == DialectSQLServer {
+		add = " ADD "
+	}
+
+	return "ALTER TABLE " + dialect.QuoteIdentifier(table) + add + columnDefinition(dialect, column)
+}
+
+func createIndexStatement(dialect Dialect, table string, index *DBIndex) string {
+	create := "CREATE INDEX "
+	if index.unique {
+		create = "CREATE UNIQUE INDEX "
+	}
+
+	return create + dialect.QuoteIdentifier(index.name) + " ON " + dialect.QuoteIdentifier(table) + " (" + quoteIdentifiers(dialect, index.columns) + ")"
+}
+
+func quoteIdentifiers(dialect Dialect, names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = dialect.QuoteIdentifier(name)
+	}
+
+	return strings.Join(quoted, ", ")
+}
+
+// hasIndex compares the index names case insensitively; MySQL and SQL Server names are not case sensitive.
+func hasIndex(table *DBTableSchema, name string) bool {
+	for _, index := range table.indexes {
+		if strings.EqualFold(index.name, name) {
+			return true
+		}
+	}
+
+	return false
+}
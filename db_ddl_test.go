@@ -0,0 +1,263 @@
+package db
+
+import (
+	"database/sql"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	db_errors "github.com/fatihtatoglu/db-go/error"
+)
+
+type testDDLCustomer struct {
+	ID        int64          `db:"id,pk,auto"`
+	Email     string         `db:"email,size=320,unique"`
+	Name      string         `db:"name,index=ix_customers_name_city"`
+	City      *string        `db:"city,size=100,index=ix_customers_name_city"`
+	Balance   float64        `db:"balance,type=DECIMAL(10,2),default=0"`
+	Active    bool           `db:"active"`
+	Note      sql.NullString `db:"note"`
+	Photo     []byte         `db:"photo"`
+	CreatedAt time.Time      `db:"created_at"`
+}
+
+func (testDDLCustomer) TableName() string {
+	return "customers"
+}
+
+type testDDLOrderLine struct {
+	OrderID    int64  `db:"order_id,pk,ref=orders(id)"`
+	LineNo     int16  `db:"line_no,pk"`
+	ProductSKU string `db:"product_sku"`
+	Quantity   int32  `db:"quantity"`
+}
+
+func TestCreateTableSQL(t *testing.T) {
+	// Test cases
+	testCases := []struct {
+		name     string
+		dialect  Dialect
+		expected []string
+	}{
+		{
+			name:    "MySQL",
+			dialect: DialectMySQL,
+			expected: []string{
+				"CREATE TABLE `customers` (`id` BIGINT NOT NULL AUTO_INCREMENT, `email` VARCHAR(320) NOT NULL, `name` VARCHAR(255) NOT NULL, " +
+					"`city` VARCHAR(100) NULL, `balance` DECIMAL(10,2) NOT NULL DEFAULT 0, `active` BOOLEAN NOT NULL, `note` TEXT NULL, " +
+					"`photo` LONGBLOB NOT NULL, `created_at` DATETIME(6) NOT NULL, PRIMARY KEY (`id`))",
+				"CREATE UNIQUE INDEX `ux_customers_email` ON `customers` (`email`)",
+				"CREATE INDEX `ix_customers_name_city` ON `customers` (`name`, `city`)",
+			},
+		},
+		{
+			name:    "PostgreSQL",
+			dialect: DialectPostgres,
+			expected: []string{
+				`CREATE TABLE "customers" ("id" BIGINT NOT NULL GENERATED BY DEFAULT AS IDENTITY, "email" VARCHAR(320) NOT NULL, "name" TEXT NOT NULL, ` +
+					`"city" VARCHAR(100) NULL, "balance" DECIMAL(10,2) NOT NULL DEFAULT 0, "active" BOOLEAN NOT NULL, "note" TEXT NULL, ` +
+					`"photo" BYTEA NOT NULL, "created_at" TIMESTAMP NOT NULL, PRIMARY KEY ("id"))`,
+				`CREATE UNIQUE INDEX "ux_customers_email" ON "customers" ("email")`,
+				`CREATE INDEX "ix_customers_name_city" ON "customers" ("name", "city")`,
+			},
+		},
+		{
+			name:    "SQLite",
+			dialect: DialectSQLite,
+			expected: []string{
+				`CREATE TABLE "customers" ("id" INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT, "email" VARCHAR(320) NOT NULL, "name" TEXT NOT NULL, ` +
+					`"city" VARCHAR(100) NULL, "balance" DECIMAL(10,2) NOT NULL DEFAULT 0, "active" BOOLEAN NOT NULL, "note" TEXT NULL, ` +
+					`"photo" BLOB NOT NULL, "created_at" TIMESTAMP NOT NULL)`,
+				`CREATE UNIQUE INDEX "ux_customers_email" ON "customers" ("email")`,
+				`CREATE INDEX "ix_customers_name_city" ON "customers" ("name", "city")`,
+			},
+		},
+		{
+			name:    "SQLServer",
+			dialect: DialectSQLServer,
+			expected: []string{
+				"CREATE TABLE [customers] ([id] BIGINT NOT NULL IDENTITY(1,1), [email] NVARCHAR(320) NOT NULL, [name] NVARCHAR(255) NOT NULL, " +
+					"[city] NVARCHAR(100) NULL, [balance] DECIMAL(10,2) NOT NULL DEFAULT 0, [active] BIT NOT NULL, [note] NVARCHAR(MAX) NULL, " +
+					"[photo] VARBINARY(MAX) NOT NULL, [created_at] DATETIME2 NOT NULL, PRIMARY KEY ([id]))",
+				"CREATE UNIQUE INDEX [ux_customers_email] ON [customers] ([email])",
+				"CREATE INDEX [ix_customers_name_city] ON [customers] ([name], [city])",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			statements, err := CreateTableSQL[testDDLCustomer](tc.dialect)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(statements, tc.expected) {
+				t.Errorf("Expected:\n%s\ngot:\n%s", strings.Join(tc.expected, "\n"), strings.Join(statements, "\n"))
+			}
+		})
+	}
+}
+
+func TestCreateTableSQL_CompositeKeyAndForeignKey(t *testing.T) {
+	// Act
+	statements, err := CreateTableSQL[testDDLOrderLine](DialectSQLite)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{
+		`CREATE TABLE "test_ddl_order_line" ("order_id" INTEGER NOT NULL, "line_no" INTEGER NOT NULL, "product_sku" TEXT NOT NULL, ` +
+			`"quantity" INTEGER NOT NULL, PRIMARY KEY ("order_id", "line_no"), ` +
+			`CONSTRAINT "fk_test_ddl_order_line_order_id" FOREIGN KEY ("order_id") REFERENCES "orders" ("id"))`,
+	}
+	if !reflect.DeepEqual(statements, expected) {
+		t.Errorf("Expected:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(statements, "\n"))
+	}
+}
+
+func TestCreateTableSQL_Unsigned(t *testing.T) {
+	// Arrange
+	type counter struct {
+		ID    uint64 `db:"id,pk,auto"`
+		Hits  uint64 `db:"hits"`
+		Total uint   `db:"total"`
+		Small uint32 `db:"small"`
+	}
+
+	// Test cases
+	testCases := []struct {
+		name     string
+		dialect  Dialect
+		expected string
+	}{
+		{
+			name:     "MySQL",
+			dialect:  DialectMySQL,
+			expected: "CREATE TABLE `counter` (`id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT, `hits` BIGINT UNSIGNED NOT NULL, `total` BIGINT UNSIGNED NOT NULL, `small` BIGINT NOT NULL, PRIMARY KEY (`id`))",
+		},
+		{
+			name:     "PostgreSQL",
+			dialect:  DialectPostgres,
+			expected: `CREATE TABLE "counter" ("id" BIGINT NOT NULL GENERATED BY DEFAULT AS IDENTITY, "hits" NUMERIC(20) NOT NULL, "total" NUMERIC(20) NOT NULL, "small" BIGINT NOT NULL, PRIMARY KEY ("id"))`,
+		},
+		{
+			name:     "SQLite",
+			dialect:  DialectSQLite,
+			expected: `CREATE TABLE "counter" ("id" INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT, "hits" NUMERIC(20) NOT NULL, "total" NUMERIC(20) NOT NULL, "small" INTEGER NOT NULL)`,
+		},
+		{
+			name:     "SQLServer",
+			dialect:  DialectSQLServer,
+			expected: "CREATE TABLE [counter] ([id] BIGINT NOT NULL IDENTITY(1,1), [hits] NUMERIC(20) NOT NULL, [total] NUMERIC(20) NOT NULL, [small] BIGINT NOT NULL, PRIMARY KEY ([id]))",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			statements, err := CreateTableSQL[counter](tc.dialect)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if statements[0] != tc.expected {
+				t.Errorf("Expected:\n%s\ngot:\n%s", tc.expected, statements[0])
+			}
+		})
+	}
+}
+
+func TestCreateTableSQL_Errors(t *testing.T) {
+	// Arrange
+	type unsupportedType struct {
+		Tags []string `db:"tags"`
+	}
+
+	type invalidSize struct {
+		Name string `db:"name,size=big"`
+	}
+
+	type overriddenType struct {
+		Tags []string `db:"tags,type=JSON"`
+	}
+
+	// Act
+	_, dialectErr := CreateTableSQL[testDDLCustomer](DialectUnknown)
+	_, typeErr := CreateTableSQL[unsupportedType](DialectPostgres)
+	_, sizeErr := CreateTableSQL[invalidSize](DialectPostgres)
+	statements, overrideErr := CreateTableSQL[overriddenType](DialectPostgres)
+
+	// Assert
+	assertError(t, dialectErr, db_errors.DDL_UnsupportedDialectErrorMessage)
+	assertError(t, typeErr, db_errors.DDL_UnsupportedTypeErrorMessage)
+	assertError(t, sizeErr, db_errors.DDL_InvalidSizeErrorMessage)
+
+	if overrideErr != nil || statements[0] != `CREATE TABLE "overridden_type" ("tags" JSON NOT NULL)` {
+		t.Errorf("Expected the type option to set the column type, got: %v %v", statements, overrideErr)
+	}
+}
+
+func TestAutoMigrate_CreatesTable(t *testing.T) {
+	// Arrange
+	command := &recordingCommand{dialect: DialectSQLite}
+
+	// Act
+	err := AutoMigrate[testDDLCustomer](command)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected, _ := CreateTableSQL[testDDLCustomer](DialectSQLite)
+	if !reflect.DeepEqual(command.queries[1:], expected) {
+		t.Errorf("Expected the table to be created:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(command.queries, "\n"))
+	}
+}
+
+func TestAutoMigrate_AddsMissingColumnsAndIndexes(t *testing.T) {
+	// Arrange
+	command := &recordingCommand{
+		dialect: DialectSQLServer,
+		tables: []*DBTable{
+			createTestTable([]string{"column_name", "ordinal_position", "data_type", "is_nullable", "length", "precision", "scale", "column_default", "auto"},
+				[]interface{}{"id", int64(1), "bigint", "NO", int64(0), int64(19), int64(0), nil, int64(1)},
+				[]interface{}{"email", int64(2), "nvarchar", "NO", int64(320), int64(0), int64(0), nil, int64(0)},
+				[]interface{}{"name", int64(3), "nvarchar", "NO", int64(255), int64(0), int64(0), nil, int64(0)},
+			),
+			createTestTable([]string{"column_name"}, []interface{}{"id"}),
+			createTestTable([]string{"name", "column", "is_unique"}, []interface{}{"UX_CUSTOMERS_EMAIL", "email", true}),
+			createTestTable([]string{"name", "column", "table", "referenced_column", "delete", "update"}),
+		},
+	}
+
+	// Act
+	err := AutoMigrate[testDDLCustomer](command)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"ALTER TABLE [customers] ADD [city] NVARCHAR(100) NULL",
+		"ALTER TABLE [customers] ADD [balance] DECIMAL(10,2) NOT NULL DEFAULT 0",
+		"ALTER TABLE [customers] ADD [active] BIT NOT NULL",
+		"ALTER TABLE [customers] ADD [note] NVARCHAR(MAX) NULL",
+		"ALTER TABLE [customers] ADD [photo] VARBINARY(MAX) NOT NULL",
+		"ALTER TABLE [customers] ADD [created_at] DATETIME2 NOT NULL",
+		"CREATE INDEX [ix_customers_name_city] ON [customers] ([name], [city])",
+	}
+	if !reflect.DeepEqual(command.queries[4:], expected) {
+		t.Errorf("Expected:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(command.queries[4:], "\n"))
+	}
+}
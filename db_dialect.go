@@ -0,0 +1,237 @@
+package db
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Dialect identifies the SQL flavour spoken by the database behind a connection.
+type Dialect string
+
+const (
+	DialectUnknown   Dialect = ""
+	DialectMySQL     Dialect = "mysql"
+	DialectPostgres  Dialect = "postgres"
+	DialectSQLite    Dialect = "sqlite"
+	DialectSQLServer Dialect = "sqlserver"
+)
+
+var (
+	driverDialectsMutex sync.RWMutex
+	driverDialects      = map[string]Dialect{
+		"mysql":     DialectMySQL,
+		"postgres":  DialectPostgres,
+		"pgx":       DialectPostgres,
+		"sqlite":    DialectSQLite,
+		"sqlite3":   DialectSQLite,
+		"sqlserver": DialectSQLServer,
+		"mssql":     DialectSQLServer,
+	}
+)
+
+// RegisterDriverDialect maps a database/sql driver name to a dialect.
+// It is used for wrapped or custom drivers whose names are not known by the package.
+func RegisterDriverDialect(driver string, dialect Dialect) {
+	driverDialectsMutex.Lock()
+	defer driverDialectsMutex.Unlock()
+
+	driverDialects[driver] = dialect
+}
+
+// GetDialectByDriver returns the dialect registered for the driver name.
+func GetDialectByDriver(driver string) Dialect {
+	driverDialectsMutex.RLock()
+	defer driverDialectsMutex.RUnlock()
+
+	return driverDialects[driver]
+}
+
+// Placeholder returns the bind parameter marker for the 1-based parameter index.
+func (d Dialect) Placeholder(index int) string {
+	switch d {
+	case DialectPostgres:
+		return "$" + strconv.Itoa(index)
+	case DialectSQLServer:
+		return "@p" + strconv.Itoa(index)
+	default:
+		return "?"
+	}
+}
+
+// Rebind replaces the ? placeholders of the query with the placeholders of the dialect.
+// Question marks inside quoted strings and identifiers are kept.
+func (d Dialect) Rebind(query string) string {
+	if d.Placeholder(1) == "?" {
+		return query
+	}
+
+	var b strings.Builder
+	b.Grow(len(query) + 8)
+
+	var quote rune
+	index := 0
+	for _, r := range query {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"' || r == '`':
+			quote = r
+		case r == '[' && d == DialectSQLServer:
+			quote = ']'
+		case r == '?':
+			index++
+			b.WriteString(d.Placeholder(index))
+			continue
+		}
+
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+// QuoteIdentifier quotes the table or column name. Qualified names like schema.table are quoted part by part.
+func (d Dialect) QuoteIdentifier(name string) string {
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		switch d {
+		case DialectMySQL:
+			parts[i] = "`" + strings.ReplaceAll(part, "`", "``") + "`"
+		case DialectSQLServer:
+			parts[i] = "[" + strings.ReplaceAll(part, "]", "]]") + "]"
+		default:
+			parts[i] = `"` + strings.ReplaceAll(part, `"`, `""`) + `"`
+		}
+	}
+
+	return strings.Join(parts, ".")
+}
+
+// maxParameters returns the number of bind parameters a single statement can carry.
+// SQL Server allows 2100 parameters per request, but sp_executesql takes two of them for the statement
+// and the parameter definitions.
+func (d Dialect) maxParameters() int {
+	switch d {
+	case DialectSQLServer:
+		return 2098
+	case DialectSQLite:
+		return 32766
+	default:
+		return 65535
+	}
+}
+
+// maxInsertRows returns the number of rows a single INSERT ... VALUES statement can carry, zero means unlimited.
+func (d Dialect) maxInsertRows() int {
+	if d == DialectSQLServer {
+		return 1000
+	}
+
+	return 0
+}
+
+func (d Dialect) savepointSQL(name string) string {
+	if d == DialectSQLServer {
+		return "SAVE TRANSACTION " + name
+	}
+
+	return "SAVEPOINT " + name
+}
+
+func (d Dialect) rollbackToSavepointSQL(name string) string {
+	if d == DialectSQLServer {
+		return "ROLLBACK TRANSACTION " + name
+	}
+
+	return "ROLLBACK TO SAVEPOINT " + name
+}
+
+// releaseSavepointSQL returns an empty string for dialects which release savepoints with the transaction.
+func (d Dialect) releaseSavepointSQL(name string) string {
+	if d == DialectSQLServer {
+		return ""
+	}
+
+	return "RELEASE SAVEPOINT " + name
+}
+
+// literal writes the value as an SQL literal of the dialect. The strings are quoted, the bytes are written
+// as hexadecimal literals and the times in UTC, so they keep their instant wherever they are restored.
+// PostgreSQL and SQLite read the +00:00 offset of the times; MySQL before 8.0.19 and the DATETIME type of
+// SQL Server reject an offset, so their times are written without it.
+func (d Dialect) literal(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "NULL"
+	case bool:
+		switch {
+		case d == DialectSQLServer && v:
+			return "1"
+		case d == DialectSQLServer:
+			return "0"
+		case v:
+			return "TRUE"
+		default:
+			return "FALSE"
+		}
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprint(v)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'g', -1, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case []byte:
+		encoded := hex.EncodeToString(v)
+		switch d {
+		case DialectPostgres:
+			return `'\x` + encoded + "'"
+		case DialectSQLServer:
+			return "0x" + encoded
+		default:
+			return "X'" + encoded + "'"
+		}
+	case time.Time:
+		layout := "2006-01-02 15:04:05.999999"
+		if d == DialectPostgres || d == DialectSQLite {
+			layout += "-07:00"
+		}
+
+		return d.stringLiteral(v.UTC().Format(layout))
+	case string:
+		return d.stringLiteral(v)
+	default:
+		return d.stringLiteral(fmt.Sprint(v))
+	}
+}
+
+// stringLiteral quotes the string; MySQL treats the backslash as an escape character and SQL Server needs
+// the N prefix for the Unicode text. The doubled backslashes of MySQL assume the default SQL mode: with
+// NO_BACKSLASH_ESCAPES they are read as two backslashes.
+func (d Dialect) stringLiteral(value string) string {
+	quoted := strings.ReplaceAll(value, "'", "''")
+	switch d {
+	case DialectMySQL:
+		return "'" + strings.ReplaceAll(quoted, `\`, `\\`) + "'"
+	case DialectSQLServer:
+		return "N'" + quoted + "'"
+	default:
+		return "'" + quoted + "'"
+	}
+}
+
+// resetSequenceSQL returns the statement which moves the sequence of the auto increment column of PostgreSQL
+// past the values inserted explicitly, empty for the other dialects, which advance their counters themselves.
+func (d Dialect) resetSequenceSQL(table string, column string) string {
+	if d != DialectPostgres {
+		return ""
+	}
+
+	return "SELECT setval(pg_get_serial_sequence(" + d.stringLiteral(d.QuoteIdentifier(table)) + ", " + d.stringLiteral(column) +
+		"), COALESCE(MAX(" + d.QuoteIdentifier(column) + "), 0) + 1, false) FROM " + d.QuoteIdentifier(table)
+}
@@ -0,0 +1,152 @@
+package db
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetDialectByDriver(t *testing.T) {
+	// Test cases
+	testCases := []struct {
+		driver          string
+		expectedDialect Dialect
+	}{
+		{"mysql", DialectMySQL},
+		{"postgres", DialectPostgres},
+		{"pgx", DialectPostgres},
+		{"sqlite3", DialectSQLite},
+		{"sqlserver", DialectSQLServer},
+		{"unknown-driver", DialectUnknown},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.driver, func(t *testing.T) {
+			// Act
+			dialect := GetDialectByDriver(tc.driver)
+
+			// Assert
+			if dialect != tc.expectedDialect {
+				t.Errorf("Expected dialect: %s, got: %s", tc.expectedDialect, dialect)
+			}
+		})
+	}
+}
+
+func TestRegisterDriverDialect(t *testing.T) {
+	// Arrange
+	driver := "custom-postgres"
+
+	// Act
+	RegisterDriverDialect(driver, DialectPostgres)
+
+	// Assert
+	if dialect := GetDialectByDriver(driver); dialect != DialectPostgres {
+		t.Errorf("Expected dialect: %s, got: %s", DialectPostgres, dialect)
+	}
+}
+
+func TestPlaceholder(t *testing.T) {
+	// Test cases
+	testCases := []struct {
+		dialect             Dialect
+		expectedPlaceholder string
+	}{
+		{DialectMySQL, "?"},
+		{DialectSQLite, "?"},
+		{DialectPostgres, "$3"},
+		{DialectSQLServer, "@p3"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.dialect), func(t *testing.T) {
+			// Act
+			placeholder := tc.dialect.Placeholder(3)
+
+			// Assert
+			if placeholder != tc.expectedPlaceholder {
+				t.Errorf("Expected placeholder: %s, got: %s", tc.expectedPlaceholder, placeholder)
+			}
+		})
+	}
+}
+
+func TestQuoteIdentifier(t *testing.T) {
+	// Test cases
+	testCases := []struct {
+		dialect        Dialect
+		name           string
+		expectedQuoted string
+	}{
+		{DialectMySQL, "users", "`users`"},
+		{DialectPostgres, "public.users", `"public"."users"`},
+		{DialectSQLServer, "dbo.users", "[dbo].[users]"},
+		{DialectSQLite, `odd"name`, `"odd""name"`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.dialect), func(t *testing.T) {
+			// Act
+			quoted := tc.dialect.QuoteIdentifier(tc.name)
+
+			// Assert
+			if quoted != tc.expectedQuoted {
+				t.Errorf("Expected: %s, got: %s", tc.expectedQuoted, quoted)
+			}
+		})
+	}
+}
+
+func TestLiteral(t *testing.T) {
+	// Test cases
+	testCases := []struct {
+		dialect  Dialect
+		value    interface{}
+		expected string
+	}{
+		{DialectPostgres, nil, "NULL"},
+		{DialectPostgres, true, "TRUE"},
+		{DialectSQLServer, true, "1"},
+		{DialectMySQL, int64(-42), "-42"},
+		{DialectSQLite, 1.5, "1.5"},
+		{DialectPostgres, "it's", "'it''s'"},
+		{DialectMySQL, `a\b's`, `'a\\b''s'`},
+		{DialectSQLServer, "şehir", "N'şehir'"},
+		{DialectPostgres, []byte{0x01, 0xff}, `'\x01ff'`},
+		{DialectMySQL, []byte{0x01, 0xff}, "X'01ff'"},
+		{DialectSQLServer, []byte{0x01, 0xff}, "0x01ff"},
+		{DialectSQLite, time.Date(2024, 1, 2, 3, 4, 5, 500000000, time.UTC), "'2024-01-02 03:04:05.5+00:00'"},
+		{DialectPostgres, time.Date(2024, 1, 2, 3, 4, 5, 0, time.FixedZone("TRT", 3*60*60)), "'2024-01-02 00:04:05+00:00'"},
+		{DialectMySQL, time.Date(2024, 1, 2, 3, 4, 5, 0, time.FixedZone("TRT", 3*60*60)), "'2024-01-02 00:04:05'"},
+		{DialectSQLServer, time.Date(2024, 1, 2, 3, 4, 5, 0, time.FixedZone("EST", -5*60*60)), "N'2024-01-02 08:04:05'"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.dialect)+"/"+tc.expected, func(t *testing.T) {
+			// Act
+			literal := tc.dialect.literal(tc.value)
+
+			// Assert
+			if literal != tc.expected {
+				t.Errorf("Expected: %s, got: %s", tc.expected, literal)
+			}
+		})
+	}
+}
+
+func TestRebind(t *testing.T) {
+	// Arrange
+	query := "SELECT * FROM users WHERE name = ? AND note <> 'why?' AND age > ?"
+
+	// Act
+	postgres := DialectPostgres.Rebind(query)
+	mysql := DialectMySQL.Rebind(query)
+
+	// Assert
+	if postgres != "SELECT * FROM users WHERE name = $1 AND note <> 'why?' AND age > $2" {
+		t.Errorf("Unexpected PostgreSQL query: %s", postgres)
+	}
+
+	if mysql != query {
+		t.Errorf("Unexpected MySQL query: %s", mysql)
+	}
+}
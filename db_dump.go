@@ -0,0 +1,617 @@
+package db
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"strings"
+
+	db_criteria "github.com/fatihtatoglu/db-go/criteria"
+	db_errors "github.com/fatihtatoglu/db-go/error"
+)
+
+// DEFAULT_DUMP_BATCH_SIZE is the number of rows Dump reads with a query.
+const DEFAULT_DUMP_BATCH_SIZE = 1000
+
+// DumpFormat is the format of the dumps.
+type DumpFormat string
+
+const (
+	// DumpFormatSQL writes the CREATE TABLE and the INSERT statements of a dialect.
+	DumpFormatSQL DumpFormat = "sql"
+	// DumpFormatJSONL writes a JSON object per line: a header with the columns of every table followed by
+	// its rows, written as DBRow values.
+	DumpFormatJSONL DumpFormat = "jsonl"
+)
+
+// DumpOption configures Dump.
+type DumpOption func(*dumpOptions)
+
+type dumpOptions struct {
+	format    DumpFormat
+	dialect   Dialect
+	schema    bool
+	batchSize int
+}
+
+// WithDumpFormat sets the format of the dump, DumpFormatSQL by default.
+func WithDumpFormat(format DumpFormat) DumpOption {
+	return func(o *dumpOptions) {
+		o.format = format
+	}
+}
+
+// WithDumpDialect sets the dialect of the SQL statements, the dialect of the command by default.
+func WithDumpDialect(dialect Dialect) DumpOption {
+	return func(o *dumpOptions) {
+		o.dialect = dialect
+	}
+}
+
+// WithoutDumpSchema dumps only the rows; the tables are not created by Restore.
+func WithoutDumpSchema() DumpOption {
+	return func(o *dumpOptions) {
+		o.schema = false
+	}
+}
+
+// WithDumpBatchSize sets the number of rows read with a query.
+func WithDumpBatchSize(size int) DumpOption {
+	return func(o *dumpOptions) {
+		o.batchSize = size
+	}
+}
+
+// dumpRecord is a line of a JSONL dump: the header of a table or one of its rows.
+type dumpRecord struct {
+	Table       string           `json:"table"`
+	Create      bool             `json:"create,omitempty"`
+	Columns     []DBColumn       `json:"columns,omitempty"`
+	PrimaryKey  []string         `json:"primaryKey,omitempty"`
+	Indexes     []dumpIndex      `json:"indexes,omitempty"`
+	ForeignKeys []dumpForeignKey `json:"foreignKeys,omitempty"`
+	Row         json.RawMessage  `json:"row,omitempty"`
+}
+
+type dumpIndex struct {
+	Name    string   `json:"name"`
+	Columns []string `json:"columns"`
+	Unique  bool     `json:"unique,omitempty"`
+}
+
+type dumpForeignKey struct {
+	Name              string   `json:"name"`
+	Columns           []string `json:"columns"`
+	ReferencedTable   string   `json:"referencedTable"`
+	ReferencedColumns []string `json:"referencedColumns"`
+	OnDelete          string   `json:"onDelete,omitempty"`
+	OnUpdate          string   `json:"onUpdate,omitempty"`
+}
+
+// Dump writes the schema and the rows of the tables to the writer. The tables are inspected with the
+// Inspector and written in the order of their foreign keys, the referenced tables first. Everything is
+// read in one read-only transaction, so the dump is consistent; the rows are read in batches in the order
+// of the primary key, each batch after the last key of the previous one.
+//
+// The foreign keys which reference the tables out of the dump are left out; the others are added after all
+// the rows, so the tables may reference themselves or each other. SQLite cannot add them later; its dumps
+// defer the foreign key checks to the end of the restore transaction instead. The schema is written with
+// the column types of the source database; to restore into another dialect, create the tables first and
+// dump without the schema. The SQL dumps of SQL Server insert the identity values with IDENTITY_INSERT
+// and the PostgreSQL dumps move the sequences past the inserted values.
+func Dump(command DBCommandInterface, tables []string, writer io.Writer, options ...DumpOption) error {
+	opts := dumpOptions{
+		format:    DumpFormatSQL,
+		dialect:   command.GetDialect(),
+		schema:    true,
+		batchSize: DEFAULT_DUMP_BATCH_SIZE,
+	}
+
+	for _, option := range options {
+		option(&opts)
+	}
+
+	if opts.format != DumpFormatSQL && opts.format != DumpFormatJSONL {
+		return db_errors.DumpInvalidFormatError()
+	}
+
+	switch opts.dialect {
+	case DialectMySQL, DialectPostgres, DialectSQLite, DialectSQLServer:
+	default:
+		return db_errors.DDLUnsupportedDialectError()
+	}
+
+	if reader, ok := command.(snapshotReader); ok {
+		return reader.inSnapshot(func(session DBCommandInterface) error {
+			return dump(session, tables, writer, opts)
+		})
+	}
+
+	return dump(command, tables, writer, opts)
+}
+
+func dump(command DBCommandInterface, tables []string, writer io.Writer, opts dumpOptions) error {
+	inspector, err := CreateNewInspector(command)
+	if err != nil {
+		return err
+	}
+
+	schemas := make([]DBTableSchema, 0, len(tables))
+	for _, name := range tables {
+		table, err := inspector.GetTable(name)
+		if err != nil {
+			return err
+		}
+
+		schemas = append(schemas, *table)
+	}
+
+	w := bufio.NewWriter(writer)
+	if opts.format == DumpFormatSQL && opts.dialect == DialectSQLite {
+		_, err = io.WriteString(w, deferForeignKeysSQL+";\n\n")
+		if err != nil {
+			return err
+		}
+	}
+
+	foreignKeys := make([]string, 0)
+	for _, table := range sortByForeignKeys(schemas) {
+		table.foreignKeys = dumpedForeignKeys(&table, tables)
+
+		if opts.format == DumpFormatJSONL {
+			err = dumpJSONL(command, &table, w, opts)
+		} else {
+			if opts.schema && opts.dialect != DialectSQLite {
+				for i := range table.foreignKeys {
+					foreignKeys = append(foreignKeys, addForeignKeyStatement(opts.dialect, table.name, &table.foreignKeys[i])+";")
+				}
+
+				table.foreignKeys = nil
+			}
+
+			err = dumpSQL(command, &table, w, opts)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(foreignKeys) > 0 {
+		if opts.dialect == DialectSQLServer {
+			foreignKeys = append(foreignKeys, "GO")
+		}
+
+		_, err = io.WriteString(w, strings.Join(append(foreignKeys, ""), "\n")+"\n")
+		if err != nil {
+			return err
+		}
+	}
+
+	return w.Flush()
+}
+
+// deferForeignKeysSQL defers the foreign key checks of SQLite to the end of the transaction, so the rows
+// can reference the rows restored after them.
+const deferForeignKeysSQL = "PRAGMA defer_foreign_keys = ON"
+
+// dumpedForeignKeys returns the foreign keys of the table which reference the dumped tables.
+func dumpedForeignKeys(table *DBTableSchema, tables []string) []DBForeignKey {
+	foreignKeys := make([]DBForeignKey, 0, len(table.foreignKeys))
+	for _, foreignKey := range table.foreignKeys {
+		for _, name := range tables {
+			if foreignKey.referencedTable == name {
+				foreignKeys = append(foreignKeys, foreignKey)
+				break
+			}
+		}
+	}
+
+	return foreignKeys
+}
+
+func dumpSQL(command DBCommandInterface, table *DBTableSchema, w io.Writer, opts dumpOptions) error {
+	dialect := opts.dialect
+	if opts.schema {
+		for _, statement := range createTableStatements(dialect, table) {
+			_, err := io.WriteString(w, statement+";\n")
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	identity := autoIncrementColumn(table)
+	if identity != "" && dialect == DialectSQLServer {
+		_, err := io.WriteString(w, "SET IDENTITY_INSERT "+dialect.QuoteIdentifier(table.name)+" ON;\n")
+		if err != nil {
+			return err
+		}
+	}
+
+	insert := "INSERT INTO " + dialect.QuoteIdentifier(table.name) + " (" + quoteIdentifiers(dialect, table.columnNames()) + ") VALUES ("
+	err := dumpRows(command, table, opts.batchSize, func(values []interface{}) error {
+		literals := make([]string, len(values))
+		for i, value := range values {
+			literals[i] = dialect.literal(value)
+		}
+
+		_, err := io.WriteString(w, insert+strings.Join(literals, ", ")+");\n")
+		return err
+	})
+
+	if err != nil {
+		return err
+	}
+
+	var footer []string
+	if identity != "" && dialect == DialectSQLServer {
+		footer = append(footer, "SET IDENTITY_INSERT "+dialect.QuoteIdentifier(table.name)+" OFF;")
+	}
+
+	if reset := dialect.resetSequenceSQL(table.name, identity); identity != "" && reset != "" {
+		footer = append(footer, reset+";")
+	}
+
+	// SQL Server runs the script in batches separated by the GO lines.
+	if dialect == DialectSQLServer {
+		footer = append(footer, "GO")
+	}
+
+	_, err = io.WriteString(w, strings.Join(append(footer, ""), "\n")+"\n")
+	return err
+}
+
+func dumpJSONL(command DBCommandInterface, table *DBTableSchema, w io.Writer, opts dumpOptions) error {
+	encoder := json.NewEncoder(w)
+
+	header := dumpRecord{
+		Table:   table.name,
+		Create:  opts.schema,
+		Columns: table.columns,
+	}
+
+	if opts.schema {
+		header.PrimaryKey = table.primaryKey
+		for _, index := range table.indexes {
+			header.Indexes = append(header.Indexes, dumpIndex{Name: index.name, Columns: index.columns, Unique: index.unique})
+		}
+
+		for _, fk := range table.foreignKeys {
+			header.ForeignKeys = append(header.ForeignKeys, dumpForeignKey{Name: fk.name, Columns: fk.columns,
+				ReferencedTable: fk.referencedTable, ReferencedColumns: fk.referencedColumns, OnDelete: fk.onDelete, OnUpdate: fk.onUpdate})
+		}
+	}
+
+	err := encoder.Encode(header)
+	if err != nil {
+		return err
+	}
+
+	return dumpRows(command, table, opts.batchSize, func(values []interface{}) error {
+		row := DBRow{itemArray: values}
+		data, err := row.MarshalJSON()
+		if err != nil {
+			return err
+		}
+
+		return encoder.Encode(dumpRecord{Table: table.name, Row: data})
+	})
+}
+
+// dumpRows reads the rows of the table in batches and calls write with the values of every row in the
+// order of the columns. The values of the binary columns are written as bytes and the others as strings.
+//
+// A batch is read after the primary key of the last row of the previous batch. The tables without a
+// primary key are ordered by all their columns and read with an offset, which is stable in the snapshot.
+func dumpRows(command DBCommandInterface, table *DBTableSchema, batchSize int, write func(values []interface{}) error) error {
+	dialect := command.GetDialect()
+	if batchSize <= 0 {
+		batchSize = DEFAULT_DUMP_BATCH_SIZE
+	}
+
+	columns := make([]string, len(table.columns))
+	for i := range table.columns {
+		columns[i] = dialect.QuoteIdentifier(table.columns[i].name)
+	}
+
+	order := make([]db_criteria.Sort, 0, len(table.columns))
+	keys := make([]int, 0, len(table.primaryKey))
+	for _, column := range table.primaryKey {
+		for i := range table.columns {
+			if strings.EqualFold(table.columns[i].name, column) {
+				order = append(order, db_criteria.Asc(table.columns[i].name))
+				keys = append(keys, i)
+				break
+			}
+		}
+	}
+
+	keyset := len(keys) > 0 && len(keys) == len(table.primaryKey)
+	if !keyset {
+		order = order[:0]
+		for i := range table.columns {
+			order = append(order, db_criteria.Asc(table.columns[i].name))
+		}
+	}
+
+	var last []interface{}
+	for offset := 0; ; offset += batchSize {
+		builder := Select(columns...).From(dialect.QuoteIdentifier(table.name)).OrderBySort(order...).Limit(batchSize)
+		if !keyset {
+			builder.Offset(offset)
+		} else if last != nil {
+			builder.WhereCriteria(keysetCriteria(order, last))
+		}
+
+		dt, err := builder.Query(command)
+		if err != nil {
+			return err
+		}
+
+		for _, row := range dt.rows {
+			values := make([]interface{}, len(table.columns))
+			for i := range values {
+				if i >= len(row.itemArray) {
+					break
+				}
+
+				// The commands read every byte value as a string; only the binary columns are written as bytes.
+				value := row.itemArray[i]
+				switch v := value.(type) {
+				case []byte:
+					if !isBinaryColumn(&table.columns[i]) {
+						value = string(v)
+					}
+				case string:
+					if isBinaryColumn(&table.columns[i]) {
+						value = []byte(v)
+					}
+				}
+
+				values[i] = value
+			}
+
+			err = write(values)
+			if err != nil {
+				return err
+			}
+
+			if keyset {
+				last = make([]interface{}, len(keys))
+				for i, key := range keys {
+					last[i] = values[key]
+				}
+			}
+		}
+
+		if len(dt.rows) < batchSize {
+			return nil
+		}
+	}
+}
+
+func (t *DBTableSchema) columnNames() []string {
+	names := make([]string, len(t.columns))
+	for i := range t.columns {
+		names[i] = t.columns[i].name
+	}
+
+	return names
+}
+
+// autoIncrementColumn returns the name of the auto increment column of the table, empty without one.
+func autoIncrementColumn(table *DBTableSchema) string {
+	for i := range table.columns {
+		if table.columns[i].autoIncrement {
+			return table.columns[i].name
+		}
+	}
+
+	return ""
+}
+
+// Restore replays a dump written by Dump into the database of the command in a transaction. The format is
+// detected from the content: the SQL dumps are run with ExecuteScript and the JSONL dumps insert their rows
+// with bind parameters in the dialect of the command, creating the tables first when the dump has the schema.
+//
+// The tables are restored in the order of the dump, which puts the referenced tables first; the foreign keys
+// of the created tables are added after all the rows and SQLite checks them when the transaction commits.
+// The rows restored into existing tables must follow their foreign keys on the other databases. MySQL commits
+// the CREATE TABLE and ALTER TABLE statements implicitly; they are not rolled back.
+func Restore(command DBCommandInterface, reader io.Reader) error {
+	buffered := bufio.NewReader(reader)
+
+	jsonl := false
+	for {
+		b, err := buffered.Peek(1)
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if !isScriptSpace(b[0]) {
+			jsonl = b[0] == '{'
+			break
+		}
+
+		buffered.ReadByte()
+	}
+
+	if !jsonl {
+		_, err := command.ExecuteScript(buffered, WithScriptTransaction())
+		return err
+	}
+
+	session := command
+	if pinner, ok := command.(sessionPinner); ok {
+		pinned, release, err := pinner.pin()
+		if err != nil {
+			return err
+		}
+		defer release()
+
+		session = pinned
+	}
+
+	tx, err := session.Begin()
+	if err != nil {
+		return err
+	}
+
+	err = restoreJSONL(tx, buffered)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// restoredTable is the table whose rows are being restored.
+type restoredTable struct {
+	schema  DBTableSchema
+	table   DBTable
+	columns []string
+}
+
+func restoreJSONL(tx DBCommandInterface, reader io.Reader) error {
+	dialect := tx.GetDialect()
+	decoder := json.NewDecoder(reader)
+
+	if dialect == DialectSQLite {
+		_, err := tx.Execute(deferForeignKeysSQL)
+		if err != nil {
+			return err
+		}
+	}
+
+	// The foreign keys are added after all the rows, except on SQLite, which defers their checks.
+	foreignKeys := make([]string, 0)
+
+	var current *restoredTable
+	for {
+		var record dumpRecord
+		err := decoder.Decode(&record)
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if record.Row != nil {
+			if current == nil || current.schema.name != record.Table {
+				return db_errors.DumpInvalidRecordError()
+			}
+
+			row := current.table.CreateNewDBRow()
+			err = json.Unmarshal(record.Row, &row)
+			if err != nil {
+				return err
+			}
+
+			_, err = Insert(record.Table).Columns(current.columns...).Values(row.itemArray...).Execute(tx)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		err = finishRestoredTable(tx, current)
+		if err != nil {
+			return err
+		}
+
+		current = &restoredTable{
+			schema: DBTableSchema{name: record.Table, columns: record.Columns, primaryKey: record.PrimaryKey},
+			table:  CreateNewDBTable(),
+		}
+
+		for _, column := range record.Columns {
+			current.table.AddDBColumn(column)
+			current.columns = append(current.columns, column.name)
+		}
+
+		for _, index := range record.Indexes {
+			current.schema.indexes = append(current.schema.indexes, CreateNewDBIndex(index.Name, index.Columns, index.Unique))
+		}
+
+		for _, fk := range record.ForeignKeys {
+			foreignKey := CreateNewDBForeignKey(fk.Name, fk.Columns, fk.ReferencedTable, fk.ReferencedColumns)
+			foreignKey.onDelete = fk.OnDelete
+			foreignKey.onUpdate = fk.OnUpdate
+			current.schema.foreignKeys = append(current.schema.foreignKeys, foreignKey)
+		}
+
+		if record.Create {
+			table := current.schema
+			if dialect != DialectSQLite {
+				for i := range table.foreignKeys {
+					foreignKeys = append(foreignKeys, addForeignKeyStatement(dialect, table.name, &table.foreignKeys[i]))
+				}
+
+				table.foreignKeys = nil
+			}
+
+			for _, statement := range createTableStatements(dialect, &table) {
+				_, err = tx.Execute(statement)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		if autoIncrementColumn(&current.schema) != "" && dialect == DialectSQLServer {
+			_, err = tx.Execute("SET IDENTITY_INSERT " + dialect.QuoteIdentifier(record.Table) + " ON")
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	err := finishRestoredTable(tx, current)
+	if err != nil {
+		return err
+	}
+
+	for _, statement := range foreignKeys {
+		_, err = tx.Execute(statement)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// finishRestoredTable turns the explicit identity values of SQL Server off and moves the PostgreSQL
+// sequence of the table past the restored values.
+func finishRestoredTable(tx DBCommandInterface, current *restoredTable) error {
+	if current == nil {
+		return nil
+	}
+
+	identity := autoIncrementColumn(&current.schema)
+	if identity == "" {
+		return nil
+	}
+
+	dialect := tx.GetDialect()
+	statement := dialect.resetSequenceSQL(current.schema.name, identity)
+	if dialect == DialectSQLServer {
+		statement = "SET IDENTITY_INSERT " + dialect.QuoteIdentifier(current.schema.name) + " OFF"
+	}
+
+	if statement == "" {
+		return nil
+	}
+
+	_, err := tx.Execute(statement)
+	return err
+}
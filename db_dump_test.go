@@ -0,0 +1,385 @@
+package db
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	db_errors "github.com/fatihtatoglu/db-go/error"
+	db_mock "github.com/fatihtatoglu/db-go/mock"
+)
+
+// createTestDumpCommand returns a PostgreSQL command which inspects the orders and the customers tables
+// and reads their rows, two rows per batch.
+func createTestDumpCommand() *recordingCommand {
+	tables := createTestInspection(
+		testInspectedTable{
+			columns: [][]interface{}{
+				{"id", int64(1), "bigint", "NO", int64(0), int64(64), int64(0), nil, int64(0)},
+				{"customer_id", int64(2), "bigint", "NO", int64(0), int64(64), int64(0), nil, int64(0)},
+				{"note", int64(3), "text", "YES", int64(0), int64(0), int64(0), nil, int64(0)},
+			},
+			primaryKey: []string{"id"},
+			foreignKeys: [][]interface{}{
+				{"fk_orders_customer", "customer_id", "customers", "id", "CASCADE", "NO ACTION"},
+				{"fk_orders_product", "product_id", "products", "id", "NO ACTION", "NO ACTION"},
+			},
+		},
+		testInspectedTable{
+			columns: [][]interface{}{
+				{"id", int64(1), "bigint", "NO", int64(0), int64(64), int64(0), "nextval('customers_id_seq'::regclass)", int64(1)},
+				{"name", int64(2), "character varying", "NO", int64(100), int64(0), int64(0), nil, int64(0)},
+				{"photo", int64(3), "bytea", "YES", int64(0), int64(0), int64(0), nil, int64(0)},
+			},
+			primaryKey: []string{"id"},
+			indexes:    [][]interface{}{{"ux_customers_name", "name", true}},
+		},
+	)
+
+	return &recordingCommand{
+		dialect: DialectPostgres,
+		tables: append(tables,
+			createTestTable([]string{"id", "name", "photo"}, []interface{}{int64(1), []byte("Ann's"), []byte{0x01, 0xff}}),
+			createTestTable([]string{"id", "customer_id", "note"},
+				[]interface{}{int64(10), int64(1), nil},
+				[]interface{}{int64(11), int64(1), []byte("rush")},
+			),
+		),
+	}
+}
+
+func TestDump_SQL(t *testing.T) {
+	// Arrange
+	command := createTestDumpCommand()
+	var output bytes.Buffer
+
+	// Act
+	err := Dump(command, []string{"orders", "customers"}, &output, WithDumpBatchSize(2))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := `CREATE TABLE "customers" ("id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY, "name" character varying(100) NOT NULL, "photo" bytea NULL, PRIMARY KEY ("id"));
+CREATE UNIQUE INDEX "ux_customers_name" ON "customers" ("name");
+INSERT INTO "customers" ("id", "name", "photo") VALUES (1, 'Ann''s', '\x01ff');
+SELECT setval(pg_get_serial_sequence('"customers"', 'id'), COALESCE(MAX("id"), 0) + 1, false) FROM "customers";
+
+CREATE TABLE "orders" ("id" bigint NOT NULL, "customer_id" bigint NOT NULL, "note" text NULL, PRIMARY KEY ("id"));
+INSERT INTO "orders" ("id", "customer_id", "note") VALUES (10, 1, NULL);
+INSERT INTO "orders" ("id", "customer_id", "note") VALUES (11, 1, 'rush');
+
+ALTER TABLE "orders" ADD CONSTRAINT "fk_orders_customer" FOREIGN KEY ("customer_id") REFERENCES "customers" ("id") ON DELETE CASCADE ON UPDATE NO ACTION;
+
+`
+	if output.String() != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, output.String())
+	}
+
+	dataQueries := command.queries[8:]
+	expectedQueries := []string{
+		`SELECT "id", "name", "photo" FROM "customers" ORDER BY "id" ASC LIMIT $1 OFFSET $2`,
+		`SELECT "id", "customer_id", "note" FROM "orders" ORDER BY "id" ASC LIMIT $1 OFFSET $2`,
+		`SELECT "id", "customer_id", "note" FROM "orders" WHERE (("id" > $1)) ORDER BY "id" ASC LIMIT $2 OFFSET $3`,
+	}
+	if !reflect.DeepEqual(dataQueries, expectedQueries) || !reflect.DeepEqual(command.args[10], []interface{}{int64(11), 2, 0}) {
+		t.Errorf("Expected the rows to be read in batches after the last key, got: %v %v", dataQueries, command.args[8:])
+	}
+}
+
+func TestDump_SQLServerDataOnly(t *testing.T) {
+	// Arrange
+	command := createTestDumpCommand()
+	var output bytes.Buffer
+
+	// Act
+	err := Dump(command, []string{"orders", "customers"}, &output, WithDumpBatchSize(2), WithoutDumpSchema(), WithDumpDialect(DialectSQLServer))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := `SET IDENTITY_INSERT [customers] ON;
+INSERT INTO [customers] ([id], [name], [photo]) VALUES (1, N'Ann''s', 0x01ff);
+SET IDENTITY_INSERT [customers] OFF;
+GO
+
+INSERT INTO [orders] ([id], [customer_id], [note]) VALUES (10, 1, NULL);
+INSERT INTO [orders] ([id], [customer_id], [note]) VALUES (11, 1, N'rush');
+GO
+
+`
+	if output.String() != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, output.String())
+	}
+}
+
+func TestDumpAndRestore_JSONL(t *testing.T) {
+	// Arrange
+	var output bytes.Buffer
+	err := Dump(createTestDumpCommand(), []string{"orders", "customers"}, &output, WithDumpFormat(DumpFormatJSONL), WithDumpBatchSize(2))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	command := &recordingCommand{dialect: DialectPostgres}
+
+	// Act
+	err = Restore(command, &output)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expectedQueries := []string{
+		`CREATE TABLE "customers" ("id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY, "name" character varying(100) NOT NULL, "photo" bytea NULL, PRIMARY KEY ("id"))`,
+		`CREATE UNIQUE INDEX "ux_customers_name" ON "customers" ("name")`,
+		`INSERT INTO "customers" ("id", "name", "photo") VALUES ($1, $2, $3)`,
+		`SELECT setval(pg_get_serial_sequence('"customers"', 'id'), COALESCE(MAX("id"), 0) + 1, false) FROM "customers"`,
+		`CREATE TABLE "orders" ("id" bigint NOT NULL, "customer_id" bigint NOT NULL, "note" text NULL, PRIMARY KEY ("id"))`,
+		`INSERT INTO "orders" ("id", "customer_id", "note") VALUES ($1, $2, $3)`,
+		`INSERT INTO "orders" ("id", "customer_id", "note") VALUES ($1, $2, $3)`,
+		`ALTER TABLE "orders" ADD CONSTRAINT "fk_orders_customer" FOREIGN KEY ("customer_id") REFERENCES "customers" ("id") ON DELETE CASCADE ON UPDATE NO ACTION`,
+	}
+	if !reflect.DeepEqual(command.queries, expectedQueries) {
+		t.Fatalf("Expected:\n%s\ngot:\n%s", strings.Join(expectedQueries, "\n"), strings.Join(command.queries, "\n"))
+	}
+
+	if !reflect.DeepEqual(command.args[2], []interface{}{int64(1), "Ann's", []byte{0x01, 0xff}}) {
+		t.Errorf("Expected the customer values to be restored, got: %#v", command.args[2])
+	}
+
+	if !reflect.DeepEqual(command.args[6], []interface{}{int64(11), int64(1), "rush"}) {
+		t.Errorf("Expected the order values to be restored, got: %#v", command.args[6])
+	}
+
+	if !reflect.DeepEqual(command.events, []string{"begin", "commit"}) {
+		t.Errorf("Expected the restore to run in a transaction, got: %v", command.events)
+	}
+}
+
+func TestDump_Snapshot(t *testing.T) {
+	// Arrange
+	command, mock := createTestMockCommand(t, DialectPostgres)
+	mock.ExpectBegin()
+	mock.ExpectQueryRegexp("column_name").WillReturnRows(db_mock.CreateNewRows(testInspectedColumnNames...).
+		AddRow("id", int64(1), "bigint", "NO", int64(0), int64(64), int64(0), nil, int64(0)).
+		AddRow("parent_id", int64(2), "bigint", "YES", int64(0), int64(64), int64(0), nil, int64(0)))
+	mock.ExpectQueryRegexp(".").WillReturnRows(db_mock.CreateNewRows("column_name").AddRow("id"))
+	mock.ExpectQueryRegexp(".").WillReturnRows(db_mock.CreateNewRows("name", "column", "is_unique"))
+	mock.ExpectQueryRegexp(".").WillReturnRows(db_mock.CreateNewRows("name", "column", "table", "referenced_column", "delete", "update").
+		AddRow("fk_tags_parent", "parent_id", "tags", "id", "NO ACTION", "NO ACTION"))
+	mock.ExpectQuery(`SELECT "id", "parent_id" FROM "tags" ORDER BY "id" ASC LIMIT $1 OFFSET $2`).
+		WithArgs(int64(2), int64(0)).
+		WillReturnRows(db_mock.CreateNewRows("id", "parent_id").AddRow(int64(1), int64(2)).AddRow(int64(2), nil))
+	mock.ExpectQuery(`SELECT "id", "parent_id" FROM "tags" WHERE (("id" > $1)) ORDER BY "id" ASC LIMIT $2 OFFSET $3`).
+		WithArgs(int64(2), int64(2), int64(0)).
+		WillReturnRows(db_mock.CreateNewRows("id", "parent_id"))
+	mock.ExpectRollback()
+
+	var output bytes.Buffer
+
+	// Act
+	err := Dump(command, []string{"tags"}, &output, WithDumpBatchSize(2))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := `CREATE TABLE "tags" ("id" bigint NOT NULL, "parent_id" bigint NULL, PRIMARY KEY ("id"));
+INSERT INTO "tags" ("id", "parent_id") VALUES (1, 2);
+INSERT INTO "tags" ("id", "parent_id") VALUES (2, NULL);
+
+ALTER TABLE "tags" ADD CONSTRAINT "fk_tags_parent" FOREIGN KEY ("parent_id") REFERENCES "tags" ("id") ON DELETE NO ACTION ON UPDATE NO ACTION;
+
+`
+	if output.String() != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, output.String())
+	}
+
+	assertExpectationsWereMet(t, mock)
+}
+
+func TestDumpAndRestore_Binary(t *testing.T) {
+	// Test cases
+	testCases := []struct {
+		name   string
+		format DumpFormat
+	}{
+		{"SQL", DumpFormatSQL},
+		{"JSONL", DumpFormatJSONL},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			command, mock := createTestMockCommand(t, DialectPostgres)
+			mock.ExpectBegin()
+			mock.ExpectQueryRegexp("column_name").WillReturnRows(db_mock.CreateNewRows(testInspectedColumnNames...).
+				AddRow("id", int64(1), "bigint", "NO", int64(0), int64(64), int64(0), nil, int64(0)).
+				AddRow("data", int64(2), "bytea", "YES", int64(0), int64(0), int64(0), nil, int64(0)))
+			mock.ExpectQueryRegexp(".").WillReturnRows(db_mock.CreateNewRows("column_name").AddRow("id"))
+			mock.ExpectQueryRegexp(".").WillReturnRows(db_mock.CreateNewRows("name", "column", "is_unique"))
+			mock.ExpectQueryRegexp(".").WillReturnRows(db_mock.CreateNewRows("name", "column", "table", "referenced_column", "delete", "update"))
+			mock.ExpectQuery(`SELECT "id", "data" FROM "files" ORDER BY "id" ASC LIMIT $1 OFFSET $2`).
+				WithArgs(int64(2), int64(0)).
+				WillReturnRows(db_mock.CreateNewRows("id", "data").WithDatabaseTypes("INT8", "BYTEA").AddRow(int64(1), []byte{0x00, 0xff, '\''}))
+			mock.ExpectRollback()
+
+			var output bytes.Buffer
+			err := Dump(command, []string{"files"}, &output, WithDumpFormat(tc.format), WithDumpBatchSize(2))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if tc.format == DumpFormatSQL && !strings.Contains(output.String(), `VALUES (1, '\x00ff27');`) {
+				t.Fatalf("Expected the bytea literal, got:\n%s", output.String())
+			}
+
+			target := &recordingCommand{dialect: DialectPostgres}
+
+			// Act
+			err = Restore(target, &output)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if tc.format == DumpFormatJSONL && !reflect.DeepEqual(target.args[1], []interface{}{int64(1), []byte{0x00, 0xff, '\''}}) {
+				t.Errorf("Expected the bytes to be restored, got: %#v", target.args[1])
+			}
+
+			assertExpectationsWereMet(t, mock)
+		})
+	}
+}
+
+func TestRestore_SelfReference(t *testing.T) {
+	// Arrange
+	dump := `{"table":"tags","create":true,"columns":[{"name":"id","ordinal":1,"dBType":"bigint"},{"name":"parent_id","ordinal":2,"dBType":"bigint","nullable":true}],` +
+		`"primaryKey":["id"],"foreignKeys":[{"name":"fk_tags_parent","columns":["parent_id"],"referencedTable":"tags","referencedColumns":["id"]}]}
+{"table":"tags","row":[1,2]}
+{"table":"tags","row":[2,null]}
+`
+
+	// Test cases
+	testCases := []struct {
+		name     string
+		dialect  Dialect
+		expected []string
+	}{
+		{
+			name:    "PostgreSQL",
+			dialect: DialectPostgres,
+			expected: []string{
+				`CREATE TABLE "tags" ("id" bigint NOT NULL, "parent_id" bigint NULL, PRIMARY KEY ("id"))`,
+				`INSERT INTO "tags" ("id", "parent_id") VALUES ($1, $2)`,
+				`INSERT INTO "tags" ("id", "parent_id") VALUES ($1, $2)`,
+				`ALTER TABLE "tags" ADD CONSTRAINT "fk_tags_parent" FOREIGN KEY ("parent_id") REFERENCES "tags" ("id")`,
+			},
+		},
+		{
+			name:    "SQLite",
+			dialect: DialectSQLite,
+			expected: []string{
+				"PRAGMA defer_foreign_keys = ON",
+				`CREATE TABLE "tags" ("id" bigint NOT NULL, "parent_id" bigint NULL, PRIMARY KEY ("id"), ` +
+					`CONSTRAINT "fk_tags_parent" FOREIGN KEY ("parent_id") REFERENCES "tags" ("id"))`,
+				`INSERT INTO "tags" ("id", "parent_id") VALUES (?, ?)`,
+				`INSERT INTO "tags" ("id", "parent_id") VALUES (?, ?)`,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			command := &recordingCommand{dialect: tc.dialect}
+
+			// Act
+			err := Restore(command, strings.NewReader(dump))
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(command.queries, tc.expected) {
+				t.Errorf("Expected:\n%s\ngot:\n%s", strings.Join(tc.expected, "\n"), strings.Join(command.queries, "\n"))
+			}
+		})
+	}
+}
+
+func TestRestore_SQLServerIdentity(t *testing.T) {
+	// Arrange
+	source := createTestDumpCommand()
+	source.tables = source.tables[4:9]
+
+	var output bytes.Buffer
+	err := Dump(source, []string{"customers"}, &output, WithDumpFormat(DumpFormatJSONL), WithoutDumpSchema())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	command := &recordingCommand{dialect: DialectSQLServer}
+
+	// Act
+	err = Restore(command, &output)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expectedQueries := []string{
+		"SET IDENTITY_INSERT [customers] ON",
+		"INSERT INTO [customers] ([id], [name], [photo]) VALUES (@p1, @p2, @p3)",
+		"SET IDENTITY_INSERT [customers] OFF",
+	}
+	if !reflect.DeepEqual(command.queries, expectedQueries) {
+		t.Errorf("Expected:\n%s\ngot:\n%s", strings.Join(expectedQueries, "\n"), strings.Join(command.queries, "\n"))
+	}
+}
+
+func TestRestore_SQL(t *testing.T) {
+	// Arrange
+	command := &recordingCommand{dialect: DialectSQLite}
+	script := "\n-- dump\nINSERT INTO \"t\" (\"a\") VALUES ('x;y');\nINSERT INTO \"t\" (\"a\") VALUES (NULL);\n"
+
+	// Act
+	err := Restore(command, strings.NewReader(script))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{`INSERT INTO "t" ("a") VALUES ('x;y')`, `INSERT INTO "t" ("a") VALUES (NULL)`}
+	if !reflect.DeepEqual(command.queries, expected) {
+		t.Errorf("Expected %v, got: %v", expected, command.queries)
+	}
+}
+
+func TestDumpAndRestore_Errors(t *testing.T) {
+	// Arrange
+	command := &recordingCommand{dialect: DialectSQLite}
+
+	// Act
+	formatErr := Dump(command, []string{"t"}, &bytes.Buffer{}, WithDumpFormat("xml"))
+	recordErr := Restore(command, strings.NewReader(`{"table":"t","row":[1]}`))
+
+	// Assert
+	assertError(t, formatErr, db_errors.Dump_InvalidFormatErrorMessage)
+	assertError(t, recordErr, db_errors.Dump_InvalidRecordErrorMessage)
+
+	if !reflect.DeepEqual(command.events, []string{"begin", "rollback"}) {
+		t.Errorf("Expected the restore to be rolled back, got: %v", command.events)
+	}
+}
@@ -0,0 +1,357 @@
+package db
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	db_errors "github.com/fatihtatoglu/db-go/error"
+)
+
+// Entity fields are mapped to columns with the db struct tag:
+//
+//	ID   int64  `db:"id,pk,auto"`
+//	Name string `db:"name"`
+//	Note string `db:"-"`
+//
+// The first tag value is the column name; an empty name falls back to the snake_case field name.
+// Untagged exported fields are mapped with their snake_case names and embedded structs are flattened.
+// The remaining comma separated values are options, either flags such as pk (primary key) and
+// auto (generated by the database) or key=value pairs.
+//
+// A nullable timestamp field tagged with softdelete turns Delete into setting the deletion time:
+//
+//	DeletedAt *time.Time `db:"deleted_at,softdelete"`
+//
+// An integer or timestamp field tagged with version is checked and advanced by every update:
+//
+//	Version int64 `db:"version,version"`
+//
+// The audit fields are set by the repository: created and updated fields receive the current time,
+// createdby and updatedby fields receive the user of the context.
+//
+//	CreatedAt time.Time `db:"created_at,created"`
+//	UpdatedBy string    `db:"updated_by,updatedby"`
+//
+// A foreign key field names the referenced table and column with ref, which orders the writes of a unit of work:
+//
+//	CustomerID int64 `db:"customer_id,ref=customers(id)"`
+//
+// The DDL options describe the column for CreateTableSQL and AutoMigrate: type sets the SQL type, size the
+// length of a string or byte slice column, null makes a non-pointer column nullable and default sets the
+// default expression. index and unique add an index; the fields with the same index name form a composite index.
+// Commas inside parentheses do not separate the options.
+//
+//	Email string  `db:"email,size=320,unique"`
+//	Price float64 `db:"price,type=DECIMAL(10,2),default=0"`
+const (
+	entityTagName = "db"
+
+	tagOptionPrimaryKey    = "pk"
+	tagOptionAutoIncrement = "auto"
+	tagOptionSoftDelete    = "softdelete"
+	tagOptionVersion       = "version"
+	tagOptionCreatedAt     = "created"
+	tagOptionUpdatedAt     = "updated"
+	tagOptionCreatedBy     = "createdby"
+	tagOptionUpdatedBy     = "updatedby"
+	tagOptionReference     = "ref"
+	tagOptionType          = "type"
+	tagOptionSize          = "size"
+	tagOptionNull          = "null"
+	tagOptionDefault       = "default"
+	tagOptionIndex         = "index"
+	tagOptionUnique        = "unique"
+)
+
+type entityField struct {
+	name      string
+	column    string
+	index     []int
+	fieldType reflect.Type
+	options   map[string]string
+}
+
+func (f *entityField) hasOption(name string) bool {
+	_, ok := f.options[name]
+	return ok
+}
+
+func (f *entityField) option(name string) (string, bool) {
+	value, ok := f.options[name]
+	return value, ok
+}
+
+func (f *entityField) isPrimaryKey() bool {
+	return f.hasOption(tagOptionPrimaryKey)
+}
+
+func (f *entityField) isAutoIncrement() bool {
+	return f.hasOption(tagOptionAutoIncrement)
+}
+
+func (f *entityField) isSoftDelete() bool {
+	return f.hasOption(tagOptionSoftDelete)
+}
+
+func (f *entityField) isVersion() bool {
+	return f.hasOption(tagOptionVersion)
+}
+
+// reference returns the table and the column referenced by the field; the column is empty when it is not given.
+func (f *entityField) reference() (string, string, bool) {
+	value, ok := f.option(tagOptionReference)
+	if !ok || value == "" {
+		return "", "", false
+	}
+
+	table, column, _ := strings.Cut(value, "(")
+	return strings.TrimSpace(table), strings.TrimSpace(strings.TrimSuffix(column, ")")), true
+}
+
+// isCreateAudit reports whether the field is only written when the record is created.
+func (f *entityField) isCreateAudit() bool {
+	return f.hasOption(tagOptionCreatedAt) || f.hasOption(tagOptionCreatedBy)
+}
+
+type entityMetadata struct {
+	entityType reflect.Type
+	fields     []*entityField
+	columns    map[string]*entityField
+	relations  map[string]*entityRelation
+}
+
+var entityMetadataCache sync.Map
+
+var (
+	timeType    = reflect.TypeOf(time.Time{})
+	scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	valuerType  = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+)
+
+// getEntityMetadata returns the column mapping of the struct type or the pointer to struct type.
+func getEntityMetadata(t reflect.Type) (*entityMetadata, error) {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, db_errors.EntityInvalidTypeError()
+	}
+
+	if cached, ok := entityMetadataCache.Load(t); ok {
+		return cached.(*entityMetadata), nil
+	}
+
+	m := &entityMetadata{
+		entityType: t,
+		fields:     make([]*entityField, 0),
+		columns:    make(map[string]*entityField),
+		relations:  make(map[string]*entityRelation),
+	}
+	m.collectFields(t, nil)
+
+	cached, _ := entityMetadataCache.LoadOrStore(t, m)
+	return cached.(*entityMetadata), nil
+}
+
+func (m *entityMetadata) collectFields(t reflect.Type, parentIndex []int) {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag, tagged := sf.Tag.Lookup(entityTagName)
+		if tag == "-" {
+			continue
+		}
+
+		index := append(append([]int{}, parentIndex...), i)
+		if relationTag, ok := sf.Tag.Lookup(relationTagName); ok {
+			m.relations[sf.Name] = parseRelation(m.entityType, sf, index, relationTag)
+			continue
+		}
+
+		if sf.Anonymous && !tagged && sf.Type.Kind() == reflect.Struct && !isValueStruct(sf.Type) {
+			m.collectFields(sf.Type, index)
+			continue
+		}
+
+		if !sf.IsExported() {
+			continue
+		}
+
+		field := &entityField{
+			name:      sf.Name,
+			index:     index,
+			fieldType: sf.Type,
+			options:   make(map[string]string),
+		}
+
+		parts := splitTagOptions(tag)
+		field.column = strings.TrimSpace(parts[0])
+		if field.column == "" {
+			field.column = toSnakeCase(sf.Name)
+		}
+
+		for _, part := range parts[1:] {
+			key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+			if key != "" {
+				field.options[key] = value
+			}
+		}
+
+		m.fields = append(m.fields, field)
+		m.columns[field.column] = field
+	}
+}
+
+func (m *entityMetadata) primaryKeys() []*entityField {
+	keys := make([]*entityField, 0)
+	for _, f := range m.fields {
+		if f.isPrimaryKey() {
+			keys = append(keys, f)
+		}
+	}
+
+	return keys
+}
+
+// softDeleteField returns the field tagged with softdelete, nil when the entity is deleted physically.
+func (m *entityMetadata) softDeleteField() *entityField {
+	for _, f := range m.fields {
+		if f.isSoftDelete() {
+			return f
+		}
+	}
+
+	return nil
+}
+
+// versionField returns the field tagged with version, nil when the entity is not versioned.
+func (m *entityMetadata) versionField() *entityField {
+	for _, f := range m.fields {
+		if f.isVersion() {
+			return f
+		}
+	}
+
+	return nil
+}
+
+// referencedTables returns the distinct tables referenced by the fields in field order.
+func (m *entityMetadata) referencedTables() []string {
+	tables := make([]string, 0)
+	for _, f := range m.fields {
+		table, _, ok := f.reference()
+		if ok && !slices.Contains(tables, table) {
+			tables = append(tables, table)
+		}
+	}
+
+	return tables
+}
+
+// insertFields returns the fields written by an INSERT statement.
+func (m *entityMetadata) insertFields() []*entityField {
+	fields := make([]*entityField, 0, len(m.fields))
+	for _, f := range m.fields {
+		if !f.isAutoIncrement() {
+			fields = append(fields, f)
+		}
+	}
+
+	return fields
+}
+
+func (m *entityMetadata) fieldByColumn(column string) (*entityField, bool) {
+	f, ok := m.columns[column]
+	return f, ok
+}
+
+// structValue returns the addressable struct value of the entity, dereferencing pointers.
+func (m *entityMetadata) structValue(entity interface{}) (reflect.Value, error) {
+	v := reflect.ValueOf(entity)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return reflect.Value{}, db_errors.EntityInvalidTypeError()
+		}
+
+		v = v.Elem()
+	}
+
+	if v.Type() != m.entityType {
+		return reflect.Value{}, db_errors.EntityInvalidTypeError()
+	}
+
+	return v, nil
+}
+
+func (m *entityMetadata) values(v reflect.Value, fields []*entityField) []interface{} {
+	values := make([]interface{}, len(fields))
+	for i, f := range fields {
+		values[i] = v.FieldByIndex(f.index).Interface()
+	}
+
+	return values
+}
+
+func columnNames(fields []*entityField) []string {
+	names := make([]string, len(fields))
+	for i, f := range fields {
+		names[i] = f.column
+	}
+
+	return names
+}
+
+// splitTagOptions splits the tag by the commas which are not inside parentheses.
+func splitTagOptions(tag string) []string {
+	parts := make([]string, 0, 4)
+
+	depth, start := 0, 0
+	for i, r := range tag {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth = max(depth-1, 0)
+		case ',':
+			if depth == 0 {
+				parts = append(parts, tag[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	return append(parts, tag[start:])
+}
+
+// isValueStruct reports whether the struct type is stored in a single column.
+func isValueStruct(t reflect.Type) bool {
+	return t == timeType || t.Implements(valuerType) || reflect.PointerTo(t).Implements(scannerType)
+}
+
+func toSnakeCase(name string) string {
+	runes := []rune(name)
+
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			previousLower := i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]))
+			nextLower := i > 0 && i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1])
+			if previousLower || nextLower {
+				b.WriteRune('_')
+			}
+
+			b.WriteRune(unicode.ToLower(r))
+			continue
+		}
+
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
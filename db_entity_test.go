@@ -0,0 +1,130 @@
+package db
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	db_errors "github.com/fatihtatoglu/db-go/error"
+)
+
+type testAuditInfo struct {
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type testUser struct {
+	testAuditInfo
+	ID        int64  `db:"id,pk,auto"`
+	FirstName string `db:"first_name,size=100"`
+	Age       int
+	Password  string `db:"-"`
+	internal  string
+}
+
+func TestGetEntityMetadata(t *testing.T) {
+	// Act
+	metadata, err := getEntityMetadata(reflect.TypeOf(&testUser{}))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expectedColumns := []string{"created_at", "id", "first_name", "age"}
+	columns := columnNames(metadata.fields)
+	if !reflect.DeepEqual(columns, expectedColumns) {
+		t.Errorf("Expected columns: %v, got: %v", expectedColumns, columns)
+	}
+
+	id, _ := metadata.fieldByColumn("id")
+	if !id.isPrimaryKey() || !id.isAutoIncrement() {
+		t.Error("Expected id to be an auto increment primary key")
+	}
+
+	firstName, _ := metadata.fieldByColumn("first_name")
+	if size, ok := firstName.option("size"); !ok || size != "100" {
+		t.Errorf("Expected size option: 100, got: %s", size)
+	}
+
+	insertColumns := columnNames(metadata.insertFields())
+	if !reflect.DeepEqual(insertColumns, []string{"created_at", "first_name", "age"}) {
+		t.Errorf("Unexpected insert columns: %v", insertColumns)
+	}
+}
+
+func TestGetEntityMetadata_Invalid_Type(t *testing.T) {
+	// Act
+	_, err := getEntityMetadata(reflect.TypeOf(42))
+
+	// Assert
+	assertError(t, err, db_errors.Entity_InvalidTypeErrorMessage)
+}
+
+func TestToSnakeCase(t *testing.T) {
+	// Test cases
+	testCases := []struct {
+		name     string
+		expected string
+	}{
+		{"ID", "id"},
+		{"FirstName", "first_name"},
+		{"UserID", "user_id"},
+		{"HTTPServer", "http_server"},
+		{"Address2Line", "address2_line"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			result := toSnakeCase(tc.name)
+
+			// Assert
+			if result != tc.expected {
+				t.Errorf("Expected: %s, got: %s", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestEntityField_Reference(t *testing.T) {
+	// Arrange
+	metadata, _ := getEntityMetadata(reflect.TypeOf(testOrder{}))
+	field, _ := metadata.fieldByColumn("customer_id")
+
+	// Act
+	table, column, ok := field.reference()
+
+	// Assert
+	if !ok || table != "customers" || column != "id" {
+		t.Errorf("Expected customers(id), got: %s(%s)", table, column)
+	}
+
+	if !reflect.DeepEqual(metadata.referencedTables(), []string{"customers"}) {
+		t.Errorf("Unexpected referenced tables: %v", metadata.referencedTables())
+	}
+}
+
+func TestSplitTagOptions(t *testing.T) {
+	// Test cases
+	testCases := []struct {
+		tag      string
+		expected []string
+	}{
+		{"id,pk,auto", []string{"id", "pk", "auto"}},
+		{"price,type=DECIMAL(10,2),default=0", []string{"price", "type=DECIMAL(10,2)", "default=0"}},
+		{"customer_id,ref=customers(id)", []string{"customer_id", "ref=customers(id)"}},
+		{"", []string{""}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.tag, func(t *testing.T) {
+			// Act
+			result := splitTagOptions(tc.tag)
+
+			// Assert
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("Expected: %q, got: %q", tc.expected, result)
+			}
+		})
+	}
+}
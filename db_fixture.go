@@ -0,0 +1,668 @@
+package db
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	db_errors "github.com/fatihtatoglu/db-go/error"
+)
+
+// FIXTURE_LABEL_KEY is the key which names a row in the list form of a fixture file.
+const FIXTURE_LABEL_KEY = "_label"
+
+// fixtureTemplate matches the {{...}} templates in the values of the fixture rows.
+var fixtureTemplate = regexp.MustCompile(`\{\{\s*([^{}]*?)\s*\}\}`)
+
+// FixtureDecoder decodes the content of a fixture file into v, e.g. json.Unmarshal or yaml.Unmarshal.
+type FixtureDecoder func(data []byte, v interface{}) error
+
+// FixtureOption configures a FixtureLoader.
+type FixtureOption func(*fixtureOptions)
+
+type fixtureOptions struct {
+	decoders map[string]FixtureDecoder
+	now      time.Time
+}
+
+// WithFixtureDecoder decodes the fixture files with the extension, e.g. ".yaml" and ".yml" with
+// yaml.Unmarshal. The .json files are decoded without an option. The library ships no YAML decoder; the
+// YAML files are skipped unless a decoder is given for their extension.
+func WithFixtureDecoder(extension string, decoder FixtureDecoder) FixtureOption {
+	return func(o *fixtureOptions) {
+		o.decoders[strings.ToLower(extension)] = decoder
+	}
+}
+
+// WithFixtureNow fixes the time of the {{now}} templates; they use the time of the load by default.
+func WithFixtureNow(now time.Time) FixtureOption {
+	return func(o *fixtureOptions) {
+		o.now = now
+	}
+}
+
+// FixtureLoader loads the rows of the fixture files into the database, e.g. before each integration test.
+type FixtureLoader struct {
+	command DBCommandInterface
+	tables  []fixtureTable
+	options fixtureOptions
+}
+
+type fixtureTable struct {
+	name string
+	rows []fixtureRow
+}
+
+type fixtureRow struct {
+	label  string
+	values map[string]interface{}
+}
+
+// CreateNewFixtureLoader reads the fixture files in the root of fsys, in the order of their names. A file
+// maps the table names to their rows, either a list of rows or a map of the rows by their labels:
+//
+//	{
+//	  "customers": {"ann": {"name": "Ann", "created_at": "{{now}}"}},
+//	  "orders": [{"customer_id": "{{ref:customers.ann}}", "code": "A-{{seq}}"}]
+//	}
+//
+// A row of a list is labeled with the _label key. The rows of a table in several files are loaded together.
+// Only the .json files are decoded by default; the files of the other extensions, YAML included, are skipped
+// unless their decoder is given with WithFixtureDecoder.
+func CreateNewFixtureLoader(command DBCommandInterface, fsys fs.FS, options ...FixtureOption) (*FixtureLoader, error) {
+	if command == nil {
+		return nil, db_errors.FixtureNilCommandError()
+	}
+
+	l := &FixtureLoader{
+		command: command,
+		options: fixtureOptions{
+			decoders: map[string]FixtureDecoder{".json": decodeFixtureJSON},
+		},
+	}
+
+	for _, option := range options {
+		option(&l.options)
+	}
+
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		decoder, ok := l.options.decoders[strings.ToLower(path.Ext(entry.Name()))]
+		if !ok {
+			continue
+		}
+
+		data, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		err = l.add(data, decoder)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", entry.Name(), err)
+		}
+	}
+
+	return l, nil
+}
+
+// GetTables returns the names of the tables of the fixtures in the order they were read.
+func (l *FixtureLoader) GetTables() []string {
+	names := make([]string, len(l.tables))
+	for i := range l.tables {
+		names[i] = l.tables[i].name
+	}
+
+	return names
+}
+
+// Load replaces the rows of the fixture tables in a transaction. The tables are cleared with DELETE, the
+// referencing tables first, and filled in the reverse order, so the foreign keys hold at each statement.
+// The auto increment counters are reset after the clearing, so the generated values start from 1, and
+// again after the inserts, so the later rows continue after the explicit values.
+//
+// The templates of the string values are replaced before the insert:
+//
+//	{{now}}, {{now-24h}}          the time of the load, moved by a duration
+//	{{seq}}                       the 1-based position of the row in its table
+//	{{ref:table.label.column}}    a value of a labeled row loaded before; the column defaults to the primary key
+//
+// A value which is a single template keeps the type of its value; the templates inside a longer text are
+// written as text. The generated key of a labeled row is read back for the references, so the referenced
+// tables need no explicit keys.
+//
+// MySQL resets the counters with ALTER TABLE, which commits a transaction implicitly, so its counters are
+// reset once after the commit: the generated values of a load continue after the rows of the previous one.
+func (l *FixtureLoader) Load() error {
+	session := l.command
+	if pinner, ok := l.command.(sessionPinner); ok {
+		pinned, release, err := pinner.pin()
+		if err != nil {
+			return err
+		}
+		defer release()
+
+		session = pinned
+	}
+
+	tx, err := session.Begin()
+	if err != nil {
+		return err
+	}
+
+	resets, err := l.load(tx)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	for _, statement := range resets {
+		_, err = session.Execute(statement)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fixtureLoad is the state of a single Load.
+type fixtureLoad struct {
+	tx      DBCommandInterface
+	now     time.Time
+	schemas map[string]*DBTableSchema
+	// loaded holds the values of the labeled rows by their table and label.
+	loaded map[string]map[string]map[string]interface{}
+}
+
+// load replaces the rows in the transaction and returns the counter resets which must run after its commit.
+func (l *FixtureLoader) load(tx DBCommandInterface) ([]string, error) {
+	inspector, err := CreateNewInspector(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &fixtureLoad{
+		tx:      tx,
+		now:     l.options.now,
+		schemas: make(map[string]*DBTableSchema, len(l.tables)),
+		loaded:  make(map[string]map[string]map[string]interface{}, len(l.tables)),
+	}
+
+	if state.now.IsZero() {
+		state.now = time.Now()
+	}
+
+	schemas := make([]DBTableSchema, 0, len(l.tables))
+	for _, table := range l.tables {
+		schema, err := inspector.GetTable(table.name)
+		if err != nil {
+			return nil, err
+		}
+
+		schemas = append(schemas, *schema)
+	}
+
+	sorted := sortByForeignKeys(schemas)
+	for i := range sorted {
+		state.schemas[sorted[i].name] = &sorted[i]
+	}
+
+	dialect := tx.GetDialect()
+	for i := len(sorted) - 1; i >= 0; i-- {
+		_, err = tx.Execute("DELETE FROM " + dialect.QuoteIdentifier(sorted[i].name))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resets, err := fixtureResetStatements(tx, sorted)
+	if err != nil {
+		return nil, err
+	}
+
+	// ALTER TABLE commits the transaction of MySQL implicitly; its counters are reset after the commit.
+	if dialect == DialectMySQL {
+		err = l.insert(state, sorted)
+		if err != nil {
+			return nil, err
+		}
+
+		return resets, nil
+	}
+
+	for _, statement := range resets {
+		_, err = tx.Execute(statement)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = l.insert(state, sorted)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, statement := range resets {
+		_, err = tx.Execute(statement)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return nil, nil
+}
+
+// insert inserts the rows of the tables, the referenced tables first.
+func (l *FixtureLoader) insert(state *fixtureLoad, sorted []DBTableSchema) error {
+	for i := range sorted {
+		err := state.insert(&sorted[i], l.table(sorted[i].name).rows)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (l *FixtureLoader) table(name string) *fixtureTable {
+	for i := range l.tables {
+		if strings.EqualFold(l.tables[i].name, name) {
+			return &l.tables[i]
+		}
+	}
+
+	return nil
+}
+
+// add merges the tables of a decoded fixture file.
+func (l *FixtureLoader) add(data []byte, decoder FixtureDecoder) error {
+	var content map[string]interface{}
+	err := decoder(data, &content)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(content))
+	for name := range content {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		rows, err := fixtureRows(content[name])
+		if err != nil {
+			return err
+		}
+
+		table := l.table(name)
+		if table == nil {
+			l.tables = append(l.tables, fixtureTable{name: name})
+			table = &l.tables[len(l.tables)-1]
+		}
+
+		for _, row := range rows {
+			if row.label != "" && table.find(row.label) != nil {
+				return db_errors.FixtureDuplicateLabelError()
+			}
+
+			table.rows = append(table.rows, row)
+		}
+	}
+
+	return nil
+}
+
+func (t *fixtureTable) find(label string) *fixtureRow {
+	for i := range t.rows {
+		if t.rows[i].label == label {
+			return &t.rows[i]
+		}
+	}
+
+	return nil
+}
+
+// fixtureRows reads the rows of a table, a list of rows or a map of the rows by their labels sorted by
+// the labels.
+func fixtureRows(value interface{}) ([]fixtureRow, error) {
+	if list, ok := value.([]interface{}); ok {
+		rows := make([]fixtureRow, 0, len(list))
+		for _, item := range list {
+			values, ok := fixtureMap(item)
+			if !ok {
+				return nil, db_errors.FixtureInvalidFileError()
+			}
+
+			label := ""
+			if value, ok := values[FIXTURE_LABEL_KEY]; ok {
+				label = fmt.Sprint(value)
+				delete(values, FIXTURE_LABEL_KEY)
+			}
+
+			rows = append(rows, fixtureRow{label: label, values: values})
+		}
+
+		return rows, nil
+	}
+
+	labeled, ok := fixtureMap(value)
+	if !ok {
+		return nil, db_errors.FixtureInvalidFileError()
+	}
+
+	labels := make([]string, 0, len(labeled))
+	for label := range labeled {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+
+	rows := make([]fixtureRow, 0, len(labeled))
+	for _, label := range labels {
+		values, ok := fixtureMap(labeled[label])
+		if !ok {
+			return nil, db_errors.FixtureInvalidFileError()
+		}
+
+		rows = append(rows, fixtureRow{label: label, values: values})
+	}
+
+	return rows, nil
+}
+
+// fixtureMap reads a map of a decoded file; the YAML decoders may key the maps with interface{}.
+func fixtureMap(value interface{}) (map[string]interface{}, bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return v, true
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			m[fmt.Sprint(key)] = item
+		}
+
+		return m, true
+	}
+
+	return nil, false
+}
+
+// insert inserts the rows of the table, the labeled ones one by one with their generated keys read back.
+func (s *fixtureLoad) insert(table *DBTableSchema, rows []fixtureRow) error {
+	dialect := s.tx.GetDialect()
+	identity := autoIncrementColumn(table)
+
+	identityInsert := false
+	if dialect == DialectSQLServer && identity != "" {
+		for _, row := range rows {
+			if _, ok := row.values[identity]; ok {
+				identityInsert = true
+				break
+			}
+		}
+	}
+
+	if identityInsert {
+		_, err := s.tx.Execute("SET IDENTITY_INSERT " + dialect.QuoteIdentifier(table.name) + " ON")
+		if err != nil {
+			return err
+		}
+	}
+
+	for i, row := range rows {
+		values := make(map[string]interface{}, len(row.values))
+		for column, value := range row.values {
+			resolved, err := s.resolve(value, i+1)
+			if err != nil {
+				return err
+			}
+
+			values[column] = resolved
+		}
+
+		columns := make([]string, 0, len(values))
+		for column := range values {
+			columns = append(columns, column)
+		}
+		sort.Strings(columns)
+
+		args := make([]interface{}, len(columns))
+		for j, column := range columns {
+			args[j] = values[column]
+		}
+
+		builder := Insert(table.name).Columns(columns...).Values(args...)
+
+		_, explicit := values[identity]
+		if row.label == "" || identity == "" || explicit {
+			_, err := builder.Execute(s.tx)
+			if err != nil {
+				return err
+			}
+		} else {
+			generated, err := s.insertReturning(builder, identity)
+			if err != nil {
+				return err
+			}
+
+			values[identity] = generated
+		}
+
+		if row.label != "" {
+			if s.loaded[table.name] == nil {
+				s.loaded[table.name] = make(map[string]map[string]interface{})
+			}
+
+			s.loaded[table.name][row.label] = values
+		}
+	}
+
+	if identityInsert {
+		_, err := s.tx.Execute("SET IDENTITY_INSERT " + dialect.QuoteIdentifier(table.name) + " OFF")
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// insertReturning inserts a row and returns the generated value of its auto increment column.
+func (s *fixtureLoad) insertReturning(builder *InsertBuilder, identity string) (interface{}, error) {
+	if s.tx.GetDialect() == DialectMySQL {
+		result, err := builder.Execute(s.tx)
+		if err != nil {
+			return nil, err
+		}
+
+		return (*result).LastInsertId()
+	}
+
+	inserted, err := builder.Returning(identity).Query(s.tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(inserted.rows) == 0 || len(inserted.rows[0].itemArray) == 0 {
+		return nil, db_errors.FixtureReferenceNotFoundError()
+	}
+
+	return inserted.rows[0].itemArray[0], nil
+}
+
+// resolve replaces the templates of a value; seq is the position of the row in its table. The numbers of
+// the JSON files are read as int64 when they have no fraction and the nested values are written as JSON.
+func (s *fixtureLoad) resolve(value interface{}, seq int) (interface{}, error) {
+	switch v := value.(type) {
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n, nil
+		}
+
+		return v.Float64()
+	case map[string]interface{}, []interface{}:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+
+		return string(data), nil
+	case string:
+		matches := fixtureTemplate.FindAllStringSubmatchIndex(v, -1)
+		if len(matches) == 0 {
+			return v, nil
+		}
+
+		if len(matches) == 1 && matches[0][0] == 0 && matches[0][1] == len(v) {
+			return s.template(v[matches[0][2]:matches[0][3]], seq)
+		}
+
+		var sb strings.Builder
+		last := 0
+		for _, match := range matches {
+			resolved, err := s.template(v[match[2]:match[3]], seq)
+			if err != nil {
+				return nil, err
+			}
+
+			if t, ok := resolved.(time.Time); ok {
+				resolved = t.Format(time.RFC3339)
+			}
+
+			sb.WriteString(v[last:match[0]])
+			sb.WriteString(fmt.Sprint(resolved))
+			last = match[1]
+		}
+		sb.WriteString(v[last:])
+
+		return sb.String(), nil
+	}
+
+	return value, nil
+}
+
+func (s *fixtureLoad) template(expression string, seq int) (interface{}, error) {
+	switch {
+	case expression == "seq":
+		return int64(seq), nil
+	case expression == "now":
+		return s.now, nil
+	case strings.HasPrefix(expression, "now+"), strings.HasPrefix(expression, "now-"):
+		offset, err := time.ParseDuration(expression[3:])
+		if err != nil {
+			return nil, db_errors.FixtureUnknownTemplateError()
+		}
+
+		return s.now.Add(offset), nil
+	case strings.HasPrefix(expression, "ref:"):
+		return s.reference(strings.TrimSpace(expression[4:]))
+	}
+
+	return nil, db_errors.FixtureUnknownTemplateError()
+}
+
+// reference returns the value of a table.label.column reference; the column of a table.label reference is
+// the single column primary key of the table.
+func (s *fixtureLoad) reference(reference string) (interface{}, error) {
+	parts := strings.SplitN(reference, ".", 3)
+	if len(parts) < 2 {
+		return nil, db_errors.FixtureReferenceNotFoundError()
+	}
+
+	rows, ok := s.loaded[parts[0]]
+	if !ok {
+		return nil, db_errors.FixtureReferenceNotFoundError()
+	}
+
+	row, ok := rows[parts[1]]
+	if !ok {
+		return nil, db_errors.FixtureReferenceNotFoundError()
+	}
+
+	column := ""
+	if len(parts) == 3 {
+		column = parts[2]
+	} else if schema := s.schemas[parts[0]]; schema != nil && len(schema.primaryKey) == 1 {
+		column = schema.primaryKey[0]
+	}
+
+	value, ok := row[column]
+	if !ok {
+		return nil, db_errors.FixtureReferenceNotFoundError()
+	}
+
+	return value, nil
+}
+
+// fixtureResetStatements returns the statements which reset the auto increment counters of the tables
+// to the largest value of their column, to 0 for the empty tables.
+func fixtureResetStatements(tx DBCommandInterface, tables []DBTableSchema) ([]string, error) {
+	dialect := tx.GetDialect()
+
+	if dialect == DialectSQLite {
+		// The counters of SQLite are kept in sqlite_sequence only for the AUTOINCREMENT tables; the other
+		// tables continue after their largest key.
+		found, err := tx.Query("SELECT name FROM sqlite_master WHERE type = 'table' AND name = 'sqlite_sequence'")
+		if err != nil {
+			return nil, err
+		}
+
+		if len(found.rows) == 0 {
+			return nil, nil
+		}
+	}
+
+	statements := make([]string, 0, len(tables))
+	for i := range tables {
+		identity := autoIncrementColumn(&tables[i])
+		if identity == "" {
+			continue
+		}
+
+		name := tables[i].name
+		switch dialect {
+		case DialectPostgres:
+			statements = append(statements, dialect.resetSequenceSQL(name, identity))
+		case DialectMySQL:
+			// MySQL moves the counter up to the largest value of the column.
+			statements = append(statements, "ALTER TABLE "+dialect.QuoteIdentifier(name)+" AUTO_INCREMENT = 1")
+		case DialectSQLite:
+			statements = append(statements, "UPDATE sqlite_sequence SET seq = (SELECT COALESCE(MAX("+
+				dialect.QuoteIdentifier(identity)+"), 0) FROM "+dialect.QuoteIdentifier(name)+") WHERE name = "+dialect.stringLiteral(name))
+		case DialectSQLServer:
+			// A table which never had a row starts from its seed; reseeding it would start from 0.
+			statements = append(statements, "IF (SELECT last_value FROM sys.identity_columns WHERE object_id = OBJECT_ID("+
+				dialect.stringLiteral(dialect.QuoteIdentifier(name))+") AND name = "+dialect.stringLiteral(identity)+") IS NOT NULL "+
+				"BEGIN DECLARE @seed BIGINT = (SELECT COALESCE(MAX("+dialect.QuoteIdentifier(identity)+"), 0) FROM "+
+				dialect.QuoteIdentifier(name)+"); DBCC CHECKIDENT ("+dialect.stringLiteral(dialect.QuoteIdentifier(name))+", RESEED, @seed) END")
+		}
+	}
+
+	return statements, nil
+}
+
+// decodeFixtureJSON decodes the JSON fixture files, keeping the numbers as json.Number for the int64 keys.
+func decodeFixtureJSON(data []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
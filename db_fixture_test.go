@@ -0,0 +1,279 @@
+package db
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	db_errors "github.com/fatihtatoglu/db-go/error"
+	db_mock "github.com/fatihtatoglu/db-go/mock"
+)
+
+// createTestFixtureCommand returns a PostgreSQL command which inspects the orders and the customers tables
+// and returns the generated keys of two customers.
+func createTestFixtureCommand() *recordingCommand {
+	tables := createTestInspection(
+		testInspectedTable{
+			columns: [][]interface{}{
+				{"id", int64(1), "bigint", "NO", int64(0), int64(64), int64(0), nil, int64(0)},
+				{"customer_id", int64(2), "bigint", "NO", int64(0), int64(64), int64(0), nil, int64(0)},
+				{"code", int64(3), "text", "NO", int64(0), int64(0), int64(0), nil, int64(0)},
+				{"created_at", int64(4), "timestamp", "NO", int64(0), int64(0), int64(0), nil, int64(0)},
+				{"details", int64(5), "jsonb", "YES", int64(0), int64(0), int64(0), nil, int64(0)},
+			},
+			primaryKey:  []string{"id"},
+			foreignKeys: [][]interface{}{{"fk_orders_customer", "customer_id", "customers", "id", "CASCADE", "NO ACTION"}},
+		},
+		testInspectedTable{
+			columns: [][]interface{}{
+				{"id", int64(1), "bigint", "NO", int64(0), int64(64), int64(0), "nextval('customers_id_seq'::regclass)", int64(1)},
+				{"name", int64(2), "character varying", "NO", int64(100), int64(0), int64(0), nil, int64(0)},
+			},
+			primaryKey: []string{"id"},
+		},
+	)
+
+	return &recordingCommand{
+		dialect: DialectPostgres,
+		tables: append(tables,
+			createTestTable([]string{"id"}, []interface{}{int64(7)}),
+			createTestTable([]string{"id"}, []interface{}{int64(8)}),
+		),
+	}
+}
+
+func TestFixtureLoader_Load(t *testing.T) {
+	// Arrange
+	fsys := fstest.MapFS{
+		"1_orders.json": {Data: []byte(`{
+			"orders": [
+				{"id": "{{seq}}", "customer_id": "{{ref:customers.bob}}", "code": "A-{{seq}}", "created_at": "{{now-1h}}", "details": {"rush": true}},
+				{"id": 10, "customer_id": "{{ref:customers.ann.id}}", "code": "{{ref:customers.ann.name}} at {{now}}", "created_at": "{{now}}"}
+			]
+		}`)},
+		"2_customers.json": {Data: []byte(`{"customers": {"bob": {"name": "Bob"}, "ann": {"name": "Ann"}}}`)},
+		"readme.md":        {Data: []byte("not a fixture")},
+	}
+
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	command := createTestFixtureCommand()
+
+	loader, err := CreateNewFixtureLoader(command, fsys, WithFixtureNow(now))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Act
+	err = loader.Load()
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(loader.GetTables(), []string{"orders", "customers"}) {
+		t.Errorf("Expected the tables in the order of the files, got: %v", loader.GetTables())
+	}
+
+	reset := `SELECT setval(pg_get_serial_sequence('"customers"', 'id'), COALESCE(MAX("id"), 0) + 1, false) FROM "customers"`
+	expectedQueries := []string{
+		`DELETE FROM "orders"`,
+		`DELETE FROM "customers"`,
+		reset,
+		`INSERT INTO "customers" ("name") VALUES ($1) RETURNING "id"`,
+		`INSERT INTO "customers" ("name") VALUES ($1) RETURNING "id"`,
+		`INSERT INTO "orders" ("code", "created_at", "customer_id", "details", "id") VALUES ($1, $2, $3, $4, $5)`,
+		`INSERT INTO "orders" ("code", "created_at", "customer_id", "id") VALUES ($1, $2, $3, $4)`,
+		reset,
+	}
+	if !reflect.DeepEqual(command.queries[8:], expectedQueries) {
+		t.Fatalf("Expected:\n%s\ngot:\n%s", strings.Join(expectedQueries, "\n"), strings.Join(command.queries[8:], "\n"))
+	}
+
+	if !reflect.DeepEqual(command.args[11], []interface{}{"Ann"}) || !reflect.DeepEqual(command.args[12], []interface{}{"Bob"}) {
+		t.Errorf("Expected the customers in the order of their labels, got: %v %v", command.args[11], command.args[12])
+	}
+
+	expectedFirst := []interface{}{"A-1", now.Add(-time.Hour), int64(8), `{"rush":true}`, int64(1)}
+	if !reflect.DeepEqual(command.args[13], expectedFirst) {
+		t.Errorf("Expected %#v, got: %#v", expectedFirst, command.args[13])
+	}
+
+	expectedSecond := []interface{}{"Ann at 2024-05-01T12:00:00Z", now, int64(7), int64(10)}
+	if !reflect.DeepEqual(command.args[14], expectedSecond) {
+		t.Errorf("Expected %#v, got: %#v", expectedSecond, command.args[14])
+	}
+
+	if !reflect.DeepEqual(command.events, []string{"begin", "commit"}) {
+		t.Errorf("Expected the fixtures to be loaded in a transaction, got: %v", command.events)
+	}
+}
+
+func TestFixtureLoader_Decoder(t *testing.T) {
+	// Arrange
+	fsys := fstest.MapFS{
+		"customers.yml": {Data: []byte("ignored")},
+	}
+
+	decoder := func(data []byte, v interface{}) error {
+		*(v.(*map[string]interface{})) = map[string]interface{}{
+			"customers": []interface{}{
+				map[interface{}]interface{}{"_label": "ann", "id": 5, "name": "Ann"},
+			},
+		}
+
+		return nil
+	}
+
+	command := &recordingCommand{
+		dialect: DialectSQLServer,
+		tables: createTestInspection(testInspectedTable{
+			columns: [][]interface{}{
+				{"id", int64(1), "bigint", "NO", int64(0), int64(64), int64(0), nil, int64(1)},
+				{"name", int64(2), "nvarchar", "NO", int64(100), int64(0), int64(0), nil, int64(0)},
+			},
+			primaryKey: []string{"id"},
+		}),
+	}
+
+	loader, err := CreateNewFixtureLoader(command, fsys, WithFixtureDecoder(".YML", decoder))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Act
+	err = loader.Load()
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	reset := "IF (SELECT last_value FROM sys.identity_columns WHERE object_id = OBJECT_ID(N'[customers]') AND name = N'id') IS NOT NULL " +
+		"BEGIN DECLARE @seed BIGINT = (SELECT COALESCE(MAX([id]), 0) FROM [customers]); DBCC CHECKIDENT (N'[customers]', RESEED, @seed) END"
+	expectedQueries := []string{
+		"DELETE FROM [customers]",
+		reset,
+		"SET IDENTITY_INSERT [customers] ON",
+		"INSERT INTO [customers] ([id], [name]) VALUES (@p1, @p2)",
+		"SET IDENTITY_INSERT [customers] OFF",
+		reset,
+	}
+	if !reflect.DeepEqual(command.queries[4:], expectedQueries) {
+		t.Fatalf("Expected:\n%s\ngot:\n%s", strings.Join(expectedQueries, "\n"), strings.Join(command.queries[4:], "\n"))
+	}
+
+	if !reflect.DeepEqual(command.args[7], []interface{}{5, "Ann"}) {
+		t.Errorf("Expected the decoded values, got: %#v", command.args[7])
+	}
+}
+
+func TestFixtureLoader_MySQL_Resets_After_Commit(t *testing.T) {
+	// Arrange
+	fsys := fstest.MapFS{"customers.json": {Data: []byte(`{"customers": [{"name": "Ann"}]}`)}}
+
+	command, mock := createTestMockCommand(t, DialectMySQL)
+	mock.ExpectBegin()
+	mock.ExpectQueryRegexp("column_name").WillReturnRows(db_mock.CreateNewRows(testInspectedColumnNames...).
+		AddRow("id", int64(1), "bigint", "NO", int64(0), int64(19), int64(0), nil, int64(1)).
+		AddRow("name", int64(2), "varchar", "NO", int64(100), int64(0), int64(0), nil, int64(0)))
+	mock.ExpectQueryRegexp(".").WillReturnRows(db_mock.CreateNewRows("column_name").AddRow("id"))
+	mock.ExpectQueryRegexp(".").WillReturnRows(db_mock.CreateNewRows("index_name", "column_name", "unique"))
+	mock.ExpectQueryRegexp(".").WillReturnRows(db_mock.CreateNewRows("constraint_name", "column_name", "referenced_table", "referenced_column", "delete_rule", "update_rule"))
+	mock.ExpectExec("DELETE FROM `customers`").WillReturnResult(0, 1)
+	mock.ExpectExec("INSERT INTO `customers` (`name`) VALUES (?)").WithArgs("Ann").WillReturnResult(1, 1)
+	mock.ExpectCommit()
+	mock.ExpectExec("ALTER TABLE `customers` AUTO_INCREMENT = 1").WillReturnResult(0, 0)
+
+	loader, err := CreateNewFixtureLoader(command, fsys)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Act
+	err = loader.Load()
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	assertExpectationsWereMet(t, mock)
+}
+
+func TestFixtureLoader_Errors(t *testing.T) {
+	// Test cases
+	testCases := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{name: "Invalid file", content: `{"customers": "Ann"}`, expected: db_errors.Fixture_InvalidFileErrorMessage},
+		{name: "Duplicate label", content: `{"customers": [{"_label": "a"}, {"_label": "a"}]}`, expected: db_errors.Fixture_DuplicateLabelErrorMessage},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			fsys := fstest.MapFS{"fixtures.json": {Data: []byte(tc.content)}}
+
+			// Act
+			_, err := CreateNewFixtureLoader(&recordingCommand{dialect: DialectSQLite}, fsys)
+
+			// Assert
+			assertError(t, errors.Unwrap(err), tc.expected)
+		})
+	}
+}
+
+func TestFixtureLoader_LoadErrors(t *testing.T) {
+	// Test cases
+	testCases := []struct {
+		name     string
+		value    string
+		expected string
+	}{
+		{name: "Unknown template", value: "{{uuid}}", expected: db_errors.Fixture_UnknownTemplateErrorMessage},
+		{name: "Invalid duration", value: "{{now+1 day}}", expected: db_errors.Fixture_UnknownTemplateErrorMessage},
+		{name: "Missing reference", value: "{{ref:customers.ann}}", expected: db_errors.Fixture_ReferenceNotFoundErrorMessage},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			fsys := fstest.MapFS{"fixtures.json": {Data: []byte(`{"notes": [{"text": "` + tc.value + `"}]}`)}}
+			command := &recordingCommand{
+				dialect: DialectPostgres,
+				tables: createTestInspection(testInspectedTable{
+					columns: [][]interface{}{{"text", int64(1), "text", "NO", int64(0), int64(0), int64(0), nil, int64(0)}},
+				}),
+			}
+
+			loader, err := CreateNewFixtureLoader(command, fsys)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			// Act
+			err = loader.Load()
+
+			// Assert
+			assertError(t, err, tc.expected)
+
+			if !reflect.DeepEqual(command.events, []string{"begin", "rollback"}) {
+				t.Errorf("Expected the load to be rolled back, got: %v", command.events)
+			}
+		})
+	}
+}
+
+func TestCreateNewFixtureLoader_NilCommand(t *testing.T) {
+	// Act
+	_, err := CreateNewFixtureLoader(nil, fstest.MapFS{})
+
+	// Assert
+	assertError(t, err, db_errors.Fixture_NilCommandErrorMessage)
+}
@@ -1,5 +1,11 @@
 package db
 
+import (
+	"context"
+
+	db_criteria "github.com/fatihtatoglu/db-go/criteria"
+)
+
 // ExistenceChecker is an interface used to check the existence of an entity.
 type ExistenceChecker[T any] interface {
 	// CheckExistence checks the existence of a record by the id column.
@@ -15,13 +21,22 @@ type DataWriter[T any, K any] interface {
 
 	// Update updates the given entity by the id value.
 	// For composite primary keys, a struct can be used as a parameter.
+	// Entities with a version field return a ConcurrencyConflictError when the record has been changed since it was read.
 	Update(id K, entity T) (bool, error)
 
 	// Delete deletes the database entity record by the id value.
 	// For composite primary keys, a struct can be used as a parameter.
+	// Entities with a softdelete field are marked as deleted instead.
 	Delete(id K) (bool, error)
 }
 
+// DataUpserter is an interface used to insert or update data in a single statement.
+type DataUpserter[T any] interface {
+	// Upsert inserts the entity or updates it when a record with the same primary key exists.
+	// The single statement runs no write hooks.
+	Upsert(entity T) (bool, error)
+}
+
 // DataReader is an interface used to read data from the database.
 type DataReader[T any, K any] interface {
 	// GetById gets the entity record by the id value.
@@ -32,16 +47,60 @@ type DataReader[T any, K any] interface {
 	Retrieve() ([]T, error)
 }
 
-// DataFetcher is an interface used to fetch entity records by given limit and offset values.
+// DataFetcher is an interface used to fetch entity records page by page.
 type DataFetcher[T any] interface {
 	// Fetch fetches the entity records by the given limit and offset.
 	Fetch(limit, offset int) ([]T, error)
+
+	// FetchAfter fetches the entity records after the cursor in the given sort order.
+	// An empty cursor fetches the first page; the next cursor is returned with the page.
+	// The primary key columns are appended to the sort order to keep it stable.
+	// The sort columns should not be nullable, rows with null sort values cannot be paged past.
+	FetchAfter(cursor string, limit int, sort ...db_criteria.Sort) (*KeysetPage[T], error)
+
+	// FetchPage fetches the 1-based page of the given size with the total count of the records.
+	FetchPage(page, size int, sort ...db_criteria.Sort) (*Page[T], error)
+}
+
+// DataFinder is an interface used to query entity records by criteria.
+type DataFinder[T any] interface {
+	// Find finds the entity records matching the criteria in the given sort order.
+	// Nil criteria match all the records.
+	Find(criteria db_criteria.Criteria, sort ...db_criteria.Sort) ([]T, error)
+
+	// Count counts the entity records matching the criteria.
+	Count(criteria db_criteria.Criteria) (int64, error)
+
+	// Exists checks whether any entity record matches the criteria.
+	Exists(criteria db_criteria.Criteria) (bool, error)
+}
+
+// SoftDeleter is an interface used to manage the soft deleted records of an entity with a softdelete field.
+type SoftDeleter[T any, K any] interface {
+	// WithDeleted returns a repository whose reads include the soft deleted records.
+	WithDeleted() Repository[T, K]
+
+	// Restore restores the soft deleted record by the id value.
+	Restore(id K) (bool, error)
+
+	// HardDelete deletes the record by the id value physically, regardless of soft delete.
+	HardDelete(id K) (bool, error)
 }
 
 // Repository is an interface used for general database operations.
 type Repository[T any, K any] interface {
 	DataReader[T, K]
 	DataFetcher[T]
+	DataFinder[T]
 	ExistenceChecker[K]
 	DataWriter[T, K]
+	DataUpserter[T]
+	SoftDeleter[T, K]
+
+	// Include returns a repository which loads the given relations of the read entities.
+	// The relations are the names of the fields tagged with rel.
+	Include(relations ...string) Repository[T, K]
+
+	// WithContext returns a repository which runs the statements with the context.
+	WithContext(ctx context.Context) Repository[T, K]
 }
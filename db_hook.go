@@ -0,0 +1,233 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"reflect"
+
+	db_errors "github.com/fatihtatoglu/db-go/error"
+)
+
+// The hooks are optional interfaces of the entity type, implemented with a value or a pointer receiver.
+// The write hooks run in the transaction of the operation, which is begun by the repository unless the
+// repository already uses a transaction; an error returned by a hook rolls the operation back.
+// Upsert runs no write hooks unless it falls back to Insert or Update.
+
+// BeforeInsertHook is called before the entity is inserted, e.g. to validate or normalize it.
+type BeforeInsertHook interface {
+	BeforeInsert(ctx context.Context, tx DBCommandInterface) error
+}
+
+// AfterInsertHook is called after the entity is inserted.
+type AfterInsertHook interface {
+	AfterInsert(ctx context.Context, tx DBCommandInterface) error
+}
+
+// BeforeUpdateHook is called before the entity is updated.
+type BeforeUpdateHook interface {
+	BeforeUpdate(ctx context.Context, tx DBCommandInterface) error
+}
+
+// AfterUpdateHook is called after the entity is updated.
+type AfterUpdateHook interface {
+	AfterUpdate(ctx context.Context, tx DBCommandInterface) error
+}
+
+// BeforeDeleteHook is called with the stored entity before it is deleted.
+type BeforeDeleteHook interface {
+	BeforeDelete(ctx context.Context, tx DBCommandInterface) error
+}
+
+// AfterLoadHook is called after the entity is read from the database with the command of the read.
+type AfterLoadHook interface {
+	AfterLoad(ctx context.Context, command DBCommandInterface) error
+}
+
+type entityHooks struct {
+	beforeInsert bool
+	afterInsert  bool
+	beforeUpdate bool
+	afterUpdate  bool
+	beforeDelete bool
+	afterLoad    bool
+}
+
+var (
+	beforeInsertHookType = reflect.TypeOf((*BeforeInsertHook)(nil)).Elem()
+	afterInsertHookType  = reflect.TypeOf((*AfterInsertHook)(nil)).Elem()
+	beforeUpdateHookType = reflect.TypeOf((*BeforeUpdateHook)(nil)).Elem()
+	afterUpdateHookType  = reflect.TypeOf((*AfterUpdateHook)(nil)).Elem()
+	beforeDeleteHookType = reflect.TypeOf((*BeforeDeleteHook)(nil)).Elem()
+	afterLoadHookType    = reflect.TypeOf((*AfterLoadHook)(nil)).Elem()
+)
+
+// detectHooks checks the hooks on the pointer type, whose method set contains the value receiver methods too.
+func detectHooks(t reflect.Type) entityHooks {
+	pt := reflect.PointerTo(t)
+
+	return entityHooks{
+		beforeInsert: pt.Implements(beforeInsertHookType),
+		afterInsert:  pt.Implements(afterInsertHookType),
+		beforeUpdate: pt.Implements(beforeUpdateHookType),
+		afterUpdate:  pt.Implements(afterUpdateHookType),
+		beforeDelete: pt.Implements(beforeDeleteHookType),
+		afterLoad:    pt.Implements(afterLoadHookType),
+	}
+}
+
+// inTransaction runs fn with a repository bound to a transaction. A repository which already uses
+// a transaction runs fn itself, otherwise a transaction is begun and committed when fn succeeds.
+func (r *dbRepository[T, K]) inTransaction(fn func(tx *dbRepository[T, K]) error) error {
+	if _, ok := r.command.(DBTransactionInterface); ok {
+		return fn(r)
+	}
+
+	tx, err := r.command.Begin()
+	if err != nil {
+		return err
+	}
+
+	clone := *r
+	clone.command = tx
+
+	err = fn(&clone)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// hookTarget returns the addressable struct value of the entity; entities passed by value are copied,
+// so the changes of the hooks are written to the database but not to the caller's value.
+func (r *dbRepository[T, K]) hookTarget(entity T) (reflect.Value, error) {
+	sv, err := r.metadata.structValue(entity)
+	if err != nil || sv.CanAddr() {
+		return sv, err
+	}
+
+	target := reflect.New(sv.Type()).Elem()
+	target.Set(sv)
+	return target, nil
+}
+
+// entityOf returns the entity of the hook target as T.
+func (r *dbRepository[T, K]) entityOf(target reflect.Value) T {
+	var entity T
+	if reflect.TypeOf(&entity).Elem().Kind() == reflect.Pointer {
+		return target.Addr().Interface().(T)
+	}
+
+	return target.Interface().(T)
+}
+
+func (r *dbRepository[T, K]) insertWithHooks(entity T) (K, error) {
+	var id K
+
+	err := r.inTransaction(func(tx *dbRepository[T, K]) error {
+		target, err := tx.hookTarget(entity)
+		if err != nil {
+			return err
+		}
+
+		hook := target.Addr().Interface()
+		if h, ok := hook.(BeforeInsertHook); ok {
+			err = h.BeforeInsert(tx.command.GetContext(), tx.command)
+			if err != nil {
+				return err
+			}
+		}
+
+		id, err = tx.insert(tx.entityOf(target))
+		if err != nil {
+			return err
+		}
+
+		if h, ok := hook.(AfterInsertHook); ok {
+			return h.AfterInsert(tx.command.GetContext(), tx.command)
+		}
+
+		return nil
+	})
+
+	return id, err
+}
+
+func (r *dbRepository[T, K]) updateWithHooks(id K, entity T) (bool, error) {
+	var updated bool
+
+	err := r.inTransaction(func(tx *dbRepository[T, K]) error {
+		target, err := tx.hookTarget(entity)
+		if err != nil {
+			return err
+		}
+
+		hook := target.Addr().Interface()
+		if h, ok := hook.(BeforeUpdateHook); ok {
+			err = h.BeforeUpdate(tx.command.GetContext(), tx.command)
+			if err != nil {
+				return err
+			}
+		}
+
+		updated, err = tx.update(id, tx.entityOf(target))
+		if err != nil || !updated {
+			return err
+		}
+
+		if h, ok := hook.(AfterUpdateHook); ok {
+			return h.AfterUpdate(tx.command.GetContext(), tx.command)
+		}
+
+		return nil
+	})
+
+	return updated, err
+}
+
+// deleteWithHooks loads the stored entity for the BeforeDelete hook and deletes it with the delete function.
+// The soft deleted entities are loaded when includeDeleted is set.
+func (r *dbRepository[T, K]) deleteWithHooks(id K, includeDeleted bool, delete func(tx *dbRepository[T, K], id K) (bool, error)) (bool, error) {
+	var deleted bool
+
+	err := r.inTransaction(func(tx *dbRepository[T, K]) error {
+		lookup := *tx
+		lookup.withDeleted = lookup.withDeleted || includeDeleted
+
+		entity, err := lookup.GetById(id)
+		if err != nil {
+			if errors.Is(err, db_errors.ErrRepositoryNotFound) {
+				return nil
+			}
+
+			return err
+		}
+
+		target, err := tx.hookTarget(entity)
+		if err != nil {
+			return err
+		}
+
+		if h, ok := target.Addr().Interface().(BeforeDeleteHook); ok {
+			err = h.BeforeDelete(tx.command.GetContext(), tx.command)
+			if err != nil {
+				return err
+			}
+		}
+
+		deleted, err = delete(tx, id)
+		return err
+	})
+
+	return deleted, err
+}
+
+// afterLoad calls the AfterLoad hook of the mapped entity.
+func (r *dbRepository[T, K]) afterLoad(target reflect.Value) error {
+	if h, ok := target.Addr().Interface().(AfterLoadHook); ok {
+		return h.AfterLoad(r.command.GetContext(), r.command)
+	}
+
+	return nil
+}
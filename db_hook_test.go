@@ -0,0 +1,157 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type testHookedCustomer struct {
+	ID   int64  `db:"id,pk"`
+	Name string `db:"name"`
+	Tags string `db:"tags"`
+}
+
+func (c *testHookedCustomer) BeforeInsert(ctx context.Context, tx DBCommandInterface) error {
+	c.Name = strings.TrimSpace(c.Name)
+	if c.Name == "" {
+		return errors.New("name is required")
+	}
+
+	tx.(*recordingTransaction).events = append(tx.(*recordingTransaction).events, "before insert")
+	return nil
+}
+
+func (c *testHookedCustomer) AfterInsert(ctx context.Context, tx DBCommandInterface) error {
+	tx.(*recordingTransaction).events = append(tx.(*recordingTransaction).events, "after insert")
+	return nil
+}
+
+func (c *testHookedCustomer) BeforeDelete(ctx context.Context, tx DBCommandInterface) error {
+	if strings.EqualFold(c.Tags, "locked") {
+		return errors.New("customer is locked")
+	}
+
+	return nil
+}
+
+func (c *testHookedCustomer) AfterLoad(ctx context.Context, command DBCommandInterface) error {
+	c.Tags = strings.ToUpper(c.Tags)
+	return nil
+}
+
+func TestRepository_Insert_Hooks(t *testing.T) {
+	// Arrange
+	command := &recordingCommand{dialect: DialectMySQL}
+	sut, _ := CreateNewRepository[testHookedCustomer, int64](command, "customers")
+
+	// Act
+	_, err := sut.Insert(testHookedCustomer{ID: 1, Name: "  Fatih "})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expectedEvents := []string{"begin", "before insert", "after insert", "commit"}
+	if !reflect.DeepEqual(command.events, expectedEvents) {
+		t.Errorf("Expected events: %v, got: %v", expectedEvents, command.events)
+	}
+
+	if command.args[0][1] != "Fatih" {
+		t.Errorf("Expected the normalized name, got: %v", command.args[0][1])
+	}
+}
+
+func TestRepository_Insert_BeforeHookError(t *testing.T) {
+	// Arrange
+	command := &recordingCommand{dialect: DialectMySQL}
+	sut, _ := CreateNewRepository[*testHookedCustomer, int64](command, "customers")
+
+	// Act
+	_, err := sut.Insert(&testHookedCustomer{ID: 1, Name: " "})
+
+	// Assert
+	assertError(t, err, "name is required")
+
+	if len(command.queries) != 0 || !reflect.DeepEqual(command.events, []string{"begin", "rollback"}) {
+		t.Errorf("Expected a rollback without statements, got: %v, %v", command.events, command.queries)
+	}
+}
+
+func TestRepository_Delete_BeforeHookError(t *testing.T) {
+	// Arrange
+	command := &recordingCommand{
+		dialect: DialectMySQL,
+		table:   createTestTable([]string{"id", "name", "tags"}, []interface{}{int64(1), "Fatih", "locked"}),
+	}
+	sut, _ := CreateNewRepository[testHookedCustomer, int64](command, "customers")
+
+	// Act
+	deleted, err := sut.Delete(1)
+
+	// Assert
+	assertError(t, err, "customer is locked")
+
+	if deleted || len(command.queries) != 1 {
+		t.Errorf("Expected only the lookup query, got: %v", command.queries)
+	}
+}
+
+func TestRepository_AfterLoad(t *testing.T) {
+	// Arrange
+	command := &recordingCommand{
+		dialect: DialectMySQL,
+		table:   createTestTable([]string{"id", "name", "tags"}, []interface{}{int64(1), "Fatih", "vip"}),
+	}
+	sut, _ := CreateNewRepository[testHookedCustomer, int64](command, "customers")
+
+	// Act
+	customer, err := sut.GetById(1)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if customer.Tags != "VIP" {
+		t.Errorf("Expected the AfterLoad hook to run, got: %s", customer.Tags)
+	}
+}
+
+type testUpsertHookedCustomer struct {
+	ID   int64  `db:"id,pk"`
+	Name string `db:"name"`
+}
+
+func (c *testUpsertHookedCustomer) BeforeInsert(ctx context.Context, tx DBCommandInterface) error {
+	return errors.New("before insert")
+}
+
+func (c *testUpsertHookedCustomer) BeforeUpdate(ctx context.Context, tx DBCommandInterface) error {
+	return errors.New("before update")
+}
+
+func TestRepository_Upsert_Skips_Hooks(t *testing.T) {
+	// Arrange
+	command, mock := createTestMockCommand(t, DialectPostgres)
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO "customers" ("id", "name") VALUES ($1, $2) ON CONFLICT ("id") DO UPDATE SET "name" = EXCLUDED."name"`).
+		WithArgs(int64(1), "Fatih").
+		WillReturnResult(0, 1)
+	mock.ExpectCommit()
+
+	sut, _ := CreateNewRepository[*testUpsertHookedCustomer, int64](command, "customers")
+
+	// Act
+	ok, err := sut.Upsert(&testUpsertHookedCustomer{ID: 1, Name: "Fatih"})
+
+	// Assert
+	if err != nil || !ok {
+		t.Fatalf("Expected the upsert without hooks, got: %v, %v", ok, err)
+	}
+
+	assertExpectationsWereMet(t, mock)
+}
@@ -0,0 +1,485 @@
+package db
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	db_errors "github.com/fatihtatoglu/db-go/error"
+)
+
+// Inspector reads the structure of the current schema of the database: the tables with their columns,
+// primary keys, indexes and foreign keys, and the views.
+//
+// MySQL, PostgreSQL and SQL Server are read from the information_schema views and the catalog views
+// of the current schema; SQLite is read with the pragma table functions.
+type Inspector struct {
+	command DBCommandInterface
+}
+
+func CreateNewInspector(command DBCommandInterface) (*Inspector, error) {
+	if command == nil {
+		return nil, db_errors.InspectorNilCommandError()
+	}
+
+	switch command.GetDialect() {
+	case DialectMySQL, DialectPostgres, DialectSQLite, DialectSQLServer:
+		return &Inspector{command: command}, nil
+	default:
+		return nil, db_errors.InspectorUnsupportedDialectError()
+	}
+}
+
+// GetSchema reads all the tables and the views of the schema.
+func (i *Inspector) GetSchema() (*DBSchema, error) {
+	names, err := i.GetTableNames()
+	if err != nil {
+		return nil, err
+	}
+
+	schema := &DBSchema{tables: make([]DBTableSchema, 0, len(names))}
+	for _, name := range names {
+		table, err := i.GetTable(name)
+		if err != nil {
+			return nil, err
+		}
+
+		schema.tables = append(schema.tables, *table)
+	}
+
+	schema.views, err = i.GetViews()
+	if err != nil {
+		return nil, err
+	}
+
+	return schema, nil
+}
+
+// GetTableNames returns the names of the base tables in the name order.
+func (i *Inspector) GetTableNames() ([]string, error) {
+	var query string
+	switch i.dialect() {
+	case DialectSQLite:
+		query = "SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' ORDER BY name"
+	default:
+		query = "SELECT table_name FROM information_schema.tables WHERE table_schema = " + i.currentSchema() +
+			" AND table_type = 'BASE TABLE' ORDER BY table_name"
+	}
+
+	rows, err := i.query(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return firstColumn(rows), nil
+}
+
+// GetTable reads the columns, the primary key, the indexes and the foreign keys of the table.
+func (i *Inspector) GetTable(name string) (*DBTableSchema, error) {
+	table := &DBTableSchema{name: name}
+
+	var err error
+	if i.dialect() == DialectSQLite {
+		table.columns, table.primaryKey, err = i.sqliteColumns(name)
+	} else {
+		table.columns, err = i.columns(name)
+		if err == nil {
+			table.primaryKey, err = i.primaryKey(name)
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(table.columns) == 0 {
+		return nil, db_errors.InspectorTableNotFoundError()
+	}
+
+	table.indexes, err = i.indexes(name)
+	if err != nil {
+		return nil, err
+	}
+
+	table.foreignKeys, err = i.foreignKeys(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return table, nil
+}
+
+// GetViews returns the views with their definitions in the name order.
+func (i *Inspector) GetViews() ([]DBView, error) {
+	var query string
+	switch i.dialect() {
+	case DialectSQLite:
+		query = "SELECT name, sql FROM sqlite_master WHERE type = 'view' ORDER BY name"
+	case DialectSQLServer:
+		// The information_schema view truncates the definitions longer than 4000 characters.
+		query = "SELECT name, OBJECT_DEFINITION(object_id) FROM sys.views WHERE schema_id = SCHEMA_ID() ORDER BY name"
+	default:
+		query = "SELECT table_name, view_definition FROM information_schema.views WHERE table_schema = " +
+			i.currentSchema() + " ORDER BY table_name"
+	}
+
+	rows, err := i.query(query)
+	if err != nil {
+		return nil, err
+	}
+
+	views := make([]DBView, 0, len(rows))
+	for _, values := range rows {
+		views = append(views, DBView{
+			name:       schemaString(values[0]),
+			definition: schemaString(values[1]),
+		})
+	}
+
+	return views, nil
+}
+
+func (i *Inspector) dialect() Dialect {
+	return i.command.GetDialect()
+}
+
+func (i *Inspector) currentSchema() string {
+	switch i.dialect() {
+	case DialectMySQL:
+		return "DATABASE()"
+	case DialectSQLServer:
+		return "SCHEMA_NAME()"
+	default:
+		return "current_schema()"
+	}
+}
+
+// query runs the query with the ? placeholders rebound for the dialect and returns the row values.
+func (i *Inspector) query(query string, params ...interface{}) ([][]interface{}, error) {
+	dt, err := i.command.Query(i.dialect().Rebind(query), params...)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([][]interface{}, 0, len(dt.GetRows()))
+	for _, row := range dt.GetRows() {
+		values := make([]interface{}, len(dt.GetColumns()))
+		for index := range values {
+			values[index], _ = row.GetItemByIndex(index)
+		}
+
+		rows = append(rows, values)
+	}
+
+	return rows, nil
+}
+
+func (i *Inspector) columns(table string) ([]DBColumn, error) {
+	// The data type of MySQL leaves out the unsigned attribute, which is only part of the column type.
+	dataType := "data_type"
+	var autoIncrement string
+	switch i.dialect() {
+	case DialectMySQL:
+		dataType = "CASE WHEN column_type LIKE '%unsigned%' THEN CONCAT(data_type, ' unsigned') ELSE data_type END"
+		autoIncrement = "CASE WHEN extra LIKE '%auto_increment%' THEN 1 ELSE 0 END"
+	case DialectPostgres:
+		autoIncrement = "CASE WHEN column_default LIKE 'nextval(%' OR is_identity = 'YES' THEN 1 ELSE 0 END"
+	case DialectSQLServer:
+		autoIncrement = "COLUMNPROPERTY(OBJECT_ID(QUOTENAME(table_schema) + '.' + QUOTENAME(table_name)), column_name, 'IsIdentity')"
+	}
+
+	rows, err := i.query(`SELECT column_name, ordinal_position, `+dataType+`, is_nullable, COALESCE(character_maximum_length, 0),
+	COALESCE(numeric_precision, 0), COALESCE(numeric_scale, 0), column_default, `+autoIncrement+`
+FROM information_schema.columns
+WHERE table_schema = `+i.currentSchema()+` AND table_name = ?
+ORDER BY ordinal_position`, table)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make([]DBColumn, 0, len(rows))
+	for _, values := range rows {
+		options := []DBColumnOption{WithColumnPrecision(schemaInt64(values[5]), schemaInt64(values[6]))}
+		if values[7] != nil {
+			options = append(options, WithColumnDefault(schemaString(values[7])))
+		}
+
+		if schemaInt64(values[8]) == 1 {
+			options = append(options, WithColumnAutoIncrement())
+		}
+
+		columns = append(columns, CreateNewDBColumnFromSchema(schemaString(values[0]), int(schemaInt64(values[1])),
+			schemaString(values[2]), schemaInt64(values[4]), schemaString(values[3]) == "YES", options...))
+	}
+
+	return columns, nil
+}
+
+func (i *Inspector) primaryKey(table string) ([]string, error) {
+	rows, err := i.query(`SELECT k.column_name
+FROM information_schema.table_constraints tc
+JOIN information_schema.key_column_usage k ON k.constraint_schema = tc.constraint_schema
+	AND k.constraint_name = tc.constraint_name AND k.table_name = tc.table_name
+WHERE tc.constraint_type = 'PRIMARY KEY' AND tc.table_schema = `+i.currentSchema()+` AND tc.table_name = ?
+ORDER BY k.ordinal_position`, table)
+	if err != nil {
+		return nil, err
+	}
+
+	return firstColumn(rows), nil
+}
+
+// sqliteColumns reads the columns and the primary key of a SQLite table. The length, the precision and
+// the scale are parsed from the declared type, and the single INTEGER primary key, which is the alias
+// of the rowid, is the auto increment column.
+func (i *Inspector) sqliteColumns(table string) ([]DBColumn, []string, error) {
+	rows, err := i.query(`SELECT cid, name, type, "notnull", dflt_value, pk FROM pragma_table_info(?) ORDER BY cid`, table)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keys := make(map[int64]string)
+	for _, values := range rows {
+		if position := schemaInt64(values[5]); position > 0 {
+			keys[position] = schemaString(values[1])
+		}
+	}
+
+	primaryKey := make([]string, 0, len(keys))
+	for position := int64(1); position <= int64(len(keys)); position++ {
+		primaryKey = append(primaryKey, keys[position])
+	}
+
+	columns := make([]DBColumn, 0, len(rows))
+	for _, values := range rows {
+		dbType, arguments := parseDeclaredType(schemaString(values[2]))
+
+		var length int64
+		var options []DBColumnOption
+		switch {
+		case len(arguments) == 1 && strings.Contains(strings.ToUpper(dbType), "CHAR"):
+			length = arguments[0]
+		case len(arguments) == 1:
+			options = append(options, WithColumnPrecision(arguments[0], 0))
+		case len(arguments) == 2:
+			options = append(options, WithColumnPrecision(arguments[0], arguments[1]))
+		}
+
+		if values[4] != nil {
+			options = append(options, WithColumnDefault(schemaString(values[4])))
+		}
+
+		name := schemaString(values[1])
+		if len(primaryKey) == 1 && primaryKey[0] == name && strings.EqualFold(dbType, "INTEGER") {
+			options = append(options, WithColumnAutoIncrement())
+		}
+
+		columns = append(columns, CreateNewDBColumnFromSchema(name, int(schemaInt64(values[0]))+1, dbType, length,
+			schemaInt64(values[3]) == 0 && schemaInt64(values[5]) == 0, options...))
+	}
+
+	return columns, primaryKey, nil
+}
+
+func (i *Inspector) indexes(table string) ([]DBIndex, error) {
+	var query string
+	switch i.dialect() {
+	case DialectMySQL:
+		query = `SELECT index_name, column_name, CASE WHEN non_unique = 0 THEN 1 ELSE 0 END
+FROM information_schema.statistics
+WHERE table_schema = DATABASE() AND table_name = ? AND index_name <> 'PRIMARY'
+ORDER BY index_name, seq_in_index`
+	case DialectPostgres:
+		query = `SELECT i.relname, a.attname, ix.indisunique
+FROM pg_index ix
+JOIN pg_class t ON t.oid = ix.indrelid
+JOIN pg_class i ON i.oid = ix.indexrelid
+JOIN pg_namespace n ON n.oid = t.relnamespace
+JOIN LATERAL unnest(ix.indkey) WITH ORDINALITY AS k(attnum, position) ON true
+JOIN pg_attribute a ON a.attrelid = t.oid AND a.attnum = k.attnum
+WHERE n.nspname = current_schema() AND t.relname = ? AND NOT ix.indisprimary
+ORDER BY i.relname, k.position`
+	case DialectSQLServer:
+		query = `SELECT i.name, c.name, i.is_unique
+FROM sys.indexes i
+JOIN sys.index_columns ic ON ic.object_id = i.object_id AND ic.index_id = i.index_id
+JOIN sys.columns c ON c.object_id = ic.object_id AND c.column_id = ic.column_id
+WHERE i.object_id = OBJECT_ID(QUOTENAME(SCHEMA_NAME()) + '.' + QUOTENAME(?))
+	AND i.is_primary_key = 0 AND i.name IS NOT NULL AND ic.is_included_column = 0
+ORDER BY i.name, ic.key_ordinal`
+	case DialectSQLite:
+		query = `SELECT il.name, ii.name, il."unique"
+FROM pragma_index_list(?) il
+JOIN pragma_index_info(il.name) ii
+WHERE il.origin <> 'pk'
+ORDER BY il.name, ii.seqno`
+	}
+
+	rows, err := i.query(query, table)
+	if err != nil {
+		return nil, err
+	}
+
+	indexes := make([]DBIndex, 0)
+	for _, values := range rows {
+		name := schemaString(values[0])
+		if len(indexes) == 0 || indexes[len(indexes)-1].name != name {
+			indexes = append(indexes, DBIndex{
+				name:   name,
+				unique: schemaInt64(values[2]) == 1,
+			})
+		}
+
+		index := &indexes[len(indexes)-1]
+		index.columns = append(index.columns, schemaString(values[1]))
+	}
+
+	return indexes, nil
+}
+
+func (i *Inspector) foreignKeys(table string) ([]DBForeignKey, error) {
+	var query string
+	switch i.dialect() {
+	case DialectMySQL:
+		query = `SELECT k.constraint_name, k.column_name, k.referenced_table_name, k.referenced_column_name, r.delete_rule, r.update_rule
+FROM information_schema.key_column_usage k
+JOIN information_schema.referential_constraints r ON r.constraint_schema = k.constraint_schema AND r.constraint_name = k.constraint_name
+WHERE k.table_schema = DATABASE() AND k.table_name = ? AND k.referenced_table_name IS NOT NULL
+ORDER BY k.constraint_name, k.ordinal_position`
+	case DialectPostgres:
+		query = `SELECT k.constraint_name, k.column_name, u.table_name, u.column_name, r.delete_rule, r.update_rule
+FROM information_schema.referential_constraints r
+JOIN information_schema.key_column_usage k ON k.constraint_schema = r.constraint_schema AND k.constraint_name = r.constraint_name
+JOIN information_schema.key_column_usage u ON u.constraint_schema = r.unique_constraint_schema
+	AND u.constraint_name = r.unique_constraint_name AND u.ordinal_position = k.position_in_unique_constraint
+WHERE k.table_schema = current_schema() AND k.table_name = ?
+ORDER BY k.constraint_name, k.ordinal_position`
+	case DialectSQLServer:
+		query = `SELECT fk.name, pc.name, rt.name, rc.name, fk.delete_referential_action_desc, fk.update_referential_action_desc
+FROM sys.foreign_keys fk
+JOIN sys.foreign_key_columns fkc ON fkc.constraint_object_id = fk.object_id
+JOIN sys.columns pc ON pc.object_id = fkc.parent_object_id AND pc.column_id = fkc.parent_column_id
+JOIN sys.tables rt ON rt.object_id = fkc.referenced_object_id
+JOIN sys.columns rc ON rc.object_id = fkc.referenced_object_id AND rc.column_id = fkc.referenced_column_id
+WHERE fk.parent_object_id = OBJECT_ID(QUOTENAME(SCHEMA_NAME()) + '.' + QUOTENAME(?))
+ORDER BY fk.name, fkc.constraint_column_id`
+	case DialectSQLite:
+		// The foreign keys are not named in SQLite; the rows are grouped by the id of the foreign key.
+		query = `SELECT id, "from", "table", "to", on_delete, on_update FROM pragma_foreign_key_list(?) ORDER BY id, seq`
+	}
+
+	rows, err := i.query(query, table)
+	if err != nil {
+		return nil, err
+	}
+
+	foreignKeys := make([]DBForeignKey, 0)
+	groups := make([]string, 0)
+	for _, values := range rows {
+		group := schemaString(values[0])
+		if len(groups) == 0 || groups[len(groups)-1] != group {
+			groups = append(groups, group)
+
+			foreignKey := DBForeignKey{
+				referencedTable: schemaString(values[2]),
+				onDelete:        strings.ReplaceAll(schemaString(values[4]), "_", " "),
+				onUpdate:        strings.ReplaceAll(schemaString(values[5]), "_", " "),
+			}
+
+			if i.dialect() != DialectSQLite {
+				foreignKey.name = group
+			}
+
+			foreignKeys = append(foreignKeys, foreignKey)
+		}
+
+		foreignKey := &foreignKeys[len(foreignKeys)-1]
+		foreignKey.columns = append(foreignKey.columns, schemaString(values[1]))
+		if values[3] != nil {
+			foreignKey.referencedColumns = append(foreignKey.referencedColumns, schemaString(values[3]))
+		}
+	}
+
+	if i.dialect() != DialectSQLite {
+		return foreignKeys, nil
+	}
+
+	// SQLite leaves the referenced columns empty when the foreign key references the primary key.
+	for index := range foreignKeys {
+		foreignKey := &foreignKeys[index]
+		if len(foreignKey.referencedColumns) > 0 {
+			continue
+		}
+
+		_, foreignKey.referencedColumns, err = i.sqliteColumns(foreignKey.referencedTable)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return foreignKeys, nil
+}
+
+// parseDeclaredType splits the declared SQLite type like DECIMAL(10, 2) into the type name and its arguments.
+func parseDeclaredType(declared string) (string, []int64) {
+	name, rest, found := strings.Cut(declared, "(")
+	name = strings.TrimSpace(name)
+	if !found {
+		return name, nil
+	}
+
+	rest, _, _ = strings.Cut(rest, ")")
+
+	arguments := make([]int64, 0, 2)
+	for _, argument := range strings.Split(rest, ",") {
+		value, err := strconv.ParseInt(strings.TrimSpace(argument), 10, 64)
+		if err != nil {
+			return name, nil
+		}
+
+		arguments = append(arguments, value)
+	}
+
+	return name, arguments
+}
+
+func firstColumn(rows [][]interface{}) []string {
+	values := make([]string, 0, len(rows))
+	for _, row := range rows {
+		values = append(values, schemaString(row[0]))
+	}
+
+	return values
+}
+
+// schemaString converts the catalog value to a string; the drivers return the text columns
+// as strings or byte slices.
+func schemaString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return string(v)
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// schemaInt64 converts the catalog value to an integer; booleans are converted to 0 and 1.
+func schemaInt64(value interface{}) int64 {
+	switch v := value.(type) {
+	case int64:
+		return v
+	case bool:
+		if v {
+			return 1
+		}
+
+		return 0
+	default:
+		number, _ := strconv.ParseInt(schemaString(value), 10, 64)
+		return number
+	}
+}
@@ -0,0 +1,329 @@
+package db
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	db_errors "github.com/fatihtatoglu/db-go/error"
+)
+
+// testInspectedColumnNames are the columns of the column query of the Inspector.
+var testInspectedColumnNames = []string{"column_name", "ordinal_position", "data_type", "is_nullable", "length", "precision", "scale", "column_default", "auto"}
+
+// testInspectedTable is a table read by the Inspector. The columns are the rows of the column query; the
+// indexes are name, column and unique rows, the foreign keys are name, column, referenced table, referenced
+// column, delete rule and update rule rows.
+type testInspectedTable struct {
+	columns     [][]interface{}
+	primaryKey  []string
+	indexes     [][]interface{}
+	foreignKeys [][]interface{}
+}
+
+// createTestInspection returns the results of the queries which the Inspector runs for the tables, in order.
+func createTestInspection(tables ...testInspectedTable) []*DBTable {
+	results := make([]*DBTable, 0, len(tables)*4)
+	for _, table := range tables {
+		primaryKey := make([][]interface{}, len(table.primaryKey))
+		for i, column := range table.primaryKey {
+			primaryKey[i] = []interface{}{column}
+		}
+
+		results = append(results,
+			createTestTable(testInspectedColumnNames, table.columns...),
+			createTestTable([]string{"column_name"}, primaryKey...),
+			createTestTable([]string{"index_name", "column_name", "unique"}, table.indexes...),
+			createTestTable([]string{"constraint_name", "column_name", "referenced_table", "referenced_column", "delete_rule", "update_rule"}, table.foreignKeys...),
+		)
+	}
+
+	return results
+}
+
+func TestCreateNewInspector_Errors(t *testing.T) {
+	// Act
+	_, nilCommandErr := CreateNewInspector(nil)
+	_, dialectErr := CreateNewInspector(&recordingCommand{dialect: DialectUnknown})
+
+	// Assert
+	assertError(t, nilCommandErr, db_errors.Inspector_NilCommandErrorMessage)
+	assertError(t, dialectErr, db_errors.Inspector_UnsupportedDialectErrorMessage)
+}
+
+func TestInspector_GetTable_MySQL(t *testing.T) {
+	// Arrange
+	command := &recordingCommand{
+		dialect: DialectMySQL,
+		tables: []*DBTable{
+			createTestTable(testInspectedColumnNames,
+				[]interface{}{"id", int64(1), "bigint", "NO", int64(0), int64(19), int64(0), nil, int64(1)},
+				[]interface{}{"email", int64(2), "varchar", "NO", int64(255), int64(0), int64(0), nil, int64(0)},
+				[]interface{}{"status", int64(3), "varchar", "YES", int64(20), int64(0), int64(0), []byte("active"), int64(0)},
+				[]interface{}{"customer_id", int64(4), "bigint", "NO", int64(0), int64(19), int64(0), nil, int64(0)},
+			),
+			createTestTable([]string{"column_name"}, []interface{}{"id"}),
+			createTestTable([]string{"index_name", "column_name", "unique"},
+				[]interface{}{"ix_users_email", "email", int64(1)},
+				[]interface{}{"ix_users_status", "status", int64(0)},
+				[]interface{}{"ix_users_status", "email", int64(0)},
+			),
+			createTestTable([]string{"constraint_name", "column_name", "referenced_table", "referenced_column", "delete_rule", "update_rule"},
+				[]interface{}{"fk_users_customers", "customer_id", "customers", "id", "CASCADE", "NO ACTION"},
+			),
+		},
+	}
+	sut, _ := CreateNewInspector(command)
+
+	// Act
+	table, err := sut.GetTable("users")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(table.GetColumns()) != 4 {
+		t.Fatalf("Expected 4 columns, got: %d", len(table.GetColumns()))
+	}
+
+	id, _ := table.GetColumn("id")
+	if !id.GetAutoIncrement() || id.GetNullable() || id.GetPrecision() != 19 {
+		t.Errorf("Expected an auto increment, not null id column, got: %+v", id)
+	}
+
+	email, _ := table.GetColumn("email")
+	if _, ok := email.GetDefaultValue(); ok || email.GetLength() != 255 || email.GetDBType() != "varchar" {
+		t.Errorf("Expected a varchar(255) email column without a default, got: %+v", email)
+	}
+
+	status, _ := table.GetColumn("status")
+	if value, ok := status.GetDefaultValue(); !ok || value != "active" || !status.GetNullable() {
+		t.Errorf("Expected a nullable status column with the default, got: %+v", status)
+	}
+
+	if !reflect.DeepEqual(table.GetPrimaryKey(), []string{"id"}) {
+		t.Errorf("Expected the primary key: [id], got: %v", table.GetPrimaryKey())
+	}
+
+	expectedIndexes := []DBIndex{
+		CreateNewDBIndex("ix_users_email", []string{"email"}, true),
+		CreateNewDBIndex("ix_users_status", []string{"status", "email"}, false),
+	}
+	if !reflect.DeepEqual(table.GetIndexes(), expectedIndexes) {
+		t.Errorf("Expected the indexes: %+v, got: %+v", expectedIndexes, table.GetIndexes())
+	}
+
+	foreignKeys := table.GetForeignKeys()
+	if len(foreignKeys) != 1 || foreignKeys[0].GetName() != "fk_users_customers" || foreignKeys[0].GetReferencedTable() != "customers" ||
+		foreignKeys[0].GetOnDelete() != "CASCADE" || foreignKeys[0].GetOnUpdate() != "NO ACTION" {
+		t.Errorf("Expected the foreign key to customers, got: %+v", foreignKeys)
+	}
+
+	if !strings.Contains(command.queries[0], "column_type LIKE '%unsigned%'") {
+		t.Errorf("Expected the column query to read the unsigned attribute, got: %s", command.queries[0])
+	}
+
+	for i, query := range command.queries {
+		if !strings.Contains(query, "DATABASE()") || !reflect.DeepEqual(command.args[i], []interface{}{"users"}) {
+			t.Errorf("Expected the query %d to filter the users table of the current database, got: %s %v", i, query, command.args[i])
+		}
+	}
+}
+
+func TestInspector_GetTable_Unreadable_Referenced_Columns(t *testing.T) {
+	// Arrange
+	command := &recordingCommand{
+		dialect: DialectPostgres,
+		tables: []*DBTable{
+			createTestTable(testInspectedColumnNames,
+				[]interface{}{"customer_id", int64(1), "bigint", "NO", int64(0), int64(64), int64(0), nil, int64(0)},
+			),
+			createTestTable([]string{"column_name"}),
+			createTestTable([]string{"index_name", "column_name", "unique"}),
+			createTestTable([]string{"constraint_name", "column_name", "referenced_table", "referenced_column", "delete_rule", "update_rule"},
+				[]interface{}{"fk_users_customers", "customer_id", "customers", nil, "CASCADE", "NO ACTION"},
+			),
+		},
+	}
+	sut, _ := CreateNewInspector(command)
+
+	// Act
+	table, err := sut.GetTable("users")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(command.queries) != 4 {
+		t.Errorf("Expected no SQLite pragma queries for PostgreSQL, got: %v", command.queries)
+	}
+
+	if foreignKeys := table.GetForeignKeys(); len(foreignKeys) != 1 || len(foreignKeys[0].GetReferencedColumns()) != 0 {
+		t.Errorf("Expected the foreign key without referenced columns, got: %+v", foreignKeys)
+	}
+}
+
+func TestInspector_GetTable_Rebind(t *testing.T) {
+	// Arrange
+	command := &recordingCommand{
+		dialect: DialectPostgres,
+		tables: []*DBTable{
+			createTestTable(testInspectedColumnNames,
+				[]interface{}{"id", int64(1), "bigint", "NO", int64(0), int64(64), int64(0), "nextval('users_id_seq'::regclass)", int64(1)},
+			),
+		},
+	}
+	sut, _ := CreateNewInspector(command)
+
+	// Act
+	_, err := sut.GetTable("users")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !strings.Contains(command.queries[0], "table_name = $1") || !strings.Contains(command.queries[0], "current_schema()") {
+		t.Errorf("Expected the query to be rebound for the dialect, got: %s", command.queries[0])
+	}
+}
+
+func TestInspector_GetTable_SQLite(t *testing.T) {
+	// Arrange
+	tableInfoColumns := []string{"cid", "name", "type", "notnull", "dflt_value", "pk"}
+	command := &recordingCommand{
+		dialect: DialectSQLite,
+		tables: []*DBTable{
+			createTestTable(tableInfoColumns,
+				[]interface{}{int64(0), "id", "INTEGER", int64(0), nil, int64(1)},
+				[]interface{}{int64(1), "name", "VARCHAR(100)", int64(1), "'unknown'", int64(0)},
+				[]interface{}{int64(2), "price", "DECIMAL(10, 2)", int64(0), nil, int64(0)},
+				[]interface{}{int64(3), "customer_id", "INTEGER", int64(0), nil, int64(0)},
+			),
+			createTestTable([]string{"name", "name", "unique"}, []interface{}{"ix_orders_name", "name", int64(1)}),
+			createTestTable([]string{"id", "from", "table", "to", "on_delete", "on_update"},
+				[]interface{}{int64(0), "customer_id", "customers", nil, "SET NULL", "NO ACTION"},
+			),
+			createTestTable(tableInfoColumns, []interface{}{int64(0), "code", "TEXT", int64(1), nil, int64(1)}),
+		},
+	}
+	sut, _ := CreateNewInspector(command)
+
+	// Act
+	table, err := sut.GetTable("orders")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	id, _ := table.GetColumn("id")
+	if !id.GetAutoIncrement() || id.GetNullable() || id.GetOrdinal() != 1 {
+		t.Errorf("Expected the rowid alias to be an auto increment column, got: %+v", id)
+	}
+
+	name, _ := table.GetColumn("name")
+	if value, _ := name.GetDefaultValue(); name.GetDBType() != "VARCHAR" || name.GetLength() != 100 || value != "'unknown'" || name.GetNullable() {
+		t.Errorf("Expected a VARCHAR(100) name column with the default, got: %+v", name)
+	}
+
+	price, _ := table.GetColumn("price")
+	if price.GetPrecision() != 10 || price.GetScale() != 2 || !price.GetNullable() || price.GetAutoIncrement() {
+		t.Errorf("Expected a DECIMAL(10, 2) price column, got: %+v", price)
+	}
+
+	if len(table.GetIndexes()) != 1 || !table.GetIndexes()[0].GetUnique() {
+		t.Errorf("Expected the unique name index, got: %+v", table.GetIndexes())
+	}
+
+	foreignKeys := table.GetForeignKeys()
+	if len(foreignKeys) != 1 || foreignKeys[0].GetName() != "" || !reflect.DeepEqual(foreignKeys[0].GetReferencedColumns(), []string{"code"}) {
+		t.Errorf("Expected the foreign key to reference the primary key of customers, got: %+v", foreignKeys)
+	}
+
+	if !reflect.DeepEqual(command.args[3], []interface{}{"customers"}) {
+		t.Errorf("Expected the primary key of customers to be read, got: %v", command.args[3])
+	}
+}
+
+func TestInspector_GetTable_NotFound(t *testing.T) {
+	// Arrange
+	sut, _ := CreateNewInspector(&recordingCommand{dialect: DialectSQLServer})
+
+	// Act
+	_, err := sut.GetTable("missing")
+
+	// Assert
+	assertError(t, err, db_errors.Inspector_TableNotFoundErrorMessage)
+}
+
+func TestInspector_GetSchema(t *testing.T) {
+	// Arrange
+	command := &recordingCommand{
+		dialect: DialectSQLServer,
+		tables: []*DBTable{
+			createTestTable([]string{"table_name"}, []interface{}{"users"}),
+			createTestTable(testInspectedColumnNames,
+				[]interface{}{"id", int64(1), "int", "NO", int64(0), int64(10), int64(0), nil, int64(1)},
+			),
+			createTestTable([]string{"column_name"}, []interface{}{"id"}),
+			createTestTable([]string{"name", "column", "is_unique"}),
+			createTestTable([]string{"name", "column", "table", "referenced_column", "delete", "update"},
+				[]interface{}{"fk_users_users", "id", "users", "id", "SET_NULL", "NO_ACTION"},
+			),
+			createTestTable([]string{"name", "definition"}, []interface{}{"active_users", "CREATE VIEW active_users AS SELECT 1"}),
+		},
+	}
+	sut, _ := CreateNewInspector(command)
+
+	// Act
+	schema, err := sut.GetSchema()
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	users, ok := schema.GetTable("users")
+	if !ok || len(users.GetColumns()) != 1 {
+		t.Fatalf("Expected the users table, got: %+v", schema.GetTables())
+	}
+
+	if foreignKey := users.GetForeignKeys()[0]; foreignKey.GetOnDelete() != "SET NULL" || foreignKey.GetOnUpdate() != "NO ACTION" {
+		t.Errorf("Expected the referential actions to be normalized, got: %+v", foreignKey)
+	}
+
+	views := schema.GetViews()
+	if len(views) != 1 || views[0].GetName() != "active_users" {
+		t.Errorf("Expected the active_users view, got: %+v", views)
+	}
+}
+
+func TestParseDeclaredType(t *testing.T) {
+	// Test cases
+	testCases := []struct {
+		declared          string
+		expectedName      string
+		expectedArguments []int64
+	}{
+		{"INTEGER", "INTEGER", nil},
+		{"VARCHAR(255)", "VARCHAR", []int64{255}},
+		{"DECIMAL(10, 2)", "DECIMAL", []int64{10, 2}},
+		{"UNSIGNED BIG INT", "UNSIGNED BIG INT", nil},
+		{"ENUM(a)", "ENUM", nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.declared, func(t *testing.T) {
+			// Act
+			name, arguments := parseDeclaredType(tc.declared)
+
+			// Assert
+			if name != tc.expectedName || !reflect.DeepEqual(arguments, tc.expectedArguments) {
+				t.Errorf("Expected: %s %v, got: %s %v", tc.expectedName, tc.expectedArguments, name, arguments)
+			}
+		})
+	}
+}
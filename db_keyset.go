@@ -0,0 +1,308 @@
+package db
+
+import (
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"time"
+
+	db_criteria "github.com/fatihtatoglu/db-go/criteria"
+	db_errors "github.com/fatihtatoglu/db-go/error"
+)
+
+// KeysetPage is a page of entities fetched with keyset pagination.
+type KeysetPage[T any] struct {
+	items      []T
+	nextCursor string
+}
+
+func (p *KeysetPage[T]) GetItems() []T {
+	return p.items
+}
+
+// GetNextCursor returns the cursor of the next page, it is empty on the last page.
+func (p *KeysetPage[T]) GetNextCursor() string {
+	return p.nextCursor
+}
+
+func (p *KeysetPage[T]) HasNext() bool {
+	return p.nextCursor != ""
+}
+
+// keysetCursor is the decoded form of the opaque cursor token. It keeps the sort order
+// with the last seen values, so a cursor cannot be replayed against another order.
+type keysetCursor struct {
+	Sort   []string    `json:"s"`
+	Values [][2]string `json:"v"`
+}
+
+// Cursor value type codes, the values are kept as strings to avoid losing the precision of large integers.
+const (
+	cursorNull   = "n"
+	cursorInt    = "i"
+	cursorUint   = "u"
+	cursorFloat  = "f"
+	cursorString = "s"
+	cursorBool   = "b"
+	cursorTime   = "t"
+	cursorBytes  = "x"
+)
+
+func (r *dbRepository[T, K]) FetchAfter(cursor string, limit int, sort ...db_criteria.Sort) (*KeysetPage[T], error) {
+	sort, fields, err := r.stableSort(sort)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := r.selectBuilder().OrderBySort(sort...)
+	if cursor != "" {
+		values, err := decodeCursor(cursor, sort)
+		if err != nil {
+			return nil, err
+		}
+
+		builder.WhereCriteria(keysetCriteria(sort, values))
+	}
+
+	// One more row than requested tells whether there is a next page.
+	if limit > 0 {
+		builder.Limit(limit + 1)
+	}
+
+	dt, err := builder.Query(r.command)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := r.mapRows(dt)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &KeysetPage[T]{items: items}
+	if limit <= 0 || len(items) <= limit {
+		return page, nil
+	}
+
+	page.items = items[:limit]
+
+	last, err := r.metadata.structValue(page.items[limit-1])
+	if err != nil {
+		return nil, err
+	}
+
+	page.nextCursor, err = encodeCursor(sort, r.metadata.values(last, fields))
+	if err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// stableSort validates the sort columns and appends the missing primary key columns,
+// which makes the order total so rows with equal sort values are never skipped or repeated between pages.
+func (r *dbRepository[T, K]) stableSort(sort []db_criteria.Sort) ([]db_criteria.Sort, []*entityField, error) {
+	result := make([]db_criteria.Sort, 0, len(sort)+len(r.keys))
+	fields := make([]*entityField, 0, len(sort)+len(r.keys))
+	seen := make(map[string]bool)
+
+	for _, s := range sort {
+		f, ok := r.metadata.fieldByColumn(s.GetColumn())
+		if !ok {
+			return nil, nil, db_errors.RepositoryInvalidSortColumnError()
+		}
+
+		if seen[f.column] {
+			continue
+		}
+
+		seen[f.column] = true
+		result = append(result, s)
+		fields = append(fields, f)
+	}
+
+	for _, f := range r.keys {
+		if !seen[f.column] {
+			result = append(result, db_criteria.Asc(f.column))
+			fields = append(fields, f)
+		}
+	}
+
+	return result, fields, nil
+}
+
+// keysetCriteria matches the rows after the cursor values in the sort order:
+//
+//	(a > ?) OR (a = ? AND b < ?) OR (a = ? AND b = ? AND c > ?)
+//
+// The expanded form is used instead of a row value comparison to support mixed sort directions.
+func keysetCriteria(sort []db_criteria.Sort, values []interface{}) db_criteria.Criteria {
+	alternatives := make([]db_criteria.Criteria, len(sort))
+	for i, s := range sort {
+		conditions := make([]db_criteria.Criteria, 0, i+1)
+		for j := 0; j < i; j++ {
+			conditions = append(conditions, db_criteria.Eq(sort[j].GetColumn(), values[j]))
+		}
+
+		if s.IsDescending() {
+			conditions = append(conditions, db_criteria.Lt(s.GetColumn(), values[i]))
+		} else {
+			conditions = append(conditions, db_criteria.Gt(s.GetColumn(), values[i]))
+		}
+
+		alternatives[i] = db_criteria.And(conditions...)
+	}
+
+	return db_criteria.Or(alternatives...)
+}
+
+func sortSignature(sort []db_criteria.Sort) []string {
+	signature := make([]string, len(sort))
+	for i, s := range sort {
+		if s.IsDescending() {
+			signature[i] = s.GetColumn() + " DESC"
+		} else {
+			signature[i] = s.GetColumn() + " ASC"
+		}
+	}
+
+	return signature
+}
+
+func encodeCursor(sort []db_criteria.Sort, values []interface{}) (string, error) {
+	cursor := keysetCursor{
+		Sort:   sortSignature(sort),
+		Values: make([][2]string, len(values)),
+	}
+
+	for i, value := range values {
+		encoded, err := encodeCursorValue(value)
+		if err != nil {
+			return "", err
+		}
+
+		cursor.Values[i] = encoded
+	}
+
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeCursor(token string, sort []db_criteria.Sort) ([]interface{}, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, db_errors.RepositoryInvalidCursorError()
+	}
+
+	var cursor keysetCursor
+	err = json.Unmarshal(data, &cursor)
+	if err != nil {
+		return nil, db_errors.RepositoryInvalidCursorError()
+	}
+
+	signature := sortSignature(sort)
+	if len(cursor.Sort) != len(signature) || len(cursor.Values) != len(signature) {
+		return nil, db_errors.RepositoryInvalidCursorError()
+	}
+
+	values := make([]interface{}, len(cursor.Values))
+	for i, encoded := range cursor.Values {
+		if cursor.Sort[i] != signature[i] {
+			return nil, db_errors.RepositoryInvalidCursorError()
+		}
+
+		values[i], err = decodeCursorValue(encoded)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return values, nil
+}
+
+func encodeCursorValue(value interface{}) ([2]string, error) {
+	if valuer, ok := value.(driver.Valuer); ok {
+		v, err := valuer.Value()
+		if err != nil {
+			return [2]string{}, err
+		}
+
+		value = v
+	}
+
+	if t, ok := value.(time.Time); ok {
+		return [2]string{cursorTime, t.Format(time.RFC3339Nano)}, nil
+	}
+
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return [2]string{cursorNull, ""}, nil
+		}
+
+		rv = rv.Elem()
+	}
+
+	switch rv.Kind() {
+	case reflect.Invalid:
+		return [2]string{cursorNull, ""}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return [2]string{cursorInt, strconv.FormatInt(rv.Int(), 10)}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return [2]string{cursorUint, strconv.FormatUint(rv.Uint(), 10)}, nil
+	case reflect.Float32, reflect.Float64:
+		return [2]string{cursorFloat, strconv.FormatFloat(rv.Float(), 'g', -1, 64)}, nil
+	case reflect.String:
+		return [2]string{cursorString, rv.String()}, nil
+	case reflect.Bool:
+		return [2]string{cursorBool, strconv.FormatBool(rv.Bool())}, nil
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return [2]string{cursorBytes, base64.StdEncoding.EncodeToString(rv.Bytes())}, nil
+		}
+	case reflect.Struct:
+		if t, ok := rv.Interface().(time.Time); ok {
+			return [2]string{cursorTime, t.Format(time.RFC3339Nano)}, nil
+		}
+	}
+
+	return [2]string{}, db_errors.ValueUnsupportedConversionError()
+}
+
+func decodeCursorValue(encoded [2]string) (interface{}, error) {
+	var value interface{}
+	var err error
+
+	switch encoded[0] {
+	case cursorNull:
+		return nil, nil
+	case cursorInt:
+		value, err = strconv.ParseInt(encoded[1], 10, 64)
+	case cursorUint:
+		value, err = strconv.ParseUint(encoded[1], 10, 64)
+	case cursorFloat:
+		value, err = strconv.ParseFloat(encoded[1], 64)
+	case cursorString:
+		value = encoded[1]
+	case cursorBool:
+		value, err = strconv.ParseBool(encoded[1])
+	case cursorTime:
+		value, err = time.Parse(time.RFC3339Nano, encoded[1])
+	case cursorBytes:
+		value, err = base64.StdEncoding.DecodeString(encoded[1])
+	default:
+		return nil, db_errors.RepositoryInvalidCursorError()
+	}
+
+	if err != nil {
+		return nil, db_errors.RepositoryInvalidCursorError()
+	}
+
+	return value, nil
+}
@@ -0,0 +1,130 @@
+package db
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	db_criteria "github.com/fatihtatoglu/db-go/criteria"
+	db_errors "github.com/fatihtatoglu/db-go/error"
+)
+
+func TestRepository_FetchAfter(t *testing.T) {
+	// Arrange
+	command := &recordingCommand{
+		dialect: DialectPostgres,
+		table: createTestTable([]string{"id", "first_name", "age"},
+			[]interface{}{int64(1), "Fatih", int64(39)},
+			[]interface{}{int64(2), "Ali", int64(39)},
+			[]interface{}{int64(3), "Ayse", int64(25)},
+		),
+	}
+	sut, _ := CreateNewRepository[testUser, int64](command, "users")
+
+	// Act
+	first, err := sut.FetchAfter("", 2, db_criteria.Desc("age"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	_, err = sut.FetchAfter(first.GetNextCursor(), 2, db_criteria.Desc("age"))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(first.GetItems()) != 2 || !first.HasNext() {
+		t.Fatalf("Expected 2 items and a next cursor, got %d items", len(first.GetItems()))
+	}
+
+	expectedFirst := `SELECT "created_at", "id", "first_name", "age" FROM "users" ORDER BY "age" DESC, "id" ASC LIMIT $1 OFFSET $2`
+	if command.queries[0] != expectedFirst {
+		t.Errorf("Expected query: %s, got: %s", expectedFirst, command.queries[0])
+	}
+
+	expectedNext := `SELECT "created_at", "id", "first_name", "age" FROM "users" WHERE (("age" < $1)) OR (("age" = $2) AND ("id" > $3)) ORDER BY "age" DESC, "id" ASC LIMIT $4 OFFSET $5`
+	if command.queries[1] != expectedNext {
+		t.Errorf("Expected query: %s, got: %s", expectedNext, command.queries[1])
+	}
+
+	expectedArgs := []interface{}{int64(39), int64(39), int64(2), 3, 0}
+	if !reflect.DeepEqual(command.args[1], expectedArgs) {
+		t.Errorf("Expected args: %v, got: %v", expectedArgs, command.args[1])
+	}
+}
+
+func TestRepository_FetchAfter_LastPage(t *testing.T) {
+	// Arrange
+	command := &recordingCommand{
+		dialect: DialectMySQL,
+		table:   createTestTable([]string{"id"}, []interface{}{int64(1)}),
+	}
+	sut, _ := CreateNewRepository[testUser, int64](command, "users")
+
+	// Act
+	page, err := sut.FetchAfter("", 10)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if page.HasNext() || page.GetNextCursor() != "" {
+		t.Errorf("Expected the last page, got cursor: %s", page.GetNextCursor())
+	}
+}
+
+func TestRepository_FetchAfter_Errors(t *testing.T) {
+	// Arrange
+	command := &recordingCommand{dialect: DialectMySQL}
+	sut, _ := CreateNewRepository[testUser, int64](command, "users")
+	cursor, _ := encodeCursor([]db_criteria.Sort{db_criteria.Asc("id")}, []interface{}{int64(1)})
+
+	// Test cases
+	testCases := []struct {
+		testName      string
+		cursor        string
+		sort          []db_criteria.Sort
+		expectedError string
+	}{
+		{"Unknown sort column", "", []db_criteria.Sort{db_criteria.Asc("password")}, db_errors.Repository_InvalidSortColumnErrorMessage},
+		{"Malformed cursor", "not a cursor!", nil, db_errors.Repository_InvalidCursorErrorMessage},
+		{"Cursor of another order", cursor, []db_criteria.Sort{db_criteria.Desc("age")}, db_errors.Repository_InvalidCursorErrorMessage},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			// Act
+			_, err := sut.FetchAfter(tc.cursor, 10, tc.sort...)
+
+			// Assert
+			assertError(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestCursor_RoundTrip(t *testing.T) {
+	// Arrange
+	sort := []db_criteria.Sort{db_criteria.Asc("a"), db_criteria.Desc("b"), db_criteria.Asc("c"), db_criteria.Asc("d"), db_criteria.Asc("e"), db_criteria.Asc("f")}
+	created := time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)
+	values := []interface{}{int32(-7), uint64(18446744073709551615), 1.5, "x", created, nil}
+	expected := []interface{}{int64(-7), uint64(18446744073709551615), 1.5, "x", created, nil}
+
+	// Act
+	cursor, err := encodeCursor(sort, values)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	decoded, err := decodeCursor(cursor, sort)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(decoded, expected) {
+		t.Errorf("Expected values: %v, got: %v", expected, decoded)
+	}
+}
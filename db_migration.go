@@ -0,0 +1,187 @@
+package db
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	db_errors "github.com/fatihtatoglu/db-go/error"
+)
+
+// migrationFileName matches the migration files like 0001_create_users.up.sql; the name is optional.
+var migrationFileName = regexp.MustCompile(`^(\d+)(?:_(.+))?\.(up|down)\.sql$`)
+
+// MigrationFunc is the Go code of a migration. The command is bound to the transaction of the migration.
+type MigrationFunc func(tx DBCommandInterface) error
+
+// Migration is a numbered schema change with the SQL scripts or the Go functions which apply and revert it.
+type Migration struct {
+	version  int64
+	name     string
+	up       string
+	down     string
+	upFunc   MigrationFunc
+	downFunc MigrationFunc
+	checksum string
+}
+
+func CreateNewMigration(version int64, name string, up string, down string) Migration {
+	return Migration{
+		version:  version,
+		name:     name,
+		up:       up,
+		down:     down,
+		checksum: migrationChecksum(up),
+	}
+}
+
+// CreateNewGoMigration creates a migration which runs Go functions; down can be nil when the migration
+// cannot be reverted.
+func CreateNewGoMigration(version int64, name string, up MigrationFunc, down MigrationFunc) Migration {
+	return Migration{
+		version:  version,
+		name:     name,
+		upFunc:   up,
+		downFunc: down,
+	}
+}
+
+func (m *Migration) GetVersion() int64 {
+	return m.version
+}
+
+func (m *Migration) GetName() string {
+	return m.name
+}
+
+func (m *Migration) GetUp() string {
+	return m.up
+}
+
+// GetDown returns the script which reverts the migration, empty when the migration cannot be reverted.
+func (m *Migration) GetDown() string {
+	return m.down
+}
+
+// GetChecksum returns the SHA-256 checksum of the up script, empty for the Go migrations.
+func (m *Migration) GetChecksum() string {
+	return m.checksum
+}
+
+// hasDown returns whether the migration can be reverted.
+func (m *Migration) hasDown() bool {
+	return m.downFunc != nil || (m.upFunc == nil && strings.TrimSpace(m.down) != "")
+}
+
+// migrationChecksum returns the hex SHA-256 checksum of the script. The line endings are normalized,
+// so a script checked out with CRLF line endings keeps its checksum.
+func migrationChecksum(script string) string {
+	sum := sha256.Sum256([]byte(strings.ReplaceAll(script, "\r\n", "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+// MigrationStatus is the state of a migration. The applied migrations whose files are missing are reported
+// with the name recorded when they were applied.
+type MigrationStatus struct {
+	version   int64
+	name      string
+	applied   bool
+	appliedAt time.Time
+	checksum  string
+	modified  bool
+}
+
+func (s *MigrationStatus) GetVersion() int64 {
+	return s.version
+}
+
+func (s *MigrationStatus) GetName() string {
+	return s.name
+}
+
+func (s *MigrationStatus) GetApplied() bool {
+	return s.applied
+}
+
+// GetAppliedAt returns the UTC time the migration was applied, the zero time when it is pending.
+func (s *MigrationStatus) GetAppliedAt() time.Time {
+	return s.appliedAt
+}
+
+// GetModified returns whether the up script of the applied migration has been changed since it was applied.
+func (s *MigrationStatus) GetModified() bool {
+	return s.modified
+}
+
+// LoadMigrations reads the migrations from the .sql files in the root of the file system, sorted by version.
+//
+// The files are named <version>_<name>.up.sql and <version>_<name>.down.sql; the down script is optional.
+// The other files are ignored. A directory is read with os.DirFS and an embedded directory with fs.Sub.
+func LoadMigrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	migrations := make(map[int64]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
+			continue
+		}
+
+		match := migrationFileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, db_errors.MigrationInvalidFileNameError()
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, db_errors.MigrationInvalidFileNameError()
+		}
+
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := migrations[version]
+		if !ok {
+			migration = &Migration{version: version, name: match[2]}
+			migrations[version] = migration
+		} else if migration.name != match[2] {
+			return nil, db_errors.MigrationDuplicateVersionError()
+		}
+
+		script := &migration.up
+		if match[3] == "down" {
+			script = &migration.down
+		}
+
+		if *script != "" {
+			return nil, db_errors.MigrationDuplicateVersionError()
+		}
+
+		*script = string(content)
+	}
+
+	result := make([]Migration, 0, len(migrations))
+	for _, migration := range migrations {
+		if strings.TrimSpace(migration.up) == "" {
+			return nil, db_errors.MigrationMissingUpError()
+		}
+
+		migration.checksum = migrationChecksum(migration.up)
+		result = append(result, *migration)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].version < result[j].version
+	})
+
+	return result, nil
+}
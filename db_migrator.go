@@ -0,0 +1,517 @@
+package db
+
+import (
+	"hash/fnv"
+	"io/fs"
+	"sort"
+	"strings"
+	"time"
+
+	db_errors "github.com/fatihtatoglu/db-go/error"
+)
+
+const (
+	DEFAULT_MIGRATION_TABLE     = "dbgo_schema_migrations"
+	DEFAULT_MIGRATION_LOCK_NAME = "dbgo_schema_migrations"
+)
+
+// MigratorOption configures a Migrator.
+type MigratorOption func(*migratorOptions)
+
+type migratorOptions struct {
+	table        string
+	lockName     string
+	goMigrations []Migration
+}
+
+// WithMigrationTable sets the name of the table which records the applied migrations.
+func WithMigrationTable(name string) MigratorOption {
+	return func(o *migratorOptions) {
+		o.table = name
+	}
+}
+
+// WithMigrationLockName sets the name of the lock which prevents concurrent runs. The migrators of
+// different schemas in the same database server need different lock names.
+func WithMigrationLockName(name string) MigratorOption {
+	return func(o *migratorOptions) {
+		o.lockName = name
+	}
+}
+
+// WithGoMigration registers a migration which runs Go functions, e.g. to backfill data. The Go migrations
+// are ordered by version together with the SQL migrations and always run in a transaction.
+func WithGoMigration(version int64, name string, up MigrationFunc, down MigrationFunc) MigratorOption {
+	return func(o *migratorOptions) {
+		o.goMigrations = append(o.goMigrations, CreateNewGoMigration(version, name, up, down))
+	}
+}
+
+// Migrator applies and reverts the versioned migrations and records the applied versions in a tracking table.
+//
+// Every run holds a lock on a pinned connection, so concurrent runs wait for each other: GET_LOCK on MySQL,
+// pg_advisory_lock on PostgreSQL and sp_getapplock on SQL Server; SQLite serializes the writers itself.
+// Every migration runs in a transaction with its tracking record, except the SQL migrations on MySQL, which
+// commits the DDL statements implicitly; a failed MySQL migration may leave its completed statements behind.
+//
+// The scripts are split into statements with SplitScript and run with ExecuteScript.
+//
+// The checksums of the applied SQL migrations are recorded; Up, Down and To fail when the up script of
+// an applied migration has been changed since.
+type Migrator struct {
+	command    DBCommandInterface
+	migrations []Migration
+	options    migratorOptions
+}
+
+// CreateNewMigrator creates a migrator for the migration files in the root of the file system, see LoadMigrations.
+func CreateNewMigrator(command DBCommandInterface, fsys fs.FS, options ...MigratorOption) (*Migrator, error) {
+	if command == nil {
+		return nil, db_errors.MigrationNilCommandError()
+	}
+
+	migrations, err := LoadMigrations(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Migrator{
+		command:    command,
+		migrations: migrations,
+		options: migratorOptions{
+			table:    DEFAULT_MIGRATION_TABLE,
+			lockName: DEFAULT_MIGRATION_LOCK_NAME,
+		},
+	}
+
+	for _, option := range options {
+		option(&m.options)
+	}
+
+	for _, migration := range m.options.goMigrations {
+		if migration.upFunc == nil {
+			return nil, db_errors.MigrationMissingUpError()
+		}
+
+		if m.find(migration.version) != nil {
+			return nil, db_errors.MigrationDuplicateVersionError()
+		}
+
+		m.migrations = append(m.migrations, migration)
+	}
+
+	sort.Slice(m.migrations, func(i, j int) bool {
+		return m.migrations[i].version < m.migrations[j].version
+	})
+
+	return m, nil
+}
+
+// GetMigrations returns the migrations sorted by version.
+func (m *Migrator) GetMigrations() []Migration {
+	return m.migrations
+}
+
+// Up applies all the pending migrations in the version order.
+func (m *Migrator) Up() error {
+	return m.run(func(session DBCommandInterface, applied map[int64]MigrationStatus) error {
+		err := m.verify(applied)
+		if err != nil {
+			return err
+		}
+
+		for i := range m.migrations {
+			if _, ok := applied[m.migrations[i].version]; ok {
+				continue
+			}
+
+			err := m.apply(session, &m.migrations[i])
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Down reverts the last applied migration.
+func (m *Migrator) Down() error {
+	return m.run(func(session DBCommandInterface, applied map[int64]MigrationStatus) error {
+		err := m.verify(applied)
+		if err != nil {
+			return err
+		}
+
+		versions := appliedVersions(applied)
+		if len(versions) == 0 {
+			return nil
+		}
+
+		migration, err := m.revertible(versions[len(versions)-1])
+		if err != nil {
+			return err
+		}
+
+		return m.revert(session, migration)
+	})
+}
+
+// To migrates the schema to the version: the applied migrations above the version are reverted in the
+// reverse order and the pending migrations up to the version are applied. Version 0 reverts all.
+func (m *Migrator) To(version int64) error {
+	if version != 0 && m.find(version) == nil {
+		return db_errors.MigrationVersionNotFoundError()
+	}
+
+	return m.run(func(session DBCommandInterface, applied map[int64]MigrationStatus) error {
+		err := m.verify(applied)
+		if err != nil {
+			return err
+		}
+
+		versions := appliedVersions(applied)
+
+		// All the reverted migrations are checked before the schema is changed.
+		reverts := make([]*Migration, 0)
+		for i := len(versions) - 1; i >= 0 && versions[i] > version; i-- {
+			migration, err := m.revertible(versions[i])
+			if err != nil {
+				return err
+			}
+
+			reverts = append(reverts, migration)
+		}
+
+		for _, migration := range reverts {
+			err := m.revert(session, migration)
+			if err != nil {
+				return err
+			}
+		}
+
+		for i := range m.migrations {
+			migration := &m.migrations[i]
+			if _, ok := applied[migration.version]; ok || migration.version > version {
+				continue
+			}
+
+			err := m.apply(session, migration)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Status returns the state of every migration in the version order.
+func (m *Migrator) Status() ([]MigrationStatus, error) {
+	var statuses []MigrationStatus
+
+	err := m.run(func(session DBCommandInterface, applied map[int64]MigrationStatus) error {
+		statuses = make([]MigrationStatus, 0, len(m.migrations))
+		for _, migration := range m.migrations {
+			status, ok := applied[migration.version]
+			if !ok {
+				status = MigrationStatus{version: migration.version}
+			}
+
+			status.name = migration.name
+			status.modified = isModified(&migration, status)
+			statuses = append(statuses, status)
+		}
+
+		for _, status := range applied {
+			if m.find(status.version) == nil {
+				statuses = append(statuses, status)
+			}
+		}
+
+		sort.Slice(statuses, func(i, j int) bool {
+			return statuses[i].version < statuses[j].version
+		})
+
+		return nil
+	})
+
+	return statuses, err
+}
+
+// run pins a connection, takes the migration lock on it and runs fn with the applied migrations.
+// A connection whose lock could not be released is discarded rather than returned to the pool.
+func (m *Migrator) run(fn func(session DBCommandInterface, applied map[int64]MigrationStatus) error) error {
+	session := m.command
+	if pinner, ok := m.command.(sessionPinner); ok {
+		pinned, release, err := pinner.pin()
+		if err != nil {
+			return err
+		}
+		defer release()
+
+		session = pinned
+	}
+
+	err := m.lock(session)
+	if err != nil {
+		return err
+	}
+
+	err = m.ensureTable(session)
+	if err == nil {
+		var applied map[int64]MigrationStatus
+		applied, err = m.applied(session)
+		if err == nil {
+			err = fn(session, applied)
+		}
+	}
+
+	unlockErr := m.unlock(session)
+	if unlockErr != nil {
+		// The lock may still be held by the session; the connection must not go back to the pool.
+		if discarder, ok := session.(sessionDiscarder); ok {
+			discarder.discard()
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return unlockErr
+}
+
+func (m *Migrator) lock(session DBCommandInterface) error {
+	var query string
+	var key interface{} = m.options.lockName
+	switch session.GetDialect() {
+	case DialectMySQL:
+		query = "SELECT GET_LOCK(?, -1)"
+	case DialectPostgres:
+		query = "SELECT 1 FROM (SELECT pg_advisory_lock(?)) l"
+		key = m.lockKey()
+	case DialectSQLServer:
+		query = "DECLARE @result INT; EXEC @result = sp_getapplock @Resource = ?, @LockMode = 'Exclusive', " +
+			"@LockOwner = 'Session', @LockTimeout = -1; SELECT CASE WHEN @result >= 0 THEN 1 ELSE 0 END"
+	default:
+		return nil
+	}
+
+	dt, err := session.Query(session.GetDialect().Rebind(query), key)
+	if err != nil {
+		return err
+	}
+
+	if len(dt.GetRows()) == 0 {
+		return db_errors.MigrationLockFailedError()
+	}
+
+	value, _ := dt.GetRows()[0].GetItemByIndex(0)
+	if schemaInt64(value) != 1 {
+		return db_errors.MigrationLockFailedError()
+	}
+
+	return nil
+}
+
+func (m *Migrator) unlock(session DBCommandInterface) error {
+	var query string
+	var key interface{} = m.options.lockName
+	switch session.GetDialect() {
+	case DialectMySQL:
+		query = "SELECT RELEASE_LOCK(?)"
+	case DialectPostgres:
+		query = "SELECT pg_advisory_unlock(?)"
+		key = m.lockKey()
+	case DialectSQLServer:
+		query = "EXEC sp_releaseapplock @Resource = ?, @LockOwner = 'Session'"
+	default:
+		return nil
+	}
+
+	_, err := session.Execute(session.GetDialect().Rebind(query), key)
+	return err
+}
+
+// lockKey returns the PostgreSQL advisory lock key of the lock name.
+func (m *Migrator) lockKey() int64 {
+	h := fnv.New64a()
+	h.Write([]byte(m.options.lockName))
+	return int64(h.Sum64())
+}
+
+func (m *Migrator) ensureTable(session DBCommandInterface) error {
+	dialect := session.GetDialect()
+
+	timestamp := "TIMESTAMP"
+	switch dialect {
+	case DialectMySQL:
+		timestamp = "DATETIME(6)"
+	case DialectSQLServer:
+		timestamp = "DATETIME2"
+	}
+
+	columns := " (" + dialect.QuoteIdentifier("version") + " BIGINT NOT NULL PRIMARY KEY, " +
+		dialect.QuoteIdentifier("name") + " VARCHAR(255) NOT NULL, " +
+		dialect.QuoteIdentifier("checksum") + " VARCHAR(64) NULL, " +
+		dialect.QuoteIdentifier("applied_at") + " " + timestamp + " NOT NULL)"
+
+	table := dialect.QuoteIdentifier(m.options.table)
+	query := "CREATE TABLE IF NOT EXISTS " + table + columns
+	if dialect == DialectSQLServer {
+		query = "IF OBJECT_ID(N'" + strings.ReplaceAll(table, "'", "''") + "', N'U') IS NULL CREATE TABLE " + table + columns
+	}
+
+	_, err := session.Execute(query)
+	return err
+}
+
+func (m *Migrator) applied(session DBCommandInterface) (map[int64]MigrationStatus, error) {
+	dialect := session.GetDialect()
+
+	dt, err := session.Query("SELECT " + dialect.QuoteIdentifier("version") + ", " + dialect.QuoteIdentifier("name") + ", " +
+		dialect.QuoteIdentifier("checksum") + ", " + dialect.QuoteIdentifier("applied_at") + " FROM " + dialect.QuoteIdentifier(m.options.table))
+	if err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int64]MigrationStatus, len(dt.GetRows()))
+	for _, row := range dt.GetRows() {
+		status := MigrationStatus{applied: true}
+
+		values := make([]interface{}, 4)
+		for i := range values {
+			values[i], _ = row.GetItemByIndex(i)
+		}
+
+		err = assignValue(&status.version, values[0])
+		if err == nil {
+			err = assignValue(&status.name, values[1])
+		}
+
+		if err == nil {
+			err = assignValue(&status.checksum, values[2])
+		}
+
+		if err == nil {
+			err = assignValue(&status.appliedAt, values[3])
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		applied[status.version] = status
+	}
+
+	return applied, nil
+}
+
+func (m *Migrator) apply(session DBCommandInterface, migration *Migration) error {
+	dialect := session.GetDialect()
+	query := dialect.Rebind("INSERT INTO " + dialect.QuoteIdentifier(m.options.table) + " (" + dialect.QuoteIdentifier("version") +
+		", " + dialect.QuoteIdentifier("name") + ", " + dialect.QuoteIdentifier("checksum") + ", " + dialect.QuoteIdentifier("applied_at") +
+		") VALUES (?, ?, ?, ?)")
+
+	var checksum interface{}
+	if migration.checksum != "" {
+		checksum = migration.checksum
+	}
+
+	return m.execute(session, migration.up, migration.upFunc, query, migration.version, migration.name, checksum, time.Now().UTC())
+}
+
+func (m *Migrator) revert(session DBCommandInterface, migration *Migration) error {
+	dialect := session.GetDialect()
+	query := dialect.Rebind("DELETE FROM " + dialect.QuoteIdentifier(m.options.table) + " WHERE " + dialect.QuoteIdentifier("version") + " = ?")
+
+	return m.execute(session, migration.down, migration.downFunc, query, migration.version)
+}
+
+// execute runs the script or the function and the statement of the tracking table in a transaction.
+// MySQL commits the DDL statements implicitly, so the scripts run without a transaction there.
+func (m *Migrator) execute(session DBCommandInterface, script string, fn MigrationFunc, record string, params ...interface{}) error {
+	if fn == nil && session.GetDialect() == DialectMySQL {
+		_, err := session.ExecuteScript(strings.NewReader(script))
+		if err != nil {
+			return err
+		}
+
+		_, err = session.Execute(record, params...)
+		return err
+	}
+
+	tx, err := session.Begin()
+	if err != nil {
+		return err
+	}
+
+	if fn != nil {
+		err = fn(tx)
+	} else {
+		_, err = tx.ExecuteScript(strings.NewReader(script))
+	}
+
+	if err == nil {
+		_, err = tx.Execute(record, params...)
+	}
+
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (m *Migrator) find(version int64) *Migration {
+	for i := range m.migrations {
+		if m.migrations[i].version == version {
+			return &m.migrations[i]
+		}
+	}
+
+	return nil
+}
+
+// revertible returns the migration of the applied version when it has a down script.
+func (m *Migrator) revertible(version int64) (*Migration, error) {
+	migration := m.find(version)
+	if migration == nil || !migration.hasDown() {
+		return nil, db_errors.MigrationMissingDownError()
+	}
+
+	return migration, nil
+}
+
+// verify checks the applied SQL migrations against their recorded checksums.
+func (m *Migrator) verify(applied map[int64]MigrationStatus) error {
+	for i := range m.migrations {
+		status, ok := applied[m.migrations[i].version]
+		if ok && isModified(&m.migrations[i], status) {
+			return db_errors.MigrationChecksumMismatchError()
+		}
+	}
+
+	return nil
+}
+
+// isModified returns whether the applied migration has a checksum which differs from the recorded one.
+// The migrations recorded without a checksum are not checked.
+func isModified(migration *Migration, status MigrationStatus) bool {
+	return status.applied && status.checksum != "" && migration.checksum != "" && status.checksum != migration.checksum
+}
+
+func appliedVersions(applied map[int64]MigrationStatus) []int64 {
+	versions := make([]int64, 0, len(applied))
+	for version := range applied {
+		versions = append(versions, version)
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i] < versions[j]
+	})
+
+	return versions
+}
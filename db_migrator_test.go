@@ -0,0 +1,485 @@
+package db
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	db_errors "github.com/fatihtatoglu/db-go/error"
+	db_mock "github.com/fatihtatoglu/db-go/mock"
+)
+
+var testMigrations = fstest.MapFS{
+	"0001_create_users.up.sql":     {Data: []byte("CREATE TABLE users (id INT)")},
+	"0001_create_users.down.sql":   {Data: []byte("DROP TABLE users")},
+	"0002_add_email.up.sql":        {Data: []byte("ALTER TABLE users ADD email VARCHAR(255)")},
+	"0002_add_email.down.sql":      {Data: []byte("ALTER TABLE users DROP COLUMN email")},
+	"0003_seed_roles.up.sql":       {Data: []byte("INSERT INTO roles (name) VALUES ('admin')")},
+	"README.md":                    {Data: []byte("migrations")},
+	"testdata/0009_skipped.up.sql": {Data: []byte("SELECT 1")},
+}
+
+func createTestMigrationTable(versions ...int64) *DBTable {
+	rows := make([][]interface{}, 0, len(versions))
+	for _, version := range versions {
+		rows = append(rows, []interface{}{version, "migration", nil, "2024-01-02 03:04:05"})
+	}
+
+	return createTestTable([]string{"version", "name", "checksum", "applied_at"}, rows...)
+}
+
+func TestLoadMigrations(t *testing.T) {
+	// Act
+	migrations, err := LoadMigrations(testMigrations)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []Migration{
+		CreateNewMigration(1, "create_users", "CREATE TABLE users (id INT)", "DROP TABLE users"),
+		CreateNewMigration(2, "add_email", "ALTER TABLE users ADD email VARCHAR(255)", "ALTER TABLE users DROP COLUMN email"),
+		CreateNewMigration(3, "seed_roles", "INSERT INTO roles (name) VALUES ('admin')", ""),
+	}
+
+	if !reflect.DeepEqual(migrations, expected) {
+		t.Errorf("Expected: %+v, got: %+v", expected, migrations)
+	}
+}
+
+func TestLoadMigrations_Errors(t *testing.T) {
+	// Test cases
+	testCases := []struct {
+		name          string
+		fsys          fstest.MapFS
+		expectedError string
+	}{
+		{
+			name:          "InvalidFileName",
+			fsys:          fstest.MapFS{"create_users.sql": {Data: []byte("SELECT 1")}},
+			expectedError: db_errors.Migration_InvalidFileNameErrorMessage,
+		},
+		{
+			name: "DuplicateVersion",
+			fsys: fstest.MapFS{
+				"0001_create_users.up.sql": {Data: []byte("SELECT 1")},
+				"0001_create_roles.up.sql": {Data: []byte("SELECT 2")},
+			},
+			expectedError: db_errors.Migration_DuplicateVersionErrorMessage,
+		},
+		{
+			name:          "MissingUp",
+			fsys:          fstest.MapFS{"0001_create_users.down.sql": {Data: []byte("DROP TABLE users")}},
+			expectedError: db_errors.Migration_MissingUpErrorMessage,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			_, err := LoadMigrations(tc.fsys)
+
+			// Assert
+			assertError(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestCreateNewMigrator_NilCommand(t *testing.T) {
+	// Act
+	_, err := CreateNewMigrator(nil, testMigrations)
+
+	// Assert
+	assertError(t, err, db_errors.Migration_NilCommandErrorMessage)
+}
+
+func TestMigrator_Up(t *testing.T) {
+	// Arrange
+	command := &recordingCommand{
+		dialect: DialectSQLite,
+		tables:  []*DBTable{createTestMigrationTable(1)},
+	}
+	sut, _ := CreateNewMigrator(command, testMigrations)
+
+	// Act
+	err := sut.Up()
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expectedQueries := []string{
+		`CREATE TABLE IF NOT EXISTS "dbgo_schema_migrations" ("version" BIGINT NOT NULL PRIMARY KEY, "name" VARCHAR(255) NOT NULL, "checksum" VARCHAR(64) NULL, "applied_at" TIMESTAMP NOT NULL)`,
+		`SELECT "version", "name", "checksum", "applied_at" FROM "dbgo_schema_migrations"`,
+		"ALTER TABLE users ADD email VARCHAR(255)",
+		`INSERT INTO "dbgo_schema_migrations" ("version", "name", "checksum", "applied_at") VALUES (?, ?, ?, ?)`,
+		"INSERT INTO roles (name) VALUES ('admin')",
+		`INSERT INTO "dbgo_schema_migrations" ("version", "name", "checksum", "applied_at") VALUES (?, ?, ?, ?)`,
+	}
+	if !reflect.DeepEqual(command.queries, expectedQueries) {
+		t.Errorf("Expected the queries:\n%v\ngot:\n%v", strings.Join(expectedQueries, "\n"), strings.Join(command.queries, "\n"))
+	}
+
+	if !reflect.DeepEqual(command.events, []string{"begin", "commit", "begin", "commit"}) {
+		t.Errorf("Expected every migration to run in a transaction, got: %v", command.events)
+	}
+
+	if args := command.args[3]; args[0] != int64(2) || args[1] != "add_email" || args[2] != migrationChecksum("ALTER TABLE users ADD email VARCHAR(255)") {
+		t.Errorf("Expected the version, the name and the checksum to be recorded, got: %v", args)
+	}
+}
+
+func TestMigrator_Up_MySQL(t *testing.T) {
+	// Arrange
+	command := &recordingCommand{
+		dialect: DialectMySQL,
+		tables: []*DBTable{
+			createTestTable([]string{"lock"}, []interface{}{int64(1)}),
+			createTestMigrationTable(1, 2),
+		},
+	}
+	sut, _ := CreateNewMigrator(command, testMigrations, WithMigrationTable("schema_versions"), WithMigrationLockName("shop"))
+
+	// Act
+	err := sut.Up()
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if command.queries[0] != "SELECT GET_LOCK(?, -1)" || command.args[0][0] != "shop" {
+		t.Errorf("Expected the named lock to be taken first, got: %s %v", command.queries[0], command.args[0])
+	}
+
+	if !strings.HasPrefix(command.queries[1], "CREATE TABLE IF NOT EXISTS `schema_versions` (") || !strings.Contains(command.queries[1], "DATETIME(6)") {
+		t.Errorf("Expected the tracking table to be created, got: %s", command.queries[1])
+	}
+
+	last := len(command.queries) - 1
+	if command.queries[last] != "SELECT RELEASE_LOCK(?)" {
+		t.Errorf("Expected the lock to be released last, got: %s", command.queries[last])
+	}
+
+	if len(command.events) != 0 {
+		t.Errorf("Expected no transactions on MySQL, got: %v", command.events)
+	}
+}
+
+func TestMigrator_UnlockFailed_Discards_Connection(t *testing.T) {
+	// Arrange
+	command, mock := createTestMockCommand(t, DialectMySQL)
+	connection := command.(*dbCommand).connection
+	connection.Open()
+	defer connection.Close()
+
+	mock.ExpectQuery("SELECT GET_LOCK(?, -1)").WithArgs("dbgo_schema_migrations").WillReturnRows(db_mock.CreateNewRows("lock").AddRow(int64(1)))
+	mock.ExpectExecRegexp("^CREATE TABLE IF NOT EXISTS `dbgo_schema_migrations`").WillReturnResult(0, 0)
+	mock.ExpectQuery("SELECT `version`, `name`, `checksum`, `applied_at` FROM `dbgo_schema_migrations`").
+		WillReturnRows(db_mock.CreateNewRows("version", "name", "checksum", "applied_at"))
+	mock.ExpectExec("SELECT RELEASE_LOCK(?)").WillReturnError(errors.New("connection reset"))
+
+	sut, _ := CreateNewMigrator(command, testMigrations)
+
+	// Act
+	_, err := sut.Status()
+
+	// Assert
+	assertError(t, err, "connection reset")
+
+	if open := connection.getConnection().Stats().OpenConnections; open != 0 {
+		t.Errorf("Expected the connection which may hold the lock to be closed, got open connections: %d", open)
+	}
+
+	assertExpectationsWereMet(t, mock)
+}
+
+func TestMigrator_LockFailed(t *testing.T) {
+	// Arrange
+	command := &recordingCommand{
+		dialect: DialectSQLServer,
+		tables:  []*DBTable{createTestTable([]string{"result"}, []interface{}{int64(0)})},
+	}
+	sut, _ := CreateNewMigrator(command, testMigrations)
+
+	// Act
+	err := sut.Up()
+
+	// Assert
+	assertError(t, err, db_errors.Migration_LockFailedErrorMessage)
+
+	if len(command.queries) != 1 || !strings.Contains(command.queries[0], "sp_getapplock @Resource = @p1") {
+		t.Errorf("Expected only the lock query, got: %v", command.queries)
+	}
+}
+
+func TestMigrator_Down(t *testing.T) {
+	// Arrange
+	command := &recordingCommand{
+		dialect: DialectPostgres,
+		tables: []*DBTable{
+			createTestTable([]string{"locked"}, []interface{}{int64(1)}),
+			createTestMigrationTable(1, 2),
+		},
+	}
+	sut, _ := CreateNewMigrator(command, testMigrations)
+
+	// Act
+	err := sut.Down()
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expectedQueries := []string{
+		"SELECT 1 FROM (SELECT pg_advisory_lock($1)) l",
+		`CREATE TABLE IF NOT EXISTS "dbgo_schema_migrations" ("version" BIGINT NOT NULL PRIMARY KEY, "name" VARCHAR(255) NOT NULL, "checksum" VARCHAR(64) NULL, "applied_at" TIMESTAMP NOT NULL)`,
+		`SELECT "version", "name", "checksum", "applied_at" FROM "dbgo_schema_migrations"`,
+		"ALTER TABLE users DROP COLUMN email",
+		`DELETE FROM "dbgo_schema_migrations" WHERE "version" = $1`,
+		"SELECT pg_advisory_unlock($1)",
+	}
+	if !reflect.DeepEqual(command.queries, expectedQueries) {
+		t.Errorf("Expected the queries:\n%v\ngot:\n%v", strings.Join(expectedQueries, "\n"), strings.Join(command.queries, "\n"))
+	}
+
+	if command.args[0][0] != command.args[5][0] {
+		t.Errorf("Expected the same lock key to be locked and unlocked, got: %v and %v", command.args[0][0], command.args[5][0])
+	}
+}
+
+func TestMigrator_Down_MissingDown(t *testing.T) {
+	// Arrange
+	command := &recordingCommand{
+		dialect: DialectSQLite,
+		tables:  []*DBTable{createTestMigrationTable(1, 2, 3)},
+	}
+	sut, _ := CreateNewMigrator(command, testMigrations)
+
+	// Act
+	err := sut.Down()
+
+	// Assert
+	assertError(t, err, db_errors.Migration_MissingDownErrorMessage)
+
+	if len(command.events) != 0 {
+		t.Errorf("Expected no migration to run, got: %v", command.events)
+	}
+}
+
+func TestMigrator_To(t *testing.T) {
+	// Test cases
+	testCases := []struct {
+		name            string
+		applied         []int64
+		version         int64
+		expectedScripts []string
+	}{
+		{
+			name:            "Forward",
+			applied:         []int64{},
+			version:         2,
+			expectedScripts: []string{"CREATE TABLE users (id INT)", "ALTER TABLE users ADD email VARCHAR(255)"},
+		},
+		{
+			name:            "Backward",
+			applied:         []int64{1, 2},
+			version:         0,
+			expectedScripts: []string{"ALTER TABLE users DROP COLUMN email", "DROP TABLE users"},
+		},
+		{
+			name:            "Current",
+			applied:         []int64{1},
+			version:         1,
+			expectedScripts: []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			command := &recordingCommand{
+				dialect: DialectSQLite,
+				tables:  []*DBTable{createTestMigrationTable(tc.applied...)},
+			}
+			sut, _ := CreateNewMigrator(command, testMigrations)
+
+			// Act
+			err := sut.To(tc.version)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			scripts := make([]string, 0)
+			for i := 2; i < len(command.queries); i += 2 {
+				scripts = append(scripts, command.queries[i])
+			}
+
+			if !reflect.DeepEqual(scripts, tc.expectedScripts) {
+				t.Errorf("Expected the scripts: %v, got: %v", tc.expectedScripts, scripts)
+			}
+		})
+	}
+}
+
+func TestMigrator_To_VersionNotFound(t *testing.T) {
+	// Arrange
+	command := &recordingCommand{dialect: DialectSQLite}
+	sut, _ := CreateNewMigrator(command, testMigrations)
+
+	// Act
+	err := sut.To(99)
+
+	// Assert
+	assertError(t, err, db_errors.Migration_VersionNotFoundErrorMessage)
+
+	if len(command.queries) != 0 {
+		t.Errorf("Expected no queries, got: %v", command.queries)
+	}
+}
+
+func TestMigrator_Status(t *testing.T) {
+	// Arrange
+	command := &recordingCommand{
+		dialect: DialectSQLite,
+		tables:  []*DBTable{createTestMigrationTable(1, 5)},
+	}
+	sut, _ := CreateNewMigrator(command, testMigrations)
+
+	// Act
+	statuses, err := sut.Status()
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	appliedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	expected := []MigrationStatus{
+		{version: 1, name: "create_users", applied: true, appliedAt: appliedAt},
+		{version: 2, name: "add_email"},
+		{version: 3, name: "seed_roles"},
+		{version: 5, name: "migration", applied: true, appliedAt: appliedAt},
+	}
+
+	if !reflect.DeepEqual(statuses, expected) {
+		t.Errorf("Expected: %+v, got: %+v", expected, statuses)
+	}
+}
+
+func TestMigrator_GoMigration(t *testing.T) {
+	// Arrange
+	var backfill DBCommandInterface
+	command := &recordingCommand{
+		dialect: DialectMySQL,
+		tables: []*DBTable{
+			createTestTable([]string{"lock"}, []interface{}{int64(1)}),
+			createTestMigrationTable(1, 2, 3),
+		},
+	}
+	sut, _ := CreateNewMigrator(command, testMigrations, WithGoMigration(4, "backfill_names", func(tx DBCommandInterface) error {
+		backfill = tx
+		_, err := tx.Execute("UPDATE users SET name = ?", "unknown")
+		return err
+	}, nil))
+
+	// Act
+	err := sut.To(4)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, ok := backfill.(DBTransactionInterface); !ok {
+		t.Errorf("Expected the Go migration to receive a transaction, got: %T", backfill)
+	}
+
+	if !reflect.DeepEqual(command.events, []string{"begin", "commit"}) {
+		t.Errorf("Expected the Go migration to run in a transaction on MySQL, got: %v", command.events)
+	}
+
+	insert := command.args[4]
+	if command.queries[3] != "UPDATE users SET name = ?" || insert[0] != int64(4) || insert[2] != nil {
+		t.Errorf("Expected the Go migration to be recorded without a checksum, got: %v %v", command.queries, insert)
+	}
+}
+
+func TestMigrator_GoMigration_Errors(t *testing.T) {
+	// Arrange
+	noop := func(tx DBCommandInterface) error { return nil }
+
+	// Act
+	_, duplicateErr := CreateNewMigrator(&recordingCommand{}, testMigrations, WithGoMigration(1, "backfill", noop, nil))
+	_, missingUpErr := CreateNewMigrator(&recordingCommand{}, testMigrations, WithGoMigration(4, "backfill", nil, noop))
+
+	// Assert
+	assertError(t, duplicateErr, db_errors.Migration_DuplicateVersionErrorMessage)
+	assertError(t, missingUpErr, db_errors.Migration_MissingUpErrorMessage)
+}
+
+func TestMigrator_Down_GoMigrationWithoutDown(t *testing.T) {
+	// Arrange
+	command := &recordingCommand{
+		dialect: DialectSQLite,
+		tables:  []*DBTable{createTestMigrationTable(1, 2, 4)},
+	}
+	sut, _ := CreateNewMigrator(command, testMigrations, WithGoMigration(4, "backfill", func(tx DBCommandInterface) error { return nil }, nil))
+
+	// Act
+	err := sut.Down()
+
+	// Assert
+	assertError(t, err, db_errors.Migration_MissingDownErrorMessage)
+}
+
+func TestMigrator_ChecksumMismatch(t *testing.T) {
+	// Arrange
+	applied := createTestTable([]string{"version", "name", "checksum", "applied_at"},
+		[]interface{}{int64(1), "create_users", migrationChecksum("CREATE TABLE users (id INT)"), "2024-01-02 03:04:05"},
+		[]interface{}{int64(2), "add_email", migrationChecksum("ALTER TABLE users ADD email TEXT"), "2024-01-02 03:04:05"},
+	)
+	command := &recordingCommand{
+		dialect: DialectSQLite,
+		tables:  []*DBTable{applied, applied},
+	}
+	sut, _ := CreateNewMigrator(command, testMigrations)
+
+	// Act
+	upErr := sut.Up()
+	statuses, statusErr := sut.Status()
+
+	// Assert
+	assertError(t, upErr, db_errors.Migration_ChecksumMismatchErrorMessage)
+
+	if len(command.events) != 0 {
+		t.Errorf("Expected no migration to run, got: %v", command.events)
+	}
+
+	if statusErr != nil {
+		t.Fatalf("Unexpected error: %v", statusErr)
+	}
+
+	if statuses[0].GetModified() || !statuses[1].GetModified() || statuses[2].GetModified() {
+		t.Errorf("Expected only the edited migration to be modified, got: %+v", statuses)
+	}
+}
+
+func TestMigrationChecksum_LineEndings(t *testing.T) {
+	// Act
+	lf := migrationChecksum("CREATE TABLE users (\n  id INT\n)")
+	crlf := migrationChecksum("CREATE TABLE users (\r\n  id INT\r\n)")
+
+	// Assert
+	if lf != crlf || len(lf) != 64 {
+		t.Errorf("Expected the same SHA-256 checksum for both line endings, got: %s and %s", lf, crlf)
+	}
+}
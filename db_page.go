@@ -0,0 +1,118 @@
+package db
+
+import (
+	db_criteria "github.com/fatihtatoglu/db-go/criteria"
+	db_errors "github.com/fatihtatoglu/db-go/error"
+)
+
+// pageTotalCountColumn is the alias of the window function column which carries the total count.
+const pageTotalCountColumn = "dbgo_total_count"
+
+// Page is a numbered page of entities with the total count of the records.
+type Page[T any] struct {
+	items      []T
+	page       int
+	size       int
+	totalCount int64
+}
+
+func (p *Page[T]) GetItems() []T {
+	return p.items
+}
+
+// GetPage returns the 1-based page number.
+func (p *Page[T]) GetPage() int {
+	return p.page
+}
+
+func (p *Page[T]) GetSize() int {
+	return p.size
+}
+
+func (p *Page[T]) GetTotalCount() int64 {
+	return p.totalCount
+}
+
+func (p *Page[T]) GetPageCount() int {
+	return int((p.totalCount + int64(p.size) - 1) / int64(p.size))
+}
+
+func (p *Page[T]) HasNext() bool {
+	return p.page < p.GetPageCount()
+}
+
+func (p *Page[T]) HasPrevious() bool {
+	return p.page > 1
+}
+
+func (r *dbRepository[T, K]) FetchPage(page, size int, sort ...db_criteria.Sort) (*Page[T], error) {
+	if page < 1 || size < 1 {
+		return nil, db_errors.RepositoryInvalidPageError()
+	}
+
+	sort, _, err := r.stableSort(sort)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Page[T]{
+		items: make([]T, 0),
+		page:  page,
+		size:  size,
+	}
+	offset := (page - 1) * size
+
+	if r.options.windowCount {
+		countColumn := "COUNT(*) OVER() AS " + r.dialect().QuoteIdentifier(pageTotalCountColumn)
+		dt, err := r.selectBuilder(countColumn).OrderBySort(sort...).Limit(size).Offset(offset).Query(r.command)
+		if err != nil {
+			return nil, err
+		}
+
+		// A page past the end has no rows to carry the count, the count query is used instead.
+		if len(dt.rows) > 0 {
+			result.items, err = r.mapRows(dt)
+			if err != nil {
+				return nil, err
+			}
+
+			result.totalCount, err = readTotalCount(dt)
+			return result, err
+		}
+
+		result.totalCount, err = r.Count(nil)
+		return result, err
+	}
+
+	result.totalCount, err = r.Count(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(offset) >= result.totalCount {
+		return result, nil
+	}
+
+	dt, err := r.selectBuilder().OrderBySort(sort...).Limit(size).Offset(offset).Query(r.command)
+	if err != nil {
+		return nil, err
+	}
+
+	result.items, err = r.mapRows(dt)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func readTotalCount(dt *DBTable) (int64, error) {
+	var count int64
+	for i, column := range dt.columns {
+		if column.name == pageTotalCountColumn {
+			return count, assignValue(&count, dt.rows[0].itemArray[i])
+		}
+	}
+
+	return count, db_errors.ColumnNotFoundError()
+}
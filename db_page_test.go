@@ -0,0 +1,130 @@
+package db
+
+import (
+	"testing"
+
+	db_criteria "github.com/fatihtatoglu/db-go/criteria"
+	db_errors "github.com/fatihtatoglu/db-go/error"
+)
+
+func TestPage(t *testing.T) {
+	// Test cases
+	testCases := []struct {
+		testName            string
+		page                Page[testUser]
+		expectedPageCount   int
+		expectedHasNext     bool
+		expectedHasPrevious bool
+	}{
+		{"Empty", Page[testUser]{page: 1, size: 10}, 0, false, false},
+		{"First page", Page[testUser]{page: 1, size: 10, totalCount: 25}, 3, true, false},
+		{"Middle page", Page[testUser]{page: 2, size: 10, totalCount: 25}, 3, true, true},
+		{"Last page", Page[testUser]{page: 3, size: 10, totalCount: 30}, 3, false, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			// Act & Assert
+			if tc.page.GetPageCount() != tc.expectedPageCount {
+				t.Errorf("Expected page count: %d, got: %d", tc.expectedPageCount, tc.page.GetPageCount())
+			}
+
+			if tc.page.HasNext() != tc.expectedHasNext {
+				t.Errorf("Expected HasNext: %v", tc.expectedHasNext)
+			}
+
+			if tc.page.HasPrevious() != tc.expectedHasPrevious {
+				t.Errorf("Expected HasPrevious: %v", tc.expectedHasPrevious)
+			}
+		})
+	}
+}
+
+func TestRepository_FetchPage(t *testing.T) {
+	// Arrange
+	command := &recordingCommand{
+		dialect: DialectMySQL,
+		table:   createTestTable([]string{"COUNT(*)"}, []interface{}{int64(3)}),
+	}
+	sut, _ := CreateNewRepository[testUser, int64](command, "users")
+
+	// Act
+	page, err := sut.FetchPage(2, 2, db_criteria.Asc("first_name"))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if page.GetTotalCount() != 3 || page.GetPageCount() != 2 {
+		t.Errorf("Unexpected total count: %d", page.GetTotalCount())
+	}
+
+	expectedQuery := "SELECT `created_at`, `id`, `first_name`, `age` FROM `users` ORDER BY `first_name` ASC, `id` ASC LIMIT ? OFFSET ?"
+	if len(command.queries) != 2 || command.queries[1] != expectedQuery {
+		t.Fatalf("Expected query: %s, got: %v", expectedQuery, command.queries)
+	}
+}
+
+func TestRepository_FetchPage_PastTheEnd(t *testing.T) {
+	// Arrange
+	command := &recordingCommand{
+		dialect: DialectMySQL,
+		table:   createTestTable([]string{"COUNT(*)"}, []interface{}{int64(3)}),
+	}
+	sut, _ := CreateNewRepository[testUser, int64](command, "users")
+
+	// Act
+	page, err := sut.FetchPage(5, 2)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(command.queries) != 1 || len(page.GetItems()) != 0 {
+		t.Errorf("Expected only the count query, got: %v", command.queries)
+	}
+}
+
+func TestRepository_FetchPage_WindowCount(t *testing.T) {
+	// Arrange
+	command := &recordingCommand{
+		dialect: DialectPostgres,
+		table: createTestTable([]string{"id", "first_name", pageTotalCountColumn},
+			[]interface{}{int64(1), "Fatih", int64(7)},
+			[]interface{}{int64(2), "Ali", int64(7)},
+		),
+	}
+	sut, _ := CreateNewRepository[testUser, int64](command, "users", WithWindowCount())
+
+	// Act
+	page, err := sut.FetchPage(1, 2)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expectedQuery := `SELECT "created_at", "id", "first_name", "age", COUNT(*) OVER() AS "dbgo_total_count" FROM "users" ORDER BY "id" ASC LIMIT $1 OFFSET $2`
+	if len(command.queries) != 1 || command.queries[0] != expectedQuery {
+		t.Fatalf("Expected query: %s, got: %v", expectedQuery, command.queries)
+	}
+
+	if page.GetTotalCount() != 7 || len(page.GetItems()) != 2 || !page.HasNext() {
+		t.Errorf("Unexpected page: %d items of %d", len(page.GetItems()), page.GetTotalCount())
+	}
+}
+
+func TestRepository_FetchPage_InvalidPage(t *testing.T) {
+	// Arrange
+	sut, _ := CreateNewRepository[testUser, int64](&recordingCommand{dialect: DialectMySQL}, "users")
+
+	// Act
+	_, zeroPageErr := sut.FetchPage(0, 10)
+	_, zeroSizeErr := sut.FetchPage(1, 0)
+
+	// Assert
+	assertError(t, zeroPageErr, db_errors.Repository_InvalidPageErrorMessage)
+	assertError(t, zeroSizeErr, db_errors.Repository_InvalidPageErrorMessage)
+}
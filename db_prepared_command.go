@@ -0,0 +1,85 @@
+package db
+
+import (
+	"database/sql"
+)
+
+// DBPreparedCommandInterface is a prepared statement which can be executed many times
+// with different parameters. It must be closed when it is no longer used.
+type DBPreparedCommandInterface interface {
+	Execute(params ...interface{}) (*sql.Result, error)
+	Query(params ...interface{}) (*DBTable, error)
+	QueryFirst(params ...interface{}) (*DBRow, error)
+	Close() error
+}
+
+type dbPreparedCommand struct {
+	command *dbCommand
+	stmt    *sql.Stmt
+}
+
+// Prepare prepares the query on the connection, or on the transaction or the pinned connection of the command.
+// The connection is kept open until the prepared command is closed.
+func (cmd *dbCommand) Prepare(query string) (DBPreparedCommandInterface, error) {
+	err := cmd.open()
+	if err != nil {
+		return nil, err
+	}
+
+	var stmt *sql.Stmt
+	if cmd.tx != nil {
+		stmt, err = cmd.tx.PrepareContext(cmd.ctx, query)
+	} else if cmd.conn != nil {
+		stmt, err = cmd.conn.PrepareContext(cmd.ctx, query)
+	} else {
+		stmt, err = cmd.connection.getConnection().PrepareContext(cmd.ctx, query)
+	}
+
+	if err != nil {
+		cmd.close()
+		return nil, err
+	}
+
+	return &dbPreparedCommand{
+		command: cmd,
+		stmt:    stmt,
+	}, nil
+}
+
+func (pc *dbPreparedCommand) Execute(params ...interface{}) (*sql.Result, error) {
+	res, err := pc.stmt.ExecContext(pc.command.ctx, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (pc *dbPreparedCommand) Query(params ...interface{}) (*DBTable, error) {
+	return pc.query(false, params...)
+}
+
+func (pc *dbPreparedCommand) QueryFirst(params ...interface{}) (*DBRow, error) {
+	resultSet, err := pc.query(true, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resultSet.rows[0], nil
+}
+
+func (pc *dbPreparedCommand) Close() error {
+	defer pc.command.close()
+
+	return pc.stmt.Close()
+}
+
+func (pc *dbPreparedCommand) query(returnSingleRow bool, params ...interface{}) (*DBTable, error) {
+	rows, err := pc.stmt.QueryContext(pc.command.ctx, params...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return readTable(rows, returnSingleRow)
+}
@@ -0,0 +1,309 @@
+package db
+
+import (
+	"database/sql"
+	"reflect"
+	"strconv"
+	"strings"
+
+	db_errors "github.com/fatihtatoglu/db-go/error"
+)
+
+// ParameterDirection defines how a stored procedure parameter is passed.
+type ParameterDirection int
+
+const (
+	ParameterIn ParameterDirection = iota
+	ParameterOut
+	ParameterInOut
+)
+
+// ProcedureParameter is a stored procedure argument created by In, Out or InOut.
+type ProcedureParameter struct {
+	name      string
+	value     interface{}
+	direction ParameterDirection
+}
+
+// In creates an input parameter with the given value.
+func In(name string, value interface{}) ProcedureParameter {
+	return ProcedureParameter{
+		name:      name,
+		value:     value,
+		direction: ParameterIn,
+	}
+}
+
+// Out creates an output parameter. The dest must be a pointer which receives the output value.
+func Out(name string, dest interface{}) ProcedureParameter {
+	return ProcedureParameter{
+		name:      name,
+		value:     sql.Out{Dest: dest},
+		direction: ParameterOut,
+	}
+}
+
+// InOut creates an input/output parameter. The value pointed by dest is sent to the procedure
+// and replaced by the output value.
+func InOut(name string, dest interface{}) ProcedureParameter {
+	return ProcedureParameter{
+		name:      name,
+		value:     sql.Out{Dest: dest, In: true},
+		direction: ParameterInOut,
+	}
+}
+
+func (p *ProcedureParameter) GetName() string {
+	return p.name
+}
+
+func (p *ProcedureParameter) GetDirection() ParameterDirection {
+	return p.direction
+}
+
+func (p *ProcedureParameter) isOutput() bool {
+	return p.direction != ParameterIn
+}
+
+func (p *ProcedureParameter) inputValue() interface{} {
+	out, ok := p.value.(sql.Out)
+	if !ok {
+		return p.value
+	}
+
+	dv := reflect.ValueOf(out.Dest)
+	if dv.Kind() != reflect.Pointer || dv.IsNil() {
+		return nil
+	}
+
+	return dv.Elem().Interface()
+}
+
+// ProcedureResult holds the output parameter values and the result sets returned by a stored procedure.
+type ProcedureResult struct {
+	outputs    map[string]interface{}
+	resultSets []*DBTable
+}
+
+func (r *ProcedureResult) GetOutput(name string) (interface{}, bool) {
+	value, ok := r.outputs[name]
+	return value, ok
+}
+
+func (r *ProcedureResult) GetOutputs() map[string]interface{} {
+	return r.outputs
+}
+
+func (r *ProcedureResult) GetResultSets() []*DBTable {
+	return r.resultSets
+}
+
+// procedureCall is the dialect specific statement plan of a stored procedure invocation.
+//
+// setup statements run before the call and fetch reads the output values afterwards, both on
+// the same session; they are only needed by dialects that emulate output parameters with variables.
+type procedureCall struct {
+	setup     []string
+	setupArgs [][]interface{}
+	query     string
+	args      []interface{}
+	fetch     string
+}
+
+func buildProcedureCall(dialect Dialect, name string, params []ProcedureParameter) (*procedureCall, error) {
+	if name == "" {
+		return nil, db_errors.ProcedureEmptyNameError()
+	}
+
+	call := &procedureCall{}
+	placeholders := make([]string, 0, len(params))
+
+	switch dialect {
+	case DialectSQLite:
+		return nil, db_errors.ProcedureNotSupportedError()
+
+	case DialectSQLServer:
+		for _, p := range params {
+			if p.name == "" {
+				return nil, db_errors.ProcedureEmptyParameterNameError()
+			}
+
+			placeholder := "@" + p.name + " = @" + p.name
+			if p.isOutput() {
+				placeholder += " OUTPUT"
+			}
+
+			placeholders = append(placeholders, placeholder)
+			call.args = append(call.args, sql.Named(p.name, p.value))
+		}
+
+		call.query = "EXEC " + name
+		if len(placeholders) > 0 {
+			call.query += " " + strings.Join(placeholders, ", ")
+		}
+
+		return call, nil
+
+	case DialectMySQL:
+		variables := make([]string, 0)
+		for _, p := range params {
+			if !p.isOutput() {
+				call.args = append(call.args, p.value)
+				placeholders = append(placeholders, "?")
+				continue
+			}
+
+			variable := "@dbgo_out" + strconv.Itoa(len(variables)+1)
+			if p.direction == ParameterInOut {
+				call.setup = append(call.setup, "SET "+variable+" = ?")
+				call.setupArgs = append(call.setupArgs, []interface{}{p.inputValue()})
+			}
+
+			variables = append(variables, variable)
+			placeholders = append(placeholders, variable)
+		}
+
+		if len(variables) > 0 {
+			call.fetch = "SELECT " + strings.Join(variables, ", ")
+		}
+
+	case DialectPostgres:
+		for _, p := range params {
+			if p.direction == ParameterOut {
+				placeholders = append(placeholders, "NULL")
+				continue
+			}
+
+			call.args = append(call.args, p.inputValue())
+			placeholders = append(placeholders, dialect.Placeholder(len(call.args)))
+		}
+
+	default:
+		for _, p := range params {
+			call.args = append(call.args, p.value)
+			placeholders = append(placeholders, dialect.Placeholder(len(call.args)))
+		}
+	}
+
+	call.query = "CALL " + name + "(" + strings.Join(placeholders, ", ") + ")"
+	return call, nil
+}
+
+func (cmd *dbCommand) CallProcedure(name string, params ...ProcedureParameter) (*ProcedureResult, error) {
+	dialect := cmd.connection.GetDialect()
+
+	call, err := buildProcedureCall(dialect, name, params)
+	if err != nil {
+		return nil, err
+	}
+
+	err = cmd.open()
+	if err != nil {
+		return nil, err
+	}
+	defer cmd.close()
+
+	// The setup, call and fetch statements must share the session variables.
+	conn := cmd.executor()
+	if cmd.tx == nil && cmd.conn == nil {
+		c, err := cmd.connection.getConnection().Conn(cmd.ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer c.Close()
+
+		conn = c
+	}
+
+	for i, statement := range call.setup {
+		_, err = conn.ExecContext(cmd.ctx, statement, call.setupArgs[i]...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rows, err := conn.QueryContext(cmd.ctx, call.query, call.args...)
+	if err != nil {
+		return nil, err
+	}
+
+	resultSets, err := readResultSets(rows)
+	rows.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	outputs := make([]interface{}, 0)
+	switch {
+	case call.fetch != "":
+		fetchRows, err := conn.QueryContext(cmd.ctx, call.fetch)
+		if err != nil {
+			return nil, err
+		}
+
+		fetched, err := readTable(fetchRows, true)
+		fetchRows.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		if len(fetched.rows) > 0 {
+			outputs = fetched.rows[0].itemArray
+		}
+
+	case dialect == DialectPostgres && hasOutputParameter(params):
+		// PostgreSQL returns the output parameters as the first row of the call.
+		if len(resultSets) > 0 {
+			if len(resultSets[0].rows) > 0 {
+				outputs = resultSets[0].rows[0].itemArray
+			}
+
+			resultSets = resultSets[1:]
+		}
+
+	default:
+		// The driver has already written the values into the sql.Out destinations.
+		for _, p := range params {
+			if p.isOutput() {
+				outputs = append(outputs, p.inputValue())
+			}
+		}
+	}
+
+	result := &ProcedureResult{
+		outputs:    make(map[string]interface{}),
+		resultSets: resultSets,
+	}
+
+	index := 0
+	for _, p := range params {
+		if !p.isOutput() {
+			continue
+		}
+
+		var value interface{}
+		if index < len(outputs) {
+			value = outputs[index]
+		}
+		index++
+
+		err = assignValue(p.value.(sql.Out).Dest, value)
+		if err != nil {
+			return nil, err
+		}
+
+		result.outputs[p.name] = p.inputValue()
+	}
+
+	return result, nil
+}
+
+func hasOutputParameter(params []ProcedureParameter) bool {
+	for _, p := range params {
+		if p.isOutput() {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,172 @@
+package db
+
+import (
+	"database/sql"
+	"testing"
+
+	db_errors "github.com/fatihtatoglu/db-go/error"
+	db_mock "github.com/fatihtatoglu/db-go/mock"
+)
+
+func TestBuildProcedureCall(t *testing.T) {
+	// Test cases
+	testCases := []struct {
+		testName      string
+		dialect       Dialect
+		expectedQuery string
+		expectedSetup []string
+		expectedFetch string
+		expectedArgs  int
+	}{
+		{"MySQL", DialectMySQL, "CALL get_user(?, @dbgo_out1, @dbgo_out2)", []string{"SET @dbgo_out2 = ?"}, "SELECT @dbgo_out1, @dbgo_out2", 1},
+		{"PostgreSQL", DialectPostgres, "CALL get_user($1, NULL, $2)", nil, "", 2},
+		{"SQL Server", DialectSQLServer, "EXEC get_user @id = @id, @name = @name OUTPUT, @counter = @counter OUTPUT", nil, "", 3},
+		{"Unknown", DialectUnknown, "CALL get_user(?, ?, ?)", nil, "", 3},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			// Arrange
+			var name string
+			counter := 5
+			params := []ProcedureParameter{In("id", 1), Out("name", &name), InOut("counter", &counter)}
+
+			// Act
+			call, err := buildProcedureCall(tc.dialect, "get_user", params)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if call.query != tc.expectedQuery {
+				t.Errorf("Expected query: %s, got: %s", tc.expectedQuery, call.query)
+			}
+
+			if len(call.setup) != len(tc.expectedSetup) {
+				t.Fatalf("Expected %d setup statements, got: %d", len(tc.expectedSetup), len(call.setup))
+			}
+
+			for i, statement := range tc.expectedSetup {
+				if call.setup[i] != statement {
+					t.Errorf("Expected setup statement: %s, got: %s", statement, call.setup[i])
+				}
+			}
+
+			if call.fetch != tc.expectedFetch {
+				t.Errorf("Expected fetch statement: %s, got: %s", tc.expectedFetch, call.fetch)
+			}
+
+			if len(call.args) != tc.expectedArgs {
+				t.Errorf("Expected %d arguments, got: %d", tc.expectedArgs, len(call.args))
+			}
+		})
+	}
+}
+
+func TestBuildProcedureCall_SQLServer_NamedArguments(t *testing.T) {
+	// Arrange
+	var total int
+	params := []ProcedureParameter{In("id", 1), Out("total", &total)}
+
+	// Act
+	call, _ := buildProcedureCall(DialectSQLServer, "get_total", params)
+
+	// Assert
+	named, ok := call.args[1].(sql.NamedArg)
+	if !ok {
+		t.Fatalf("Expected sql.NamedArg, got: %T", call.args[1])
+	}
+
+	out, ok := named.Value.(sql.Out)
+	if !ok || out.Dest != &total || out.In {
+		t.Errorf("Expected sql.Out pointing to the destination, got: %v", named.Value)
+	}
+}
+
+func TestBuildProcedureCall_Errors(t *testing.T) {
+	// Test cases
+	testCases := []struct {
+		testName       string
+		dialect        Dialect
+		name           string
+		params         []ProcedureParameter
+		expectedErrMsg string
+	}{
+		{"Empty name", DialectMySQL, "", nil, db_errors.Procedure_EmptyNameErrorMessage},
+		{"SQLite", DialectSQLite, "get_user", nil, db_errors.Procedure_NotSupportedErrorMessage},
+		{"SQL Server without parameter name", DialectSQLServer, "get_user", []ProcedureParameter{In("", 1)}, db_errors.Procedure_EmptyParameterNameErrorMessage},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			// Act
+			_, err := buildProcedureCall(tc.dialect, tc.name, tc.params)
+
+			// Assert
+			assertError(t, err, tc.expectedErrMsg)
+		})
+	}
+}
+
+func TestDBCommand_CallProcedure_MySQL_Outputs(t *testing.T) {
+	// Arrange
+	command, mock := createTestMockCommand(t, DialectMySQL)
+	mock.ExpectExec("SET @dbgo_out2 = ?").WithArgs(int64(5))
+	mock.ExpectQuery("CALL transfer(?, @dbgo_out1, @dbgo_out2)").WithArgs(10).
+		WillReturnRows(db_mock.CreateNewRows("id").AddRow(1).AddRow(2))
+	mock.ExpectQuery("SELECT @dbgo_out1, @dbgo_out2").
+		WillReturnRows(db_mock.CreateNewRows("@dbgo_out1", "@dbgo_out2").AddRow("done", int64(15)))
+
+	var status string
+	counter := int64(5)
+
+	// Act
+	result, err := command.CallProcedure("transfer", In("amount", 10), Out("status", &status), InOut("counter", &counter))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if status != "done" || counter != 15 {
+		t.Errorf("Expected the output values, got: %s %d", status, counter)
+	}
+
+	if value, _ := result.GetOutput("counter"); value != int64(15) {
+		t.Errorf("Expected the output of counter: 15, got: %v", value)
+	}
+
+	if len(result.GetResultSets()) != 1 || len(result.GetResultSets()[0].GetRows()) != 2 {
+		t.Errorf("Expected the result set of the call, got: %v", result.GetResultSets())
+	}
+
+	assertExpectationsWereMet(t, mock)
+}
+
+func TestDBCommand_CallProcedure_Postgres_Outputs(t *testing.T) {
+	// Arrange
+	command, mock := createTestMockCommand(t, DialectPostgres)
+	mock.ExpectQuery("CALL transfer($1, NULL)").WithArgs(10).
+		WillReturnRows(db_mock.CreateNewRows("status").AddRow("done"))
+
+	var status string
+
+	// Act
+	result, err := command.CallProcedure("transfer", In("amount", 10), Out("status", &status))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if status != "done" {
+		t.Errorf("Expected the output value: done, got: %s", status)
+	}
+
+	if len(result.GetResultSets()) != 0 {
+		t.Errorf("Expected the output row not to be a result set, got: %v", result.GetResultSets())
+	}
+
+	assertExpectationsWereMet(t, mock)
+}
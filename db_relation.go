@@ -0,0 +1,465 @@
+package db
+
+import (
+	"reflect"
+	"strings"
+
+	db_criteria "github.com/fatihtatoglu/db-go/criteria"
+	db_errors "github.com/fatihtatoglu/db-go/error"
+)
+
+// Relations are declared with the rel struct tag and loaded by the Include option of the repository:
+//
+//	Customer *Customer  `rel:"belongs_to,fk=customer_id"`
+//	Lines    []OrderLine `rel:"has_many,fk=order_id"`
+//	Tags     []Tag       `rel:"many_to_many,join=order_tags,fk=order_id,ref=tag_id"`
+//
+// belongs_to reads the related entity by the fk column of the entity, has_many reads the related entities
+// whose fk column holds the primary key of the entity and many_to_many reads the related entities through
+// the join table, whose fk column references the entity and ref column references the related entity.
+// The fk and ref columns default to the snake_case type names with the _id suffix and the related table
+// defaults to the TableName of the related type, or to its snake_case type name. The table option overrides it.
+const (
+	relationTagName = "rel"
+
+	relationBelongsTo  = "belongs_to"
+	relationHasMany    = "has_many"
+	relationManyToMany = "many_to_many"
+)
+
+// TableNamer is implemented by the entities which define their table name.
+type TableNamer interface {
+	TableName() string
+}
+
+var tableNamerType = reflect.TypeOf((*TableNamer)(nil)).Elem()
+
+type entityRelation struct {
+	name        string
+	kind        string
+	index       []int
+	fieldType   reflect.Type
+	elementType reflect.Type
+	table       string
+	foreignKey  string
+	joinTable   string
+	reference   string
+}
+
+func parseRelation(ownerType reflect.Type, sf reflect.StructField, index []int, tag string) *entityRelation {
+	relation := &entityRelation{
+		name:        sf.Name,
+		index:       index,
+		fieldType:   sf.Type,
+		elementType: sf.Type,
+	}
+
+	if relation.elementType.Kind() == reflect.Slice {
+		relation.elementType = relation.elementType.Elem()
+	}
+
+	if relation.elementType.Kind() == reflect.Pointer {
+		relation.elementType = relation.elementType.Elem()
+	}
+
+	parts := strings.Split(tag, ",")
+	relation.kind = strings.TrimSpace(parts[0])
+
+	for _, part := range parts[1:] {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "fk":
+			relation.foreignKey = value
+		case "ref":
+			relation.reference = value
+		case "join":
+			relation.joinTable = value
+		case "table":
+			relation.table = value
+		}
+	}
+
+	if relation.table == "" {
+		relation.table = tableName(relation.elementType)
+	}
+
+	if relation.foreignKey == "" {
+		switch relation.kind {
+		case relationBelongsTo:
+			relation.foreignKey = toSnakeCase(sf.Name) + "_id"
+		default:
+			relation.foreignKey = toSnakeCase(ownerType.Name()) + "_id"
+		}
+	}
+
+	if relation.reference == "" {
+		relation.reference = toSnakeCase(relation.elementType.Name()) + "_id"
+	}
+
+	return relation
+}
+
+// tableName returns the TableName of the struct type, or its snake_case name.
+func tableName(t reflect.Type) string {
+	if t.Kind() == reflect.Struct && reflect.PointerTo(t).Implements(tableNamerType) {
+		return reflect.New(t).Interface().(TableNamer).TableName()
+	}
+
+	return toSnakeCase(t.Name())
+}
+
+// Include returns a repository which loads the given relations of the read entities.
+// Every relation is loaded with batched IN queries instead of a query per entity.
+func (r *dbRepository[T, K]) Include(relations ...string) Repository[T, K] {
+	clone := *r
+	clone.includes = append(append([]string{}, r.includes...), relations...)
+	return &clone
+}
+
+// loadRelations loads the included relations of the entities.
+func (r *dbRepository[T, K]) loadRelations(entities []T) error {
+	if len(r.includes) == 0 || len(entities) == 0 {
+		return nil
+	}
+
+	owners := make([]reflect.Value, len(entities))
+	ev := reflect.ValueOf(entities)
+	for i := range entities {
+		owner := ev.Index(i)
+		if owner.Kind() == reflect.Pointer {
+			owner = owner.Elem()
+		}
+
+		owners[i] = owner
+	}
+
+	for _, name := range r.includes {
+		relation, ok := r.metadata.relations[name]
+		if !ok {
+			return db_errors.RepositoryUnknownRelationError()
+		}
+
+		var err error
+		switch relation.kind {
+		case relationBelongsTo:
+			err = r.loadBelongsTo(relation, owners)
+		case relationHasMany:
+			err = r.loadHasMany(relation, owners)
+		case relationManyToMany:
+			err = r.loadManyToMany(relation, owners)
+		default:
+			err = db_errors.RepositoryInvalidRelationError()
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *dbRepository[T, K]) loadBelongsTo(relation *entityRelation, owners []reflect.Value) error {
+	foreignKey, ok := r.metadata.fieldByColumn(relation.foreignKey)
+	if !ok {
+		return db_errors.RepositoryInvalidRelationError()
+	}
+
+	related, err := getEntityMetadata(relation.elementType)
+	if err != nil {
+		return err
+	}
+
+	relatedKey, err := singleKey(related)
+	if err != nil {
+		return err
+	}
+
+	keys := make([]interface{}, 0, len(owners))
+	for _, owner := range owners {
+		keys = append(keys, owner.FieldByIndex(foreignKey.index).Interface())
+	}
+
+	entities, err := r.loadRelated(related, relation.table, relatedKey.column, keys)
+	if err != nil {
+		return err
+	}
+
+	byKey := make(map[interface{}]reflect.Value, len(entities))
+	for _, entity := range entities {
+		byKey[relationKey(entity.FieldByIndex(relatedKey.index).Interface())] = entity
+	}
+
+	for _, owner := range owners {
+		entity, ok := byKey[relationKey(owner.FieldByIndex(foreignKey.index).Interface())]
+		if ok {
+			setRelated(owner.FieldByIndex(relation.index), entity)
+		}
+	}
+
+	return nil
+}
+
+func (r *dbRepository[T, K]) loadHasMany(relation *entityRelation, owners []reflect.Value) error {
+	if len(r.keys) != 1 {
+		return db_errors.RepositoryInvalidRelationError()
+	}
+
+	related, err := getEntityMetadata(relation.elementType)
+	if err != nil {
+		return err
+	}
+
+	foreignKey, ok := related.fieldByColumn(relation.foreignKey)
+	if !ok {
+		return db_errors.RepositoryInvalidRelationError()
+	}
+
+	entities, err := r.loadRelated(related, relation.table, foreignKey.column, ownerKeys(owners, r.keys[0]))
+	if err != nil {
+		return err
+	}
+
+	byKey := make(map[interface{}][]reflect.Value)
+	for _, entity := range entities {
+		key := relationKey(entity.FieldByIndex(foreignKey.index).Interface())
+		byKey[key] = append(byKey[key], entity)
+	}
+
+	for _, owner := range owners {
+		appendRelated(owner.FieldByIndex(relation.index), byKey[relationKey(owner.FieldByIndex(r.keys[0].index).Interface())])
+	}
+
+	return nil
+}
+
+func (r *dbRepository[T, K]) loadManyToMany(relation *entityRelation, owners []reflect.Value) error {
+	if len(r.keys) != 1 || relation.joinTable == "" {
+		return db_errors.RepositoryInvalidRelationError()
+	}
+
+	related, err := getEntityMetadata(relation.elementType)
+	if err != nil {
+		return err
+	}
+
+	relatedKey, err := singleKey(related)
+	if err != nil {
+		return err
+	}
+
+	// The join table rows pair the owner keys with the related keys.
+	dialect := r.dialect()
+	pairs := make([][2]interface{}, 0)
+	err = inChunks(dialect, ownerKeys(owners, r.keys[0]), func(chunk []interface{}) error {
+		dt, err := Select(joinQuoted(dialect, []string{relation.foreignKey, relation.reference}, "")).
+			From(dialect.QuoteIdentifier(relation.joinTable)).
+			WhereCriteria(db_criteria.In(relation.foreignKey, chunk...)).
+			Query(r.command)
+		if err != nil {
+			return err
+		}
+
+		// The join table columns may be read with other types than the keys, e.g. as text by MySQL.
+		for _, row := range dt.rows {
+			ownerKey, err := convertKey(r.keys[0], row.itemArray[0])
+			if err != nil {
+				return err
+			}
+
+			relatedKeyValue, err := convertKey(relatedKey, row.itemArray[1])
+			if err != nil {
+				return err
+			}
+
+			pairs = append(pairs, [2]interface{}{ownerKey, relatedKeyValue})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	relatedKeys := make([]interface{}, len(pairs))
+	for i, pair := range pairs {
+		relatedKeys[i] = pair[1]
+	}
+
+	entities, err := r.loadRelated(related, relation.table, relatedKey.column, relatedKeys)
+	if err != nil {
+		return err
+	}
+
+	byKey := make(map[interface{}]reflect.Value, len(entities))
+	for _, entity := range entities {
+		byKey[relationKey(entity.FieldByIndex(relatedKey.index).Interface())] = entity
+	}
+
+	byOwner := make(map[interface{}][]reflect.Value)
+	for _, pair := range pairs {
+		entity, ok := byKey[relationKey(pair[1])]
+		if ok {
+			ownerKey := relationKey(pair[0])
+			byOwner[ownerKey] = append(byOwner[ownerKey], entity)
+		}
+	}
+
+	for _, owner := range owners {
+		appendRelated(owner.FieldByIndex(relation.index), byOwner[relationKey(owner.FieldByIndex(r.keys[0].index).Interface())])
+	}
+
+	return nil
+}
+
+// loadRelated reads the related entities whose column holds one of the keys. The duplicate and nil keys
+// are skipped and the keys are sent in chunks that fit into the parameter limit of the dialect.
+// The soft deleted entities are skipped like the reads of the repository, unless WithDeleted is used.
+func (r *dbRepository[T, K]) loadRelated(related *entityMetadata, table string, column string, keys []interface{}) ([]reflect.Value, error) {
+	dialect := r.dialect()
+	entities := make([]reflect.Value, 0)
+
+	err := inChunks(dialect, keys, func(chunk []interface{}) error {
+		builder := Select(joinQuoted(dialect, columnNames(related.fields), "")).
+			From(dialect.QuoteIdentifier(table)).
+			WhereCriteria(db_criteria.In(column, chunk...))
+
+		if softDelete := related.softDeleteField(); softDelete != nil && !r.withDeleted {
+			builder.WhereCriteria(db_criteria.IsNull(softDelete.column))
+		}
+
+		dt, err := builder.Query(r.command)
+		if err != nil {
+			return err
+		}
+
+		for i := range dt.rows {
+			entity := reflect.New(related.entityType).Elem()
+			err = mapStruct(related, &dt.rows[i], entity)
+			if err != nil {
+				return err
+			}
+
+			entities = append(entities, entity)
+		}
+
+		return nil
+	})
+
+	return entities, err
+}
+
+// inChunks calls fn with the distinct non-nil keys split by the parameter limit of the dialect.
+func inChunks(dialect Dialect, keys []interface{}, fn func(chunk []interface{}) error) error {
+	distinct := make([]interface{}, 0, len(keys))
+	seen := make(map[interface{}]bool, len(keys))
+	for _, key := range keys {
+		k := relationKey(key)
+		if k == nil || seen[k] {
+			continue
+		}
+
+		seen[k] = true
+		distinct = append(distinct, key)
+	}
+
+	size := dialect.maxParameters()
+	for start := 0; start < len(distinct); start += size {
+		err := fn(distinct[start:min(start+size, len(distinct))])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func ownerKeys(owners []reflect.Value, key *entityField) []interface{} {
+	keys := make([]interface{}, len(owners))
+	for i, owner := range owners {
+		keys[i] = owner.FieldByIndex(key.index).Interface()
+	}
+
+	return keys
+}
+
+func singleKey(metadata *entityMetadata) (*entityField, error) {
+	keys := metadata.primaryKeys()
+	if len(keys) != 1 {
+		return nil, db_errors.RepositoryInvalidRelationError()
+	}
+
+	return keys[0], nil
+}
+
+// convertKey converts the value read from the database to the type of the key field; nil stays nil.
+func convertKey(key *entityField, value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	converted := reflect.New(key.fieldType)
+	err := assignValue(converted.Interface(), value)
+	if err != nil {
+		return nil, err
+	}
+
+	return converted.Elem().Interface(), nil
+}
+
+// relationKey normalizes the key value, so the keys read from the database match the keys of the entities.
+func relationKey(value interface{}) interface{} {
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Invalid:
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(v.Uint())
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return string(v.Bytes())
+		}
+	case reflect.String:
+		return v.String()
+	}
+
+	if v.Type().Comparable() {
+		return v.Interface()
+	}
+
+	return nil
+}
+
+// setRelated sets the struct or pointer field to the related entity.
+func setRelated(field reflect.Value, entity reflect.Value) {
+	if field.Kind() == reflect.Pointer {
+		field.Set(entity.Addr())
+		return
+	}
+
+	field.Set(entity)
+}
+
+// appendRelated sets the slice field to the related entities, an empty slice when there are none.
+func appendRelated(field reflect.Value, entities []reflect.Value) {
+	slice := reflect.MakeSlice(field.Type(), 0, len(entities))
+	for _, entity := range entities {
+		if field.Type().Elem().Kind() == reflect.Pointer {
+			slice = reflect.Append(slice, entity.Addr())
+		} else {
+			slice = reflect.Append(slice, entity)
+		}
+	}
+
+	field.Set(slice)
+}
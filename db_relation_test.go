@@ -0,0 +1,223 @@
+package db
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	db_errors "github.com/fatihtatoglu/db-go/error"
+)
+
+type testShopCustomer struct {
+	ID   int64  `db:"id,pk"`
+	Name string `db:"name"`
+}
+
+func (testShopCustomer) TableName() string {
+	return "customers"
+}
+
+type testShopLine struct {
+	ID      int64  `db:"id,pk"`
+	OrderID int64  `db:"order_id"`
+	Product string `db:"product"`
+}
+
+type testShopTag struct {
+	ID   int64  `db:"id,pk"`
+	Name string `db:"name"`
+}
+
+type testShopOrder struct {
+	ID         int64             `db:"id,pk"`
+	CustomerID int64             `db:"customer_id"`
+	Customer   *testShopCustomer `rel:"belongs_to"`
+	Lines      []testShopLine    `rel:"has_many,table=order_lines,fk=order_id"`
+	Tags       []*testShopTag    `rel:"many_to_many,table=tags,join=order_tags,fk=order_id,ref=tag_id"`
+}
+
+func TestParseRelation(t *testing.T) {
+	// Act
+	metadata, _ := getEntityMetadata(reflect.TypeOf(testShopOrder{}))
+
+	// Assert
+	if len(metadata.fields) != 2 {
+		t.Errorf("Expected the relations to be excluded from the columns, got: %v", columnNames(metadata.fields))
+	}
+
+	customer := metadata.relations["Customer"]
+	if customer.kind != relationBelongsTo || customer.table != "customers" || customer.foreignKey != "customer_id" {
+		t.Errorf("Unexpected belongs_to relation: %+v", customer)
+	}
+
+	tags := metadata.relations["Tags"]
+	if tags.joinTable != "order_tags" || tags.foreignKey != "order_id" || tags.reference != "tag_id" {
+		t.Errorf("Unexpected many_to_many relation: %+v", tags)
+	}
+}
+
+func TestRepository_Include(t *testing.T) {
+	// Arrange
+	command := &recordingCommand{
+		dialect: DialectMySQL,
+		tables: []*DBTable{
+			createTestTable([]string{"id", "customer_id"}, []interface{}{int64(1), int64(10)}, []interface{}{int64(2), int64(10)}),
+			createTestTable([]string{"id", "name"}, []interface{}{int64(10), "Fatih"}),
+			createTestTable([]string{"id", "order_id", "product"}, []interface{}{int64(100), int64(2), "Pen"}, []interface{}{int64(101), int64(2), "Ink"}),
+			createTestTable([]string{"order_id", "tag_id"}, []interface{}{int64(1), int64(5)}, []interface{}{int64(2), int64(5)}),
+			createTestTable([]string{"id", "name"}, []interface{}{int64(5), "gift"}),
+		},
+	}
+	sut, _ := CreateNewRepository[testShopOrder, int64](command, "orders")
+
+	// Act
+	orders, err := sut.Include("Customer", "Lines", "Tags").Retrieve()
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expectedQueries := []string{
+		"SELECT `id`, `customer_id` FROM `orders`",
+		"SELECT `id`, `name` FROM `customers` WHERE `id` IN (?)",
+		"SELECT `id`, `order_id`, `product` FROM `order_lines` WHERE `order_id` IN (?, ?)",
+		"SELECT `order_id`, `tag_id` FROM `order_tags` WHERE `order_id` IN (?, ?)",
+		"SELECT `id`, `name` FROM `tags` WHERE `id` IN (?)",
+	}
+	if !reflect.DeepEqual(command.queries, expectedQueries) {
+		t.Fatalf("Expected queries: %v, got: %v", expectedQueries, command.queries)
+	}
+
+	if orders[0].Customer == nil || orders[0].Customer.Name != "Fatih" || orders[1].Customer != orders[0].Customer {
+		t.Errorf("Expected the shared customer, got: %+v", orders[0].Customer)
+	}
+
+	if len(orders[0].Lines) != 0 || len(orders[1].Lines) != 2 || orders[1].Lines[1].Product != "Ink" {
+		t.Errorf("Unexpected lines: %+v, %+v", orders[0].Lines, orders[1].Lines)
+	}
+
+	if len(orders[0].Tags) != 1 || orders[1].Tags[0].Name != "gift" {
+		t.Errorf("Unexpected tags: %+v", orders[1].Tags)
+	}
+}
+
+func TestRepository_Include_UnknownRelation(t *testing.T) {
+	// Arrange
+	command := &recordingCommand{
+		dialect: DialectMySQL,
+		table:   createTestTable([]string{"id"}, []interface{}{int64(1)}),
+	}
+	sut, _ := CreateNewRepository[testShopOrder, int64](command, "orders")
+
+	// Act
+	_, err := sut.Include("Invoices").GetById(1)
+
+	// Assert
+	assertError(t, err, db_errors.Repository_UnknownRelationErrorMessage)
+}
+
+func TestInChunks(t *testing.T) {
+	// Arrange
+	keys := make([]interface{}, 0, 2200)
+	for i := 0; i < 2200; i++ {
+		keys = append(keys, int64(i%2150))
+	}
+	keys = append(keys, nil)
+
+	var sizes []int
+
+	// Act
+	inChunks(DialectSQLServer, keys, func(chunk []interface{}) error {
+		sizes = append(sizes, len(chunk))
+		return nil
+	})
+
+	// Assert
+	if !reflect.DeepEqual(sizes, []int{2098, 52}) {
+		t.Errorf("Expected chunks of 2098 and 52, got: %v", sizes)
+	}
+}
+
+func TestRepository_Include_ManyToMany_Text_Keys(t *testing.T) {
+	// Arrange
+	command := &recordingCommand{
+		dialect: DialectMySQL,
+		tables: []*DBTable{
+			createTestTable([]string{"id", "customer_id"}, []interface{}{int64(1), int64(10)}),
+			createTestTable([]string{"order_id", "tag_id"}, []interface{}{"1", "5"}, []interface{}{"1", "6"}),
+			createTestTable([]string{"id", "name"}, []interface{}{int64(5), "gift"}, []interface{}{int64(6), "rush"}),
+		},
+	}
+	sut, _ := CreateNewRepository[testShopOrder, int64](command, "orders")
+
+	// Act
+	orders, err := sut.Include("Tags").Retrieve()
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(command.args[2], []interface{}{int64(5), int64(6)}) {
+		t.Errorf("Expected the related keys converted to the key type, got: %#v", command.args[2])
+	}
+
+	if len(orders[0].Tags) != 2 || orders[0].Tags[1].Name != "rush" {
+		t.Errorf("Expected the tags matched by the converted keys, got: %+v", orders[0].Tags)
+	}
+}
+
+func TestRepository_Include_SoftDeleted(t *testing.T) {
+	// Arrange
+	type testAuthor struct {
+		ID        int64      `db:"id,pk"`
+		DeletedAt *time.Time `db:"deleted_at,softdelete"`
+	}
+
+	type testBook struct {
+		ID       int64       `db:"id,pk"`
+		AuthorID int64       `db:"author_id"`
+		Author   *testAuthor `rel:"belongs_to,table=authors"`
+	}
+
+	// Test cases
+	testCases := []struct {
+		testName      string
+		withDeleted   bool
+		expectedQuery string
+	}{
+		{"Default", false, "SELECT `id`, `deleted_at` FROM `authors` WHERE (`id` IN (?)) AND (`deleted_at` IS NULL)"},
+		{"WithDeleted", true, "SELECT `id`, `deleted_at` FROM `authors` WHERE `id` IN (?)"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			// Arrange
+			command := &recordingCommand{
+				dialect: DialectMySQL,
+				tables: []*DBTable{
+					createTestTable([]string{"id", "author_id"}, []interface{}{int64(1), int64(3)}),
+					createTestTable([]string{"id", "deleted_at"}),
+				},
+			}
+
+			repository, _ := CreateNewRepository[testBook, int64](command, "books")
+			if tc.withDeleted {
+				repository = repository.WithDeleted()
+			}
+
+			// Act
+			_, err := repository.Include("Author").Retrieve()
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if command.queries[1] != tc.expectedQuery {
+				t.Errorf("Expected query: %s, got: %s", tc.expectedQuery, command.queries[1])
+			}
+		})
+	}
+}
@@ -0,0 +1,721 @@
+package db
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"time"
+
+	db_criteria "github.com/fatihtatoglu/db-go/criteria"
+	db_errors "github.com/fatihtatoglu/db-go/error"
+)
+
+// dbRepository is the Repository implementation which maps the entity to a table with the db struct tags.
+// T is a struct or a pointer to a struct. K is the primary key type; composite keys use a struct whose
+// db tags name the primary key columns. Generated keys are written back when T is a pointer.
+//
+// When the entity has a softdelete field, Delete only sets it and the reads skip the deleted records
+// unless the repository is returned by WithDeleted. When the entity has a version field, Update only
+// matches the version read with the entity and returns a ConcurrencyConflictError otherwise.
+type dbRepository[T any, K any] struct {
+	command     DBCommandInterface
+	tableName   string
+	metadata    *entityMetadata
+	keys        []*entityField
+	softDelete  *entityField
+	version     *entityField
+	withDeleted bool
+	hooks       entityHooks
+	includes    []string
+	options     repositoryOptions
+}
+
+// RepositoryOption configures CreateNewRepository.
+type RepositoryOption func(*repositoryOptions)
+
+type repositoryOptions struct {
+	windowCount  bool
+	now          func() time.Time
+	userProvider UserProvider
+}
+
+// WithWindowCount makes FetchPage read the total count with a COUNT(*) OVER() window function
+// in the same round trip as the page. The database must support window functions,
+// e.g. MySQL 8.0 or SQLite 3.25 and later.
+func WithWindowCount() RepositoryOption {
+	return func(o *repositoryOptions) {
+		o.windowCount = true
+	}
+}
+
+// WithClock replaces the clock used by the audit, soft delete and timestamp version fields.
+func WithClock(now func() time.Time) RepositoryOption {
+	return func(o *repositoryOptions) {
+		o.now = now
+	}
+}
+
+// WithUserProvider sets the provider of the user written into the createdby and updatedby fields.
+func WithUserProvider(provider UserProvider) RepositoryOption {
+	return func(o *repositoryOptions) {
+		o.userProvider = provider
+	}
+}
+
+func CreateNewRepository[T any, K any](command DBCommandInterface, tableName string, options ...RepositoryOption) (Repository[T, K], error) {
+	if command == nil {
+		return nil, db_errors.RepositoryNilCommandError()
+	}
+
+	if tableName == "" {
+		return nil, db_errors.TableEmptyNameError()
+	}
+
+	metadata, err := getEntityMetadata(reflect.TypeOf((*T)(nil)).Elem())
+	if err != nil {
+		return nil, err
+	}
+
+	keys := metadata.primaryKeys()
+	if len(keys) == 0 {
+		return nil, db_errors.RepositoryMissingPrimaryKeyError()
+	}
+
+	version := metadata.versionField()
+	if version != nil && !isVersionType(version.fieldType) {
+		return nil, db_errors.RepositoryInvalidVersionFieldError()
+	}
+
+	opts := repositoryOptions{
+		now: time.Now,
+	}
+	for _, option := range options {
+		option(&opts)
+	}
+
+	return &dbRepository[T, K]{
+		command:    command,
+		tableName:  tableName,
+		metadata:   metadata,
+		keys:       keys,
+		softDelete: metadata.softDeleteField(),
+		version:    version,
+		hooks:      detectHooks(metadata.entityType),
+		options:    opts,
+	}, nil
+}
+
+func (r *dbRepository[T, K]) GetById(id K) (T, error) {
+	var entity T
+
+	keyValues, err := r.keyValues(id)
+	if err != nil {
+		return entity, err
+	}
+
+	dt, err := r.selectBuilder().Where(r.keyCondition(), keyValues...).Query(r.command)
+	if err != nil {
+		return entity, err
+	}
+
+	if len(dt.rows) == 0 {
+		return entity, db_errors.RepositoryNotFoundError()
+	}
+
+	entities, err := r.mapRows(dt)
+	if err != nil {
+		return entity, err
+	}
+
+	return entities[0], nil
+}
+
+func (r *dbRepository[T, K]) Retrieve() ([]T, error) {
+	return r.Find(nil)
+}
+
+func (r *dbRepository[T, K]) Fetch(limit, offset int) ([]T, error) {
+	dt, err := r.selectBuilder().OrderBySort(r.keySort()...).Limit(limit).Offset(offset).Query(r.command)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.mapRows(dt)
+}
+
+func (r *dbRepository[T, K]) Find(criteria db_criteria.Criteria, sort ...db_criteria.Sort) ([]T, error) {
+	dt, err := r.selectBuilder().WhereCriteria(criteria).OrderBySort(sort...).Query(r.command)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.mapRows(dt)
+}
+
+func (r *dbRepository[T, K]) Count(criteria db_criteria.Criteria) (int64, error) {
+	dt, err := Select("COUNT(*)").From(r.quotedTable()).WhereCriteria(r.readScope()).WhereCriteria(criteria).Query(r.command)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int64
+	if len(dt.rows) > 0 {
+		err = assignValue(&count, dt.rows[0].itemArray[0])
+	}
+
+	return count, err
+}
+
+func (r *dbRepository[T, K]) Exists(criteria db_criteria.Criteria) (bool, error) {
+	dt, err := Select("1").From(r.quotedTable()).WhereCriteria(r.readScope()).WhereCriteria(criteria).Limit(1).Query(r.command)
+	if err != nil {
+		return false, err
+	}
+
+	return len(dt.rows) > 0, nil
+}
+
+func (r *dbRepository[T, K]) CheckExistence(id K) (bool, error) {
+	keyValues, err := r.keyValues(id)
+	if err != nil {
+		return false, err
+	}
+
+	dt, err := Select("1").From(r.quotedTable()).WhereCriteria(r.readScope()).Where(r.keyCondition(), keyValues...).Query(r.command)
+	if err != nil {
+		return false, err
+	}
+
+	return len(dt.rows) > 0, nil
+}
+
+func (r *dbRepository[T, K]) Insert(entity T) (K, error) {
+	if r.hooks.beforeInsert || r.hooks.afterInsert {
+		return r.insertWithHooks(entity)
+	}
+
+	return r.insert(entity)
+}
+
+func (r *dbRepository[T, K]) insert(entity T) (K, error) {
+	var id K
+
+	sv, err := r.metadata.structValue(entity)
+	if err != nil {
+		return id, err
+	}
+
+	audited, err := r.audit(sv, true)
+	if err != nil {
+		return id, err
+	}
+
+	dialect := r.dialect()
+	fields := r.metadata.insertFields()
+	generated := r.generatedKeys()
+
+	values := r.metadata.values(audited, fields)
+
+	var versionValue interface{}
+	if i := indexOfField(fields, r.version); i >= 0 {
+		versionValue = initialVersion(sv.FieldByIndex(r.version.index), r.options.now())
+		values[i] = versionValue
+	}
+
+	builder := Insert(r.tableName).Columns(columnNames(fields)...).Values(values...)
+	generatedValues := make([]interface{}, 0, len(generated))
+
+	switch {
+	case len(generated) > 0 && (dialect == DialectPostgres || dialect == DialectSQLServer):
+		dt, err := builder.Returning(columnNames(generated)...).Query(r.command)
+		if err != nil {
+			return id, err
+		}
+
+		if len(dt.rows) > 0 {
+			generatedValues = dt.rows[0].itemArray
+		}
+
+	default:
+		res, err := builder.Execute(r.command)
+		if err != nil {
+			return id, err
+		}
+
+		if len(generated) > 0 {
+			lastInsertId, err := (*res).LastInsertId()
+			if err != nil {
+				return id, err
+			}
+
+			generatedValues = append(generatedValues, lastInsertId)
+		}
+	}
+
+	if versionValue != nil {
+		err = r.writeBack(sv, r.version, versionValue)
+		if err != nil {
+			return id, err
+		}
+	}
+
+	for i, f := range generated {
+		if i >= len(generatedValues) {
+			break
+		}
+
+		err = r.writeBack(sv, f, generatedValues[i])
+		if err != nil {
+			return id, err
+		}
+	}
+
+	keyValues := r.metadata.values(sv, r.keys)
+	for i, f := range generated {
+		if i < len(generatedValues) {
+			keyValues[indexOfField(r.keys, f)] = generatedValues[i]
+		}
+	}
+
+	return r.keyFromValues(keyValues)
+}
+
+func (r *dbRepository[T, K]) Update(id K, entity T) (bool, error) {
+	if r.hooks.beforeUpdate || r.hooks.afterUpdate {
+		return r.updateWithHooks(id, entity)
+	}
+
+	return r.update(id, entity)
+}
+
+func (r *dbRepository[T, K]) update(id K, entity T) (bool, error) {
+	keyValues, err := r.keyValues(id)
+	if err != nil {
+		return false, err
+	}
+
+	sv, err := r.metadata.structValue(entity)
+	if err != nil {
+		return false, err
+	}
+
+	audited, err := r.audit(sv, false)
+	if err != nil {
+		return false, err
+	}
+
+	builder := Update(r.tableName)
+	for _, f := range r.updateFields() {
+		builder.Set(f.column, audited.FieldByIndex(f.index).Interface())
+	}
+
+	builder.Where(r.keyCondition(), keyValues...).WhereCriteria(r.readScope())
+	if r.version == nil {
+		return r.executeAffected(builder)
+	}
+
+	current := sv.FieldByIndex(r.version.index)
+	next := nextVersion(current, r.options.now())
+	builder.Set(r.version.column, next).WhereCriteria(db_criteria.Eq(r.version.column, current.Interface()))
+
+	updated, err := r.executeAffected(builder)
+	if err != nil {
+		return false, err
+	}
+
+	if !updated {
+		return false, db_errors.NewConcurrencyConflictError(r.tableName, current.Interface())
+	}
+
+	return true, r.writeBack(sv, r.version, next)
+}
+
+// Delete sets the softdelete field of the record to the current time when the entity has one,
+// otherwise the record is deleted.
+func (r *dbRepository[T, K]) Delete(id K) (bool, error) {
+	if r.hooks.beforeDelete {
+		return r.deleteWithHooks(id, false, (*dbRepository[T, K]).delete)
+	}
+
+	return r.delete(id)
+}
+
+func (r *dbRepository[T, K]) delete(id K) (bool, error) {
+	if r.softDelete == nil {
+		return r.hardDelete(id)
+	}
+
+	keyValues, err := r.keyValues(id)
+	if err != nil {
+		return false, err
+	}
+
+	builder := Update(r.tableName).
+		Set(r.softDelete.column, r.options.now()).
+		Where(r.keyCondition(), keyValues...).
+		WhereCriteria(db_criteria.IsNull(r.softDelete.column))
+
+	return r.executeAffected(builder)
+}
+
+// HardDelete deletes the record even when the entity has a softdelete field.
+func (r *dbRepository[T, K]) HardDelete(id K) (bool, error) {
+	if r.hooks.beforeDelete {
+		return r.deleteWithHooks(id, true, (*dbRepository[T, K]).hardDelete)
+	}
+
+	return r.hardDelete(id)
+}
+
+func (r *dbRepository[T, K]) hardDelete(id K) (bool, error) {
+	keyValues, err := r.keyValues(id)
+	if err != nil {
+		return false, err
+	}
+
+	return r.executeAffected(Delete(r.tableName).Where(r.keyCondition(), keyValues...))
+}
+
+// Restore clears the softdelete field of a deleted record.
+func (r *dbRepository[T, K]) Restore(id K) (bool, error) {
+	if r.softDelete == nil {
+		return false, db_errors.RepositorySoftDeleteNotSupportedError()
+	}
+
+	keyValues, err := r.keyValues(id)
+	if err != nil {
+		return false, err
+	}
+
+	builder := Update(r.tableName).
+		Set(r.softDelete.column, nil).
+		Where(r.keyCondition(), keyValues...).
+		WhereCriteria(db_criteria.IsNotNull(r.softDelete.column))
+
+	return r.executeAffected(builder)
+}
+
+// WithContext returns a repository which runs the statements with the context.
+// The context is also passed to the user provider.
+func (r *dbRepository[T, K]) WithContext(ctx context.Context) Repository[T, K] {
+	clone := *r
+	clone.command = r.command.WithContext(ctx)
+	return &clone
+}
+
+// WithDeleted returns a repository whose reads include the soft deleted records.
+func (r *dbRepository[T, K]) WithDeleted() Repository[T, K] {
+	clone := *r
+	clone.withDeleted = true
+	return &clone
+}
+
+// Upsert inserts the entity or updates the record with the same primary key in one statement.
+// A versioned entity cannot be checked by a single statement on every dialect; it is inserted while its
+// version is zero and updated with the version check of Update otherwise.
+//
+// The statement does not tell whether the record was inserted or updated, so it runs no write hooks.
+// The hooks of Insert and Update run when Upsert falls back to them: for an entity without its generated
+// key and for a versioned entity.
+func (r *dbRepository[T, K]) Upsert(entity T) (bool, error) {
+	sv, err := r.metadata.structValue(entity)
+	if err != nil {
+		return false, err
+	}
+
+	// A record without its generated key cannot conflict, it is a plain insert.
+	for _, f := range r.generatedKeys() {
+		if sv.FieldByIndex(f.index).IsZero() {
+			_, err = r.Insert(entity)
+			return err == nil, err
+		}
+	}
+
+	if r.version != nil {
+		if sv.FieldByIndex(r.version.index).IsZero() {
+			_, err = r.Insert(entity)
+			return err == nil, err
+		}
+
+		id, err := r.keyFromValues(r.metadata.values(sv, r.keys))
+		if err != nil {
+			return false, err
+		}
+
+		return r.Update(id, entity)
+	}
+
+	audited, err := r.audit(sv, true)
+	if err != nil {
+		return false, err
+	}
+
+	updateColumns := columnNames(r.updateFields())
+
+	// An upserted record is alive; a soft deleted record with the same key is restored.
+	if r.softDelete != nil {
+		audited.FieldByIndex(r.softDelete.index).SetZero()
+		updateColumns = append(updateColumns, r.softDelete.column)
+
+		err = r.writeBack(sv, r.softDelete, nil)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	affected, err := r.command.Upsert(r.tableName, columnNames(r.keys), updateColumns, audited.Addr().Interface())
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+func (r *dbRepository[T, K]) dialect() Dialect {
+	return r.command.GetDialect()
+}
+
+func (r *dbRepository[T, K]) quotedTable() string {
+	return r.dialect().QuoteIdentifier(r.tableName)
+}
+
+func (r *dbRepository[T, K]) selectBuilder(extraColumns ...string) *SelectBuilder {
+	columns := append([]string{joinQuoted(r.dialect(), columnNames(r.metadata.fields), "")}, extraColumns...)
+	return Select(columns...).From(r.quotedTable()).WhereCriteria(r.readScope())
+}
+
+// readScope returns the criteria which hides the soft deleted records, nil when all the records are visible.
+func (r *dbRepository[T, K]) readScope() db_criteria.Criteria {
+	if r.softDelete == nil || r.withDeleted {
+		return nil
+	}
+
+	return db_criteria.IsNull(r.softDelete.column)
+}
+
+// keyCondition returns the primary key comparison with ? placeholders.
+func (r *dbRepository[T, K]) keyCondition() string {
+	dialect := r.dialect()
+
+	conditions := make([]string, len(r.keys))
+	for i, f := range r.keys {
+		conditions[i] = dialect.QuoteIdentifier(f.column) + " = ?"
+	}
+
+	return strings.Join(conditions, " AND ")
+}
+
+// keySort orders the rows by the primary key, which gives the pages a stable order.
+func (r *dbRepository[T, K]) keySort() []db_criteria.Sort {
+	sort := make([]db_criteria.Sort, len(r.keys))
+	for i, f := range r.keys {
+		sort[i] = db_criteria.Asc(f.column)
+	}
+
+	return sort
+}
+
+func (r *dbRepository[T, K]) generatedKeys() []*entityField {
+	generated := make([]*entityField, 0)
+	for _, f := range r.keys {
+		if f.isAutoIncrement() {
+			generated = append(generated, f)
+		}
+	}
+
+	return generated
+}
+
+// updateFields returns the fields written by an UPDATE statement.
+// The softdelete field is only changed by Delete and Restore, the version field is advanced by Update
+// and the created audit fields are only written by Insert.
+func (r *dbRepository[T, K]) updateFields() []*entityField {
+	fields := make([]*entityField, 0, len(r.metadata.fields))
+	for _, f := range r.metadata.fields {
+		if !f.isPrimaryKey() && !f.isAutoIncrement() && !f.isSoftDelete() && !f.isVersion() && !f.isCreateAudit() {
+			fields = append(fields, f)
+		}
+	}
+
+	return fields
+}
+
+// writeBack sets the field of the entity when T is a pointer; entities passed by value cannot be changed.
+func (r *dbRepository[T, K]) writeBack(sv reflect.Value, f *entityField, value interface{}) error {
+	field := sv.FieldByIndex(f.index)
+	if !field.CanSet() {
+		return nil
+	}
+
+	return assignReflectValue(field, value)
+}
+
+func (r *dbRepository[T, K]) executeAffected(builder SQLBuilder) (bool, error) {
+	query, args, err := builder.Build(r.dialect())
+	if err != nil {
+		return false, err
+	}
+
+	res, err := r.command.Execute(query, args...)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := (*res).RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+// keyValues returns the primary key column values of the id in the order of the key fields.
+func (r *dbRepository[T, K]) keyValues(id K) ([]interface{}, error) {
+	kv := reflect.ValueOf(&id).Elem()
+	for kv.Kind() == reflect.Pointer || kv.Kind() == reflect.Interface {
+		if kv.IsNil() {
+			return nil, db_errors.RepositoryInvalidKeyError()
+		}
+
+		kv = kv.Elem()
+	}
+
+	if kv.Kind() != reflect.Struct || isValueStruct(kv.Type()) {
+		if len(r.keys) != 1 {
+			return nil, db_errors.RepositoryInvalidKeyError()
+		}
+
+		return []interface{}{kv.Interface()}, nil
+	}
+
+	keyMetadata, err := getEntityMetadata(kv.Type())
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]interface{}, len(r.keys))
+	for i, f := range r.keys {
+		kf, ok := keyMetadata.fieldByColumn(f.column)
+		if !ok {
+			return nil, db_errors.RepositoryInvalidKeyError()
+		}
+
+		values[i] = kv.FieldByIndex(kf.index).Interface()
+	}
+
+	return values, nil
+}
+
+// keyFromValues creates the id from the primary key column values.
+func (r *dbRepository[T, K]) keyFromValues(values []interface{}) (K, error) {
+	var id K
+
+	kv := reflect.ValueOf(&id).Elem()
+	kt := kv.Type()
+	for kt.Kind() == reflect.Pointer {
+		kt = kt.Elem()
+	}
+
+	if kt.Kind() != reflect.Struct || isValueStruct(kt) {
+		if len(values) != 1 {
+			return id, db_errors.RepositoryInvalidKeyError()
+		}
+
+		return id, assignReflectValue(kv, values[0])
+	}
+
+	keyMetadata, err := getEntityMetadata(kt)
+	if err != nil {
+		return id, err
+	}
+
+	target := reflect.New(kt).Elem()
+	for i, f := range r.keys {
+		kf, ok := keyMetadata.fieldByColumn(f.column)
+		if !ok {
+			return id, db_errors.RepositoryInvalidKeyError()
+		}
+
+		err = assignReflectValue(target.FieldByIndex(kf.index), values[i])
+		if err != nil {
+			return id, err
+		}
+	}
+
+	if kv.Kind() == reflect.Pointer {
+		kv.Set(target.Addr())
+	} else {
+		kv.Set(target)
+	}
+
+	return id, nil
+}
+
+func (r *dbRepository[T, K]) mapRows(dt *DBTable) ([]T, error) {
+	entities := make([]T, 0, len(dt.rows))
+	for i := range dt.rows {
+		entity, err := r.mapRow(&dt.rows[i])
+		if err != nil {
+			return nil, err
+		}
+
+		entities = append(entities, entity)
+	}
+
+	err := r.loadRelations(entities)
+	if err != nil {
+		return nil, err
+	}
+
+	return entities, nil
+}
+
+// mapRow creates the entity from the row. Columns without a matching field are ignored.
+func (r *dbRepository[T, K]) mapRow(row *DBRow) (T, error) {
+	var entity T
+
+	ev := reflect.ValueOf(&entity).Elem()
+	sv := ev
+	if ev.Kind() == reflect.Pointer {
+		ev.Set(reflect.New(r.metadata.entityType))
+		sv = ev.Elem()
+	}
+
+	err := mapStruct(r.metadata, row, sv)
+	if err != nil {
+		return entity, err
+	}
+
+	if r.hooks.afterLoad {
+		return entity, r.afterLoad(sv)
+	}
+
+	return entity, nil
+}
+
+// mapStruct assigns the row values to the fields of the struct value. Columns without a matching field are ignored.
+func mapStruct(metadata *entityMetadata, row *DBRow, sv reflect.Value) error {
+	for i, column := range row.Table.columns {
+		f, ok := metadata.fieldByColumn(column.name)
+		if !ok {
+			continue
+		}
+
+		err := assignReflectValue(sv.FieldByIndex(f.index), row.itemArray[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func indexOfField(fields []*entityField, field *entityField) int {
+	for i, f := range fields {
+		if f == field {
+			return i
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,320 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"reflect"
+	"testing"
+
+	db_criteria "github.com/fatihtatoglu/db-go/criteria"
+	db_errors "github.com/fatihtatoglu/db-go/error"
+)
+
+type testOrderLine struct {
+	OrderID  int64  `db:"order_id,pk"`
+	LineNo   int    `db:"line_no,pk"`
+	Product  string `db:"product"`
+	Quantity int    `db:"quantity"`
+}
+
+type testOrderLineKey struct {
+	OrderID int64 `db:"order_id"`
+	LineNo  int   `db:"line_no"`
+}
+
+// recordingCommand records the statements sent by a repository and returns the configured table and result.
+// The queued tables are returned by the queries in order before the table.
+type recordingCommand struct {
+	DBCommandInterface
+	dialect Dialect
+	ctx     context.Context
+	events  []string
+	queries []string
+	args    [][]interface{}
+	table   *DBTable
+	tables  []*DBTable
+	result  sql.Result
+}
+
+func (c *recordingCommand) GetDialect() Dialect {
+	return c.dialect
+}
+
+func (c *recordingCommand) GetContext() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+
+	return c.ctx
+}
+
+func (c *recordingCommand) WithContext(ctx context.Context) DBCommandInterface {
+	c.ctx = ctx
+	return c
+}
+
+func (c *recordingCommand) Execute(query string, params ...interface{}) (*sql.Result, error) {
+	c.queries = append(c.queries, query)
+	c.args = append(c.args, params)
+
+	result := c.result
+	if result == nil {
+		result = driver.RowsAffected(1)
+	}
+
+	return &result, nil
+}
+
+// ExecuteScript records the statements of the script one by one.
+func (c *recordingCommand) ExecuteScript(script io.Reader, options ...ScriptOption) (*ScriptResult, error) {
+	content, err := io.ReadAll(script)
+	if err != nil {
+		return nil, err
+	}
+
+	statements, err := SplitScript(string(content), c.dialect)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ScriptResult{}
+	for _, statement := range statements {
+		c.Execute(statement.GetText())
+		result.executed++
+	}
+
+	return result, nil
+}
+
+func (c *recordingCommand) Query(query string, params ...interface{}) (*DBTable, error) {
+	c.queries = append(c.queries, query)
+	c.args = append(c.args, params)
+
+	if len(c.tables) > 0 {
+		dt := c.tables[0]
+		c.tables = c.tables[1:]
+		return dt, nil
+	}
+
+	if c.table == nil {
+		dt := CreateNewDBTable()
+		return &dt, nil
+	}
+
+	return c.table, nil
+}
+
+// recordingTransaction is the transaction of a recordingCommand; the statements are recorded by the command.
+type recordingTransaction struct {
+	*recordingCommand
+}
+
+func (c *recordingCommand) Begin() (DBTransactionInterface, error) {
+	c.events = append(c.events, "begin")
+	return &recordingTransaction{c}, nil
+}
+
+func (t *recordingTransaction) Commit() error {
+	t.events = append(t.events, "commit")
+	return nil
+}
+
+func (t *recordingTransaction) Rollback() error {
+	t.events = append(t.events, "rollback")
+	return nil
+}
+
+func createTestTable(columnNames []string, rows ...[]interface{}) *DBTable {
+	dt := createTestTableWithColumns(columnNames)
+	for _, values := range rows {
+		row := dt.CreateNewDBRow()
+		copy(row.itemArray, values)
+		dt.AddDBRow(row)
+	}
+
+	return dt
+}
+
+func TestCreateNewRepository_Errors(t *testing.T) {
+	// Arrange
+	command := &recordingCommand{dialect: DialectMySQL}
+
+	// Act
+	_, nilCommandErr := CreateNewRepository[testUser, int64](nil, "users")
+	_, emptyTableErr := CreateNewRepository[testUser, int64](command, "")
+	_, missingKeyErr := CreateNewRepository[testAuditInfo, int64](command, "audits")
+
+	// Assert
+	assertError(t, nilCommandErr, db_errors.Repository_NilCommandErrorMessage)
+	assertError(t, emptyTableErr, db_errors.Table_EmptyNameErrorMessage)
+	assertError(t, missingKeyErr, db_errors.Repository_MissingPrimaryKeyErrorMessage)
+}
+
+func TestRepository_GetById(t *testing.T) {
+	// Arrange
+	command := &recordingCommand{
+		dialect: DialectMySQL,
+		table:   createTestTable([]string{"id", "first_name", "age"}, []interface{}{int64(7), "Fatih", "39"}),
+	}
+	sut, _ := CreateNewRepository[*testUser, int64](command, "users")
+
+	// Act
+	user, err := sut.GetById(7)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expectedQuery := "SELECT `created_at`, `id`, `first_name`, `age` FROM `users` WHERE `id` = ?"
+	if command.queries[0] != expectedQuery {
+		t.Errorf("Expected query: %s, got: %s", expectedQuery, command.queries[0])
+	}
+
+	if user.ID != 7 || user.FirstName != "Fatih" || user.Age != 39 {
+		t.Errorf("Unexpected entity: %+v", user)
+	}
+}
+
+func TestRepository_GetById_NotFound(t *testing.T) {
+	// Arrange
+	sut, _ := CreateNewRepository[testUser, int64](&recordingCommand{dialect: DialectMySQL}, "users")
+
+	// Act
+	_, err := sut.GetById(7)
+
+	// Assert
+	assertError(t, err, db_errors.Repository_NotFoundErrorMessage)
+}
+
+func TestRepository_CompositeKey(t *testing.T) {
+	// Arrange
+	command := &recordingCommand{dialect: DialectPostgres}
+	sut, _ := CreateNewRepository[testOrderLine, testOrderLineKey](command, "order_lines")
+
+	// Act
+	_, err := sut.Update(testOrderLineKey{OrderID: 1, LineNo: 2}, testOrderLine{Product: "pen", Quantity: 3})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expectedQuery := `UPDATE "order_lines" SET "product" = $1, "quantity" = $2 WHERE "order_id" = $3 AND "line_no" = $4`
+	if command.queries[0] != expectedQuery {
+		t.Errorf("Expected query: %s, got: %s", expectedQuery, command.queries[0])
+	}
+
+	expectedArgs := []interface{}{"pen", 3, int64(1), 2}
+	if !reflect.DeepEqual(command.args[0], expectedArgs) {
+		t.Errorf("Expected args: %v, got: %v", expectedArgs, command.args[0])
+	}
+}
+
+func TestRepository_Insert_Returning(t *testing.T) {
+	// Arrange
+	command := &recordingCommand{
+		dialect: DialectPostgres,
+		table:   createTestTable([]string{"id"}, []interface{}{int64(42)}),
+	}
+	sut, _ := CreateNewRepository[*testUser, int64](command, "users")
+	user := &testUser{FirstName: "Fatih", Age: 39}
+
+	// Act
+	id, err := sut.Insert(user)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expectedQuery := `INSERT INTO "users" ("created_at", "first_name", "age") VALUES ($1, $2, $3) RETURNING "id"`
+	if command.queries[0] != expectedQuery {
+		t.Errorf("Expected query: %s, got: %s", expectedQuery, command.queries[0])
+	}
+
+	if id != 42 || user.ID != 42 {
+		t.Errorf("Expected generated id: 42, got: %d and %d", id, user.ID)
+	}
+}
+
+func TestRepository_Fetch_SQLServer(t *testing.T) {
+	// Arrange
+	command := &recordingCommand{dialect: DialectSQLServer}
+	sut, _ := CreateNewRepository[testUser, int64](command, "users")
+
+	// Act
+	sut.Fetch(10, 20)
+
+	// Assert
+	expectedQuery := "SELECT [created_at], [id], [first_name], [age] FROM [users] ORDER BY [id] ASC OFFSET @p1 ROWS FETCH NEXT @p2 ROWS ONLY"
+	if command.queries[0] != expectedQuery {
+		t.Errorf("Expected query: %s, got: %s", expectedQuery, command.queries[0])
+	}
+
+	if !reflect.DeepEqual(command.args[0], []interface{}{20, 10}) {
+		t.Errorf("Unexpected args: %v", command.args[0])
+	}
+}
+
+func TestRepository_Invalid_Key(t *testing.T) {
+	// Arrange
+	sut, _ := CreateNewRepository[testOrderLine, int64](&recordingCommand{dialect: DialectMySQL}, "order_lines")
+
+	// Act
+	_, err := sut.Delete(1)
+
+	// Assert
+	assertError(t, err, db_errors.Repository_InvalidKeyErrorMessage)
+}
+
+func TestRepository_Find(t *testing.T) {
+	// Arrange
+	command := &recordingCommand{dialect: DialectPostgres}
+	sut, _ := CreateNewRepository[testUser, int64](command, "users")
+	criteria := db_criteria.And(db_criteria.Gte("age", 18), db_criteria.In("first_name", "Fatih", "Ali"))
+
+	// Act
+	_, err := sut.Find(criteria, db_criteria.Desc("age"))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expectedQuery := `SELECT "created_at", "id", "first_name", "age" FROM "users" WHERE ("age" >= $1) AND ("first_name" IN ($2, $3)) ORDER BY "age" DESC`
+	if command.queries[0] != expectedQuery {
+		t.Errorf("Expected query: %s, got: %s", expectedQuery, command.queries[0])
+	}
+
+	if !reflect.DeepEqual(command.args[0], []interface{}{18, "Fatih", "Ali"}) {
+		t.Errorf("Unexpected args: %v", command.args[0])
+	}
+}
+
+func TestRepository_Count(t *testing.T) {
+	// Arrange
+	command := &recordingCommand{
+		dialect: DialectMySQL,
+		table:   createTestTable([]string{"COUNT(*)"}, []interface{}{"12"}),
+	}
+	sut, _ := CreateNewRepository[testUser, int64](command, "users")
+
+	// Act
+	count, err := sut.Count(db_criteria.IsNull("created_at"))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if command.queries[0] != "SELECT COUNT(*) FROM `users` WHERE `created_at` IS NULL" {
+		t.Errorf("Unexpected query: %s", command.queries[0])
+	}
+
+	if count != 12 {
+		t.Errorf("Expected count: 12, got: %d", count)
+	}
+}
@@ -1,7 +1,14 @@
 package db
 
 import (
+	"bytes"
+	"database/sql"
+	"encoding/base64"
 	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
 
 	db_errors "github.com/fatihtatoglu/db-go/error"
 )
@@ -53,7 +60,107 @@ func (dr *DBRow) MarshalJSON() ([]byte, error) {
 	return json.Marshal(dr.itemArray)
 }
 
-// TODO: UnmarshalJSON methodunu ekle.
+// UnmarshalJSON reads the values written by MarshalJSON. The values of a row of a table are converted to
+// the types of its columns: the binary values are decoded from base64 and the time values are parsed.
+// Without a table, the numbers are read as int64 or float64.
+func (dr *DBRow) UnmarshalJSON(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var values []interface{}
+	err := decoder.Decode(&values)
+	if err != nil {
+		return err
+	}
+
+	if dr.Table != nil && len(values) != len(dr.Table.columns) {
+		return db_errors.RowValueCountMismatchError()
+	}
+
+	dr.itemArray = make([]interface{}, len(values))
+	dr.itemArrayPtrs = make([]interface{}, len(values))
+	for i, value := range values {
+		var column *DBColumn
+		if dr.Table != nil {
+			column = &dr.Table.columns[i]
+		}
+
+		dr.itemArray[i], err = jsonColumnValue(value, column)
+		if err != nil {
+			return err
+		}
+
+		dr.itemArrayPtrs[i] = &dr.itemArray[i]
+	}
+
+	return nil
+}
+
+// jsonColumnValue converts a decoded JSON value to the type of the column; the column can be nil.
+func jsonColumnValue(value interface{}, column *DBColumn) (interface{}, error) {
+	var t reflect.Type
+	if column != nil {
+		t = column.appType
+		if valueType, ok := nullTypes[t]; ok {
+			t = valueType
+		}
+	}
+
+	switch v := value.(type) {
+	case json.Number:
+		if t != nil && isNumericKind(t.Kind()) {
+			switch t.Kind() {
+			case reflect.Float32, reflect.Float64:
+				f, err := v.Float64()
+				return reflect.ValueOf(f).Convert(t).Interface(), err
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+				u, err := strconv.ParseUint(v.String(), 10, 64)
+				return reflect.ValueOf(u).Convert(t).Interface(), err
+			default:
+				n, err := v.Int64()
+				return reflect.ValueOf(n).Convert(t).Interface(), err
+			}
+		}
+
+		if n, err := v.Int64(); err == nil {
+			return n, nil
+		}
+
+		return v.Float64()
+	case string:
+		if column != nil && isBinaryColumn(column) {
+			return base64.StdEncoding.DecodeString(v)
+		}
+
+		if t == timeType {
+			for _, layout := range timeLayouts {
+				parsed, err := time.Parse(layout, v)
+				if err == nil {
+					return parsed, nil
+				}
+			}
+		}
+	}
+
+	return value, nil
+}
+
+// isBinaryColumn returns whether the values of the column are bytes rather than text. The drivers scan
+// the text columns into bytes too, so the database type is checked first.
+func isBinaryColumn(column *DBColumn) bool {
+	if column.dBType == "" {
+		return column.appType == reflect.TypeOf([]byte(nil)) || column.appType == reflect.TypeOf(sql.RawBytes(nil))
+	}
+
+	dbType := strings.ToUpper(column.dBType)
+	for _, name := range []string{"BLOB", "BINARY", "BYTEA", "IMAGE"} {
+		if strings.Contains(dbType, name) {
+			return true
+		}
+	}
+
+	return false
+}
 
 func (dr *DBRow) findColumnByName(columnName string) (*DBColumn, error) {
 	var column *DBColumn
@@ -1,7 +1,11 @@
 package db
 
 import (
+	"database/sql"
+	"encoding/json"
+	"reflect"
 	"testing"
+	"time"
 
 	db_errors "github.com/fatihtatoglu/db-go/error"
 )
@@ -166,6 +170,74 @@ func TestGetItemByName_NotExisting_Name(t *testing.T) {
 	assertError(t, err, db_errors.Column_NotFoundErrorMessage)
 }
 
+func TestDBTable_JSON(t *testing.T) {
+	// Arrange
+	dt := CreateNewDBTable()
+	columns := []DBColumn{
+		{name: "id", ordinal: 0, appType: reflect.TypeOf(int32(0)), dBType: "INT"},
+		{name: "price", ordinal: 1, appType: reflect.TypeOf(sql.NullFloat64{}), dBType: "DECIMAL"},
+		{name: "photo", ordinal: 2, appType: reflect.TypeOf(sql.RawBytes(nil)), dBType: "BLOB"},
+		{name: "created_at", ordinal: 3, appType: reflect.TypeOf(time.Time{}), dBType: "DATETIME"},
+		{name: "note", ordinal: 4, appType: reflect.TypeOf(sql.RawBytes(nil)), dBType: "VARCHAR"},
+	}
+
+	for _, column := range columns {
+		dt.AddDBColumn(column)
+	}
+
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 6000, time.UTC)
+	row := dt.CreateNewDBRow()
+	row.itemArray = []interface{}{int32(7), 9.5, []byte{0, 1, 255}, createdAt, nil}
+	dt.AddDBRow(row)
+
+	data, err := json.Marshal(&dt)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Act
+	var result DBTable
+	err = json.Unmarshal(data, &result)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(result.GetColumns()) != len(columns) || len(result.GetRows()) != 1 {
+		t.Fatalf("Expected %d columns and 1 row, got: %d %d", len(columns), len(result.GetColumns()), len(result.GetRows()))
+	}
+
+	values := result.GetRows()[0].itemArray
+	expected := []interface{}{int32(7), 9.5, []byte{0, 1, 255}, createdAt, nil}
+	if !reflect.DeepEqual(values, expected) {
+		t.Errorf("Expected %#v, got: %#v", expected, values)
+	}
+
+	note, err := result.GetRows()[0].GetItemByName("note")
+	if err != nil || note != nil {
+		t.Errorf("Expected the row to be bound to the table, got: %v %v", note, err)
+	}
+}
+
+func TestDBRow_UnmarshalJSON(t *testing.T) {
+	// Arrange
+	dt := createTestTableWithColumns([]string{"id", "name"})
+	row := dt.CreateNewDBRow()
+	var detached DBRow
+
+	// Act
+	err := json.Unmarshal([]byte(`[1, "a", 2.5]`), &row)
+	detachedErr := json.Unmarshal([]byte(`[1, "a", 2.5]`), &detached)
+
+	// Assert
+	assertError(t, err, db_errors.Row_ValueCountMismatchErrorMessage)
+
+	if detachedErr != nil || !reflect.DeepEqual(detached.itemArray, []interface{}{int64(1), "a", 2.5}) {
+		t.Errorf("Expected the numbers to be read as int64 and float64, got: %#v %v", detached.itemArray, detachedErr)
+	}
+}
+
 func createTestTableWithColumns(columnNames []string) *DBTable {
 	dt := CreateNewDBTable()
 
@@ -0,0 +1,157 @@
+package db
+
+// DBSchema is the structure of the tables and the views of a database schema.
+type DBSchema struct {
+	tables []DBTableSchema
+	views  []DBView
+}
+
+func (s *DBSchema) GetTables() []DBTableSchema {
+	return s.tables
+}
+
+func (s *DBSchema) GetViews() []DBView {
+	return s.views
+}
+
+// GetTable returns the table by the name, the name is compared case sensitively.
+func (s *DBSchema) GetTable(name string) (*DBTableSchema, bool) {
+	for i := range s.tables {
+		if s.tables[i].name == name {
+			return &s.tables[i], true
+		}
+	}
+
+	return nil, false
+}
+
+// DBTableSchema is the structure of a table: its columns, primary key, indexes and foreign keys.
+type DBTableSchema struct {
+	name        string
+	columns     []DBColumn
+	primaryKey  []string
+	indexes     []DBIndex
+	foreignKeys []DBForeignKey
+}
+
+func (t *DBTableSchema) GetName() string {
+	return t.name
+}
+
+// GetColumns returns the columns in the ordinal order.
+func (t *DBTableSchema) GetColumns() []DBColumn {
+	return t.columns
+}
+
+func (t *DBTableSchema) GetColumn(name string) (*DBColumn, bool) {
+	for i := range t.columns {
+		if t.columns[i].name == name {
+			return &t.columns[i], true
+		}
+	}
+
+	return nil, false
+}
+
+// GetPrimaryKey returns the primary key columns in the key order.
+func (t *DBTableSchema) GetPrimaryKey() []string {
+	return t.primaryKey
+}
+
+// GetIndexes returns the secondary indexes; the index of the primary key is not included.
+func (t *DBTableSchema) GetIndexes() []DBIndex {
+	return t.indexes
+}
+
+func (t *DBTableSchema) GetForeignKeys() []DBForeignKey {
+	return t.foreignKeys
+}
+
+// DBIndex is an index of a table with its columns in the key order.
+type DBIndex struct {
+	name    string
+	columns []string
+	unique  bool
+}
+
+func CreateNewDBIndex(name string, columns []string, unique bool) DBIndex {
+	return DBIndex{
+		name:    name,
+		columns: columns,
+		unique:  unique,
+	}
+}
+
+func (i *DBIndex) GetName() string {
+	return i.name
+}
+
+func (i *DBIndex) GetColumns() []string {
+	return i.columns
+}
+
+func (i *DBIndex) GetUnique() bool {
+	return i.unique
+}
+
+// DBForeignKey is a foreign key of a table. The columns and the referenced columns are paired by position.
+type DBForeignKey struct {
+	name              string
+	columns           []string
+	referencedTable   string
+	referencedColumns []string
+	onDelete          string
+	onUpdate          string
+}
+
+func CreateNewDBForeignKey(name string, columns []string, referencedTable string, referencedColumns []string) DBForeignKey {
+	return DBForeignKey{
+		name:              name,
+		columns:           columns,
+		referencedTable:   referencedTable,
+		referencedColumns: referencedColumns,
+	}
+}
+
+// GetName returns the constraint name; SQLite does not report the names of the foreign keys.
+func (fk *DBForeignKey) GetName() string {
+	return fk.name
+}
+
+func (fk *DBForeignKey) GetColumns() []string {
+	return fk.columns
+}
+
+func (fk *DBForeignKey) GetReferencedTable() string {
+	return fk.referencedTable
+}
+
+func (fk *DBForeignKey) GetReferencedColumns() []string {
+	return fk.referencedColumns
+}
+
+// GetOnDelete returns the referential action of the deletes, e.g. CASCADE or NO ACTION.
+func (fk *DBForeignKey) GetOnDelete() string {
+	return fk.onDelete
+}
+
+// GetOnUpdate returns the referential action of the updates.
+func (fk *DBForeignKey) GetOnUpdate() string {
+	return fk.onUpdate
+}
+
+// DBView is a view with the query which defines it.
+type DBView struct {
+	name       string
+	definition string
+}
+
+func (v *DBView) GetName() string {
+	return v.name
+}
+
+// GetDefinition returns the query of the view as reported by the database. SQLite reports the whole
+// CREATE VIEW statement.
+func (v *DBView) GetDefinition() string {
+	return v.definition
+}
@@ -0,0 +1,735 @@
+package db
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	db_errors "github.com/fatihtatoglu/db-go/error"
+)
+
+// ColumnChange is a property of a column which differs between two schemas.
+type ColumnChange string
+
+const (
+	ColumnChangeType          ColumnChange = "type"
+	ColumnChangeNullable      ColumnChange = "nullable"
+	ColumnChangeDefault       ColumnChange = "default"
+	ColumnChangeAutoIncrement ColumnChange = "auto_increment"
+)
+
+// typeAliases maps the type names reported by the databases to the names they are compared by.
+// MySQL stores BOOLEAN as TINYINT(1).
+var typeAliases = map[string]string{
+	"bool":                        "tinyint",
+	"boolean":                     "tinyint",
+	"character":                   "char",
+	"character varying":           "varchar",
+	"decimal":                     "numeric",
+	"double":                      "double precision",
+	"float4":                      "real",
+	"float8":                      "double precision",
+	"int":                         "integer",
+	"int2":                        "smallint",
+	"int4":                        "integer",
+	"int8":                        "bigint",
+	"time without time zone":      "time",
+	"timestamp with time zone":    "timestamptz",
+	"timestamp without time zone": "timestamp",
+}
+
+// SchemaDiff is the difference between a current schema and a desired schema, e.g. production and staging
+// or a database and its entities. The views are not compared.
+type SchemaDiff struct {
+	addedTables   []DBTableSchema
+	removedTables []DBTableSchema
+	changedTables []TableDiff
+}
+
+// GetAddedTables returns the tables of the desired schema which are missing from the current schema.
+func (d *SchemaDiff) GetAddedTables() []DBTableSchema {
+	return d.addedTables
+}
+
+// GetRemovedTables returns the tables of the current schema which are missing from the desired schema.
+func (d *SchemaDiff) GetRemovedTables() []DBTableSchema {
+	return d.removedTables
+}
+
+func (d *SchemaDiff) GetChangedTables() []TableDiff {
+	return d.changedTables
+}
+
+func (d *SchemaDiff) HasChanges() bool {
+	return len(d.addedTables) > 0 || len(d.removedTables) > 0 || len(d.changedTables) > 0
+}
+
+// TableDiff is the difference of a table which is in both schemas. A changed index or foreign key is
+// reported as removed and added.
+type TableDiff struct {
+	name               string
+	addedColumns       []DBColumn
+	removedColumns     []DBColumn
+	changedColumns     []ColumnDiff
+	addedIndexes       []DBIndex
+	removedIndexes     []DBIndex
+	addedForeignKeys   []DBForeignKey
+	removedForeignKeys []DBForeignKey
+	fromPrimaryKey     []string
+	toPrimaryKey       []string
+}
+
+func (t *TableDiff) GetName() string {
+	return t.name
+}
+
+func (t *TableDiff) GetAddedColumns() []DBColumn {
+	return t.addedColumns
+}
+
+func (t *TableDiff) GetRemovedColumns() []DBColumn {
+	return t.removedColumns
+}
+
+func (t *TableDiff) GetChangedColumns() []ColumnDiff {
+	return t.changedColumns
+}
+
+func (t *TableDiff) GetAddedIndexes() []DBIndex {
+	return t.addedIndexes
+}
+
+func (t *TableDiff) GetRemovedIndexes() []DBIndex {
+	return t.removedIndexes
+}
+
+func (t *TableDiff) GetAddedForeignKeys() []DBForeignKey {
+	return t.addedForeignKeys
+}
+
+func (t *TableDiff) GetRemovedForeignKeys() []DBForeignKey {
+	return t.removedForeignKeys
+}
+
+// GetPrimaryKeyChange returns the current and the desired primary key columns and whether they differ.
+func (t *TableDiff) GetPrimaryKeyChange() ([]string, []string, bool) {
+	return t.fromPrimaryKey, t.toPrimaryKey, !slices.Equal(t.fromPrimaryKey, t.toPrimaryKey)
+}
+
+func (t *TableDiff) hasChanges() bool {
+	_, _, primaryKeyChanged := t.GetPrimaryKeyChange()
+	return len(t.addedColumns) > 0 || len(t.removedColumns) > 0 || len(t.changedColumns) > 0 ||
+		len(t.addedIndexes) > 0 || len(t.removedIndexes) > 0 ||
+		len(t.addedForeignKeys) > 0 || len(t.removedForeignKeys) > 0 || primaryKeyChanged
+}
+
+// ColumnDiff is a column whose definition differs between the schemas.
+type ColumnDiff struct {
+	from    DBColumn
+	to      DBColumn
+	changes []ColumnChange
+}
+
+func (c *ColumnDiff) GetName() string {
+	return c.to.name
+}
+
+// GetFrom returns the column of the current schema.
+func (c *ColumnDiff) GetFrom() DBColumn {
+	return c.from
+}
+
+// GetTo returns the column of the desired schema.
+func (c *ColumnDiff) GetTo() DBColumn {
+	return c.to
+}
+
+func (c *ColumnDiff) GetChanges() []ColumnChange {
+	return c.changes
+}
+
+// EntitySchema returns the schema of the tables of the entities for the dialect, as CreateTableSQL derives
+// them. The entities are values or pointers of the entity types, e.g. EntitySchema(dialect, User{}, &Order{}).
+func EntitySchema(dialect Dialect, entities ...interface{}) (*DBSchema, error) {
+	schema := &DBSchema{
+		tables: make([]DBTableSchema, 0, len(entities)),
+		views:  make([]DBView, 0),
+	}
+
+	for _, entity := range entities {
+		table, err := entityTableSchema(reflect.TypeOf(entity), dialect)
+		if err != nil {
+			return nil, err
+		}
+
+		schema.tables = append(schema.tables, *table)
+	}
+
+	return schema, nil
+}
+
+// DiffSchemas compares the current schema with the desired schema; the changes turn the current schema
+// into the desired one.
+//
+// The tables and the columns are matched by name. The types are compared by their normalized names, so
+// the VARCHAR(320) of an entity matches the varchar column of length 320 reported by the database. The
+// indexes are matched by name case insensitively and the foreign keys by their columns and referenced
+// table, since SQLite does not report their names. The index MySQL creates for a foreign key is ignored.
+func DiffSchemas(from *DBSchema, to *DBSchema) *SchemaDiff {
+	diff := &SchemaDiff{
+		addedTables:   make([]DBTableSchema, 0),
+		removedTables: make([]DBTableSchema, 0),
+		changedTables: make([]TableDiff, 0),
+	}
+
+	for i := range to.tables {
+		current, ok := from.GetTable(to.tables[i].name)
+		if !ok {
+			diff.addedTables = append(diff.addedTables, to.tables[i])
+			continue
+		}
+
+		if table := diffTables(current, &to.tables[i]); table.hasChanges() {
+			diff.changedTables = append(diff.changedTables, table)
+		}
+	}
+
+	for i := range from.tables {
+		if _, ok := to.GetTable(from.tables[i].name); !ok {
+			diff.removedTables = append(diff.removedTables, from.tables[i])
+		}
+	}
+
+	return diff
+}
+
+func diffTables(from *DBTableSchema, to *DBTableSchema) TableDiff {
+	diff := TableDiff{
+		name:           to.name,
+		fromPrimaryKey: from.primaryKey,
+		toPrimaryKey:   to.primaryKey,
+	}
+
+	for i := range to.columns {
+		current, ok := from.GetColumn(to.columns[i].name)
+		if !ok {
+			diff.addedColumns = append(diff.addedColumns, to.columns[i])
+			continue
+		}
+
+		if changes := diffColumns(current, &to.columns[i]); len(changes) > 0 {
+			diff.changedColumns = append(diff.changedColumns, ColumnDiff{from: *current, to: to.columns[i], changes: changes})
+		}
+	}
+
+	for i := range from.columns {
+		if _, ok := to.GetColumn(from.columns[i].name); !ok {
+			diff.removedColumns = append(diff.removedColumns, from.columns[i])
+		}
+	}
+
+	for i := range to.indexes {
+		current, ok := findIndex(from, to.indexes[i].name)
+		if !ok {
+			diff.addedIndexes = append(diff.addedIndexes, to.indexes[i])
+			continue
+		}
+
+		if current.unique != to.indexes[i].unique || !equalNames(current.columns, to.indexes[i].columns) {
+			diff.removedIndexes = append(diff.removedIndexes, *current)
+			diff.addedIndexes = append(diff.addedIndexes, to.indexes[i])
+		}
+	}
+
+	for i := range from.indexes {
+		if _, ok := findIndex(to, from.indexes[i].name); !ok && !isForeignKeyIndex(from, &from.indexes[i]) {
+			diff.removedIndexes = append(diff.removedIndexes, from.indexes[i])
+		}
+	}
+
+	for i := range to.foreignKeys {
+		current, ok := findForeignKey(from, &to.foreignKeys[i])
+		if !ok {
+			diff.addedForeignKeys = append(diff.addedForeignKeys, to.foreignKeys[i])
+			continue
+		}
+
+		if referentialAction(current.onDelete) != referentialAction(to.foreignKeys[i].onDelete) ||
+			referentialAction(current.onUpdate) != referentialAction(to.foreignKeys[i].onUpdate) {
+			diff.removedForeignKeys = append(diff.removedForeignKeys, *current)
+			diff.addedForeignKeys = append(diff.addedForeignKeys, to.foreignKeys[i])
+		}
+	}
+
+	for i := range from.foreignKeys {
+		if _, ok := findForeignKey(to, &from.foreignKeys[i]); !ok {
+			diff.removedForeignKeys = append(diff.removedForeignKeys, from.foreignKeys[i])
+		}
+	}
+
+	return diff
+}
+
+// diffColumns returns the changed properties of the column. The default of an auto increment column is
+// not compared; PostgreSQL reports the sequence of the column as its default.
+func diffColumns(from *DBColumn, to *DBColumn) []ColumnChange {
+	var changes []ColumnChange
+	if columnTypeKey(from) != columnTypeKey(to) {
+		changes = append(changes, ColumnChangeType)
+	}
+
+	if from.nullable != to.nullable {
+		changes = append(changes, ColumnChangeNullable)
+	}
+
+	if !from.autoIncrement && !to.autoIncrement && defaultKey(from.defaultValue) != defaultKey(to.defaultValue) {
+		changes = append(changes, ColumnChangeDefault)
+	}
+
+	if from.autoIncrement != to.autoIncrement {
+		changes = append(changes, ColumnChangeAutoIncrement)
+	}
+
+	return changes
+}
+
+// columnTypeKey returns the lower case type of the column with the type aliases resolved. The length is
+// kept for the sized types and the precision and the scale for the numeric types; the arguments of the
+// other types, e.g. the display width of INT(11) or the fractional seconds of DATETIME(6), are dropped.
+func columnTypeKey(column *DBColumn) string {
+	sqlType := columnType(column)
+	name, arguments := parseDeclaredType(sqlType)
+	name = strings.ToLower(strings.Join(strings.Fields(name), " "))
+	if alias, ok := typeAliases[name]; ok {
+		name = alias
+	}
+
+	switch {
+	case sizedTypes[name] && strings.Contains(strings.ToUpper(sqlType), "(MAX)"):
+		return name + "(max)"
+	case sizedTypes[name] && len(arguments) > 0:
+		return name + "(" + strconv.FormatInt(arguments[0], 10) + ")"
+	case numericTypes[name] && len(arguments) > 0:
+		scale := int64(0)
+		if len(arguments) > 1 {
+			scale = arguments[1]
+		}
+
+		return name + "(" + strconv.FormatInt(arguments[0], 10) + "," + strconv.FormatInt(scale, 10) + ")"
+	}
+
+	return name
+}
+
+// defaultKey returns the default expression without the parentheses SQL Server wraps it in, the casts
+// PostgreSQL adds and the quotes of the string literals, which MySQL does not report; the keys are lower case.
+func defaultKey(value *string) string {
+	if value == nil {
+		return ""
+	}
+
+	key := strings.TrimSpace(*value)
+	for {
+		stripped := stripParentheses(key)
+		if cast := strings.LastIndex(stripped, "::"); cast > 0 && cast > strings.LastIndex(stripped, "'") {
+			stripped = strings.TrimSpace(stripped[:cast])
+		}
+
+		if stripped == key {
+			break
+		}
+
+		key = stripped
+	}
+
+	if len(key) >= 2 && key[0] == '\'' && key[len(key)-1] == '\'' {
+		key = strings.ReplaceAll(key[1:len(key)-1], "''", "'")
+	}
+
+	return strings.ToLower(key)
+}
+
+// stripParentheses removes the parentheses which enclose the whole expression.
+func stripParentheses(expression string) string {
+	if len(expression) < 2 || expression[0] != '(' || expression[len(expression)-1] != ')' {
+		return expression
+	}
+
+	depth := 0
+	for i, c := range expression {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 && i < len(expression)-1 {
+				return expression
+			}
+		}
+	}
+
+	return strings.TrimSpace(expression[1 : len(expression)-1])
+}
+
+// referentialAction returns the upper case action; NO ACTION and RESTRICT, the defaults, are empty.
+func referentialAction(action string) string {
+	action = strings.ToUpper(strings.TrimSpace(action))
+	if action == "NO ACTION" || action == "RESTRICT" {
+		return ""
+	}
+
+	return action
+}
+
+func findIndex(table *DBTableSchema, name string) (*DBIndex, bool) {
+	for i := range table.indexes {
+		if strings.EqualFold(table.indexes[i].name, name) {
+			return &table.indexes[i], true
+		}
+	}
+
+	return nil, false
+}
+
+// isForeignKeyIndex returns whether the index has the name of a foreign key of the table, as the index
+// MySQL creates for a foreign key does.
+func isForeignKeyIndex(table *DBTableSchema, index *DBIndex) bool {
+	for _, foreignKey := range table.foreignKeys {
+		if foreignKey.name != "" && strings.EqualFold(foreignKey.name, index.name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func findForeignKey(table *DBTableSchema, foreignKey *DBForeignKey) (*DBForeignKey, bool) {
+	for i := range table.foreignKeys {
+		candidate := &table.foreignKeys[i]
+		if candidate.referencedTable == foreignKey.referencedTable && equalNames(candidate.columns, foreignKey.columns) &&
+			equalNames(candidate.referencedColumns, foreignKey.referencedColumns) {
+			return candidate, true
+		}
+	}
+
+	return nil, false
+}
+
+// equalNames compares the column names case insensitively and in order.
+func equalNames(a []string, b []string) bool {
+	return slices.EqualFunc(a, b, strings.EqualFold)
+}
+
+// SQL returns the statements which turn the current schema into the desired one for the dialect.
+//
+// The foreign keys and the indexes are dropped first, then the tables are created without their foreign
+// keys, the columns are added, altered and dropped, the indexes and all the foreign keys are created and
+// the removed tables are dropped last; the tables may reference each other in cycles. The changes which
+// the dialect cannot make with an ALTER statement return an error: SQLite cannot alter columns, primary
+// keys or foreign keys, and PostgreSQL and SQL Server cannot change primary keys, whose constraint names
+// are not inspected. SQL Server cannot change defaults for the same reason. The data of the dropped
+// columns and tables is lost; review the statements first.
+func (d *SchemaDiff) SQL(dialect Dialect) ([]string, error) {
+	switch dialect {
+	case DialectMySQL, DialectPostgres, DialectSQLite, DialectSQLServer:
+	default:
+		return nil, db_errors.DDLUnsupportedDialectError()
+	}
+
+	statements := make([]string, 0)
+
+	for i := range d.changedTables {
+		table := &d.changedTables[i]
+		for j := range table.removedForeignKeys {
+			statement, err := dropForeignKeyStatement(dialect, table.name, &table.removedForeignKeys[j])
+			if err != nil {
+				return nil, err
+			}
+
+			statements = append(statements, statement)
+		}
+	}
+
+	for i := range d.changedTables {
+		table := &d.changedTables[i]
+		for j := range table.removedIndexes {
+			statements = append(statements, dropIndexStatement(dialect, table.name, &table.removedIndexes[j]))
+		}
+	}
+
+	// The foreign keys of the added tables are added after all the tables exist, so that the tables can
+	// reference each other in any order and in cycles. SQLite cannot add them later; it checks the references
+	// only when the rows are written, so they stay in its CREATE TABLE statements.
+	foreignKeys := make([]string, 0)
+	for _, table := range sortByForeignKeys(d.addedTables) {
+		if dialect != DialectSQLite {
+			for i := range table.foreignKeys {
+				foreignKeys = append(foreignKeys, addForeignKeyStatement(dialect, table.name, &table.foreignKeys[i]))
+			}
+
+			table.foreignKeys = nil
+		}
+
+		statements = append(statements, createTableStatements(dialect, &table)...)
+	}
+
+	for i := range d.changedTables {
+		table := &d.changedTables[i]
+		for j := range table.addedColumns {
+			statements = append(statements, addColumnStatement(dialect, table.name, &table.addedColumns[j]))
+		}
+
+		for j := range table.changedColumns {
+			altered, err := alterColumnStatements(dialect, table.name, &table.changedColumns[j])
+			if err != nil {
+				return nil, err
+			}
+
+			statements = append(statements, altered...)
+		}
+
+		if from, to, changed := table.GetPrimaryKeyChange(); changed {
+			statement, err := alterPrimaryKeyStatement(dialect, table.name, from, to)
+			if err != nil {
+				return nil, err
+			}
+
+			statements = append(statements, statement)
+		}
+
+		for j := range table.removedColumns {
+			statements = append(statements, "ALTER TABLE "+dialect.QuoteIdentifier(table.name)+" DROP COLUMN "+
+				dialect.QuoteIdentifier(table.removedColumns[j].name))
+		}
+	}
+
+	for i := range d.changedTables {
+		table := &d.changedTables[i]
+		for j := range table.addedIndexes {
+			statements = append(statements, createIndexStatement(dialect, table.name, &table.addedIndexes[j]))
+		}
+	}
+
+	statements = append(statements, foreignKeys...)
+
+	for i := range d.changedTables {
+		table := &d.changedTables[i]
+		for j := range table.addedForeignKeys {
+			if dialect == DialectSQLite {
+				return nil, db_errors.DiffUnsupportedChangeError()
+			}
+
+			statements = append(statements, addForeignKeyStatement(dialect, table.name, &table.addedForeignKeys[j]))
+		}
+	}
+
+	removed := sortByForeignKeys(d.removedTables)
+	for i := len(removed) - 1; i >= 0; i-- {
+		statements = append(statements, "DROP TABLE "+dialect.QuoteIdentifier(removed[i].name))
+	}
+
+	return statements, nil
+}
+
+func dropForeignKeyStatement(dialect Dialect, table string, foreignKey *DBForeignKey) (string, error) {
+	if dialect == DialectSQLite || foreignKey.name == "" {
+		return "", db_errors.DiffUnsupportedChangeError()
+	}
+
+	drop := " DROP CONSTRAINT "
+	if dialect == DialectMySQL {
+		drop = " DROP FOREIGN KEY "
+	}
+
+	return "ALTER TABLE " + dialect.QuoteIdentifier(table) + drop + dialect.QuoteIdentifier(foreignKey.name), nil
+}
+
+// dropIndexStatement returns the DROP INDEX statement; the index names of PostgreSQL and SQLite are unique
+// in the schema and are dropped without the table.
+func dropIndexStatement(dialect Dialect, table string, index *DBIndex) string {
+	if dialect == DialectMySQL || dialect == DialectSQLServer {
+		return "DROP INDEX " + dialect.QuoteIdentifier(index.name) + " ON " + dialect.QuoteIdentifier(table)
+	}
+
+	return "DROP INDEX " + dialect.QuoteIdentifier(index.name)
+}
+
+func alterColumnStatements(dialect Dialect, table string, column *ColumnDiff) ([]string, error) {
+	prefix := "ALTER TABLE " + dialect.QuoteIdentifier(table)
+	to := &column.to
+
+	switch dialect {
+	case DialectMySQL:
+		return []string{prefix + " MODIFY COLUMN " + columnDefinition(dialect, to)}, nil
+	case DialectPostgres:
+		prefix += " ALTER COLUMN " + dialect.QuoteIdentifier(to.name)
+
+		statements := make([]string, 0, len(column.changes))
+		for _, change := range column.changes {
+			switch change {
+			case ColumnChangeType:
+				statements = append(statements, prefix+" TYPE "+columnType(to))
+			case ColumnChangeNullable:
+				if to.nullable {
+					statements = append(statements, prefix+" DROP NOT NULL")
+				} else {
+					statements = append(statements, prefix+" SET NOT NULL")
+				}
+			case ColumnChangeDefault:
+				if to.defaultValue == nil {
+					statements = append(statements, prefix+" DROP DEFAULT")
+				} else {
+					statements = append(statements, prefix+" SET DEFAULT "+*to.defaultValue)
+				}
+			default:
+				return nil, db_errors.DiffUnsupportedChangeError()
+			}
+		}
+
+		return statements, nil
+	case DialectSQLServer:
+		if slices.Contains(column.changes, ColumnChangeDefault) || slices.Contains(column.changes, ColumnChangeAutoIncrement) {
+			return nil, db_errors.DiffUnsupportedChangeError()
+		}
+
+		nullable := " NOT NULL"
+		if to.nullable {
+			nullable = " NULL"
+		}
+
+		return []string{prefix + " ALTER COLUMN " + dialect.QuoteIdentifier(to.name) + " " + columnType(to) + nullable}, nil
+	}
+
+	return nil, db_errors.DiffUnsupportedChangeError()
+}
+
+// alterPrimaryKeyStatement returns the statement which replaces the primary key; only MySQL can refer to
+// the primary key without its constraint name.
+func alterPrimaryKeyStatement(dialect Dialect, table string, from []string, to []string) (string, error) {
+	if dialect != DialectMySQL {
+		return "", db_errors.DiffUnsupportedChangeError()
+	}
+
+	changes := make([]string, 0, 2)
+	if len(from) > 0 {
+		changes = append(changes, "DROP PRIMARY KEY")
+	}
+
+	if len(to) > 0 {
+		changes = append(changes, "ADD PRIMARY KEY ("+quoteIdentifiers(dialect, to)+")")
+	}
+
+	return "ALTER TABLE " + dialect.QuoteIdentifier(table) + " " + strings.Join(changes, ", "), nil
+}
+
+// sortByForeignKeys orders the tables so that a referenced table comes before the tables which reference
+// it. The references to the tables out of the list are ignored and the cycles are broken arbitrarily.
+func sortByForeignKeys(tables []DBTableSchema) []DBTableSchema {
+	positions := make(map[string]int, len(tables))
+	for i := range tables {
+		positions[tables[i].name] = i
+	}
+
+	sorted := make([]DBTableSchema, 0, len(tables))
+	visited := make([]bool, len(tables))
+
+	var visit func(i int)
+	visit = func(i int) {
+		if visited[i] {
+			return
+		}
+
+		visited[i] = true
+		for _, foreignKey := range tables[i].foreignKeys {
+			if referenced, ok := positions[foreignKey.referencedTable]; ok {
+				visit(referenced)
+			}
+		}
+
+		sorted = append(sorted, tables[i])
+	}
+
+	for i := range tables {
+		visit(i)
+	}
+
+	return sorted
+}
+
+// String returns the changes as a readable report, one line per change: + for the added, - for the removed
+// and ~ for the changed objects.
+func (d *SchemaDiff) String() string {
+	var b strings.Builder
+	for _, table := range d.addedTables {
+		fmt.Fprintf(&b, "+ table %s\n", table.name)
+	}
+
+	for _, table := range d.removedTables {
+		fmt.Fprintf(&b, "- table %s\n", table.name)
+	}
+
+	for _, table := range d.changedTables {
+		fmt.Fprintf(&b, "~ table %s\n", table.name)
+		for _, column := range table.addedColumns {
+			fmt.Fprintf(&b, "  + column %s %s\n", column.name, columnType(&column))
+		}
+
+		for _, column := range table.removedColumns {
+			fmt.Fprintf(&b, "  - column %s\n", column.name)
+		}
+
+		for _, column := range table.changedColumns {
+			changes := make([]string, 0, len(column.changes))
+			for _, change := range column.changes {
+				changes = append(changes, string(change))
+			}
+
+			fmt.Fprintf(&b, "  ~ column %s (%s): %s -> %s\n", column.to.name, strings.Join(changes, ", "),
+				columnSummary(&column.from), columnSummary(&column.to))
+		}
+
+		if from, to, changed := table.GetPrimaryKeyChange(); changed {
+			fmt.Fprintf(&b, "  ~ primary key (%s) -> (%s)\n", strings.Join(from, ", "), strings.Join(to, ", "))
+		}
+
+		for _, index := range table.removedIndexes {
+			fmt.Fprintf(&b, "  - index %s\n", index.name)
+		}
+
+		for _, index := range table.addedIndexes {
+			fmt.Fprintf(&b, "  + index %s (%s)\n", index.name, strings.Join(index.columns, ", "))
+		}
+
+		for _, foreignKey := range table.removedForeignKeys {
+			fmt.Fprintf(&b, "  - foreign key %s (%s) -> %s\n", foreignKey.name, strings.Join(foreignKey.columns, ", "), foreignKey.referencedTable)
+		}
+
+		for _, foreignKey := range table.addedForeignKeys {
+			fmt.Fprintf(&b, "  + foreign key %s (%s) -> %s\n", foreignKey.name, strings.Join(foreignKey.columns, ", "), foreignKey.referencedTable)
+		}
+	}
+
+	return b.String()
+}
+
+// columnSummary returns the type, the nullability and the default of the column for the report.
+func columnSummary(column *DBColumn) string {
+	summary := columnType(column)
+	if column.nullable {
+		summary += " NULL"
+	} else {
+		summary += " NOT NULL"
+	}
+
+	if column.defaultValue != nil {
+		summary += " DEFAULT " + *column.defaultValue
+	}
+
+	if column.autoIncrement {
+		summary += " AUTO"
+	}
+
+	return summary
+}
@@ -0,0 +1,402 @@
+package db
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	db_errors "github.com/fatihtatoglu/db-go/error"
+)
+
+func createTestSchema(tables ...DBTableSchema) *DBSchema {
+	return &DBSchema{tables: tables}
+}
+
+func TestDiffSchemas_EntityMatchesInspectedTable(t *testing.T) {
+	// Arrange
+	desired, err := EntitySchema(DialectPostgres, testDDLCustomer{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	current := createTestSchema(DBTableSchema{
+		name: "customers",
+		columns: []DBColumn{
+			CreateNewDBColumnFromSchema("id", 1, "bigint", 0, false, WithColumnAutoIncrement(), WithColumnDefault("nextval('customers_id_seq'::regclass)")),
+			CreateNewDBColumnFromSchema("email", 2, "character varying", 320, false),
+			CreateNewDBColumnFromSchema("name", 3, "text", 0, false),
+			CreateNewDBColumnFromSchema("city", 4, "character varying", 100, true),
+			CreateNewDBColumnFromSchema("balance", 5, "numeric", 0, false, WithColumnPrecision(10, 2), WithColumnDefault("(0)::numeric")),
+			CreateNewDBColumnFromSchema("active", 6, "boolean", 0, false),
+			CreateNewDBColumnFromSchema("note", 7, "text", 0, true),
+			CreateNewDBColumnFromSchema("photo", 8, "bytea", 0, false),
+			CreateNewDBColumnFromSchema("created_at", 9, "timestamp without time zone", 0, false),
+		},
+		primaryKey: []string{"id"},
+		indexes: []DBIndex{
+			CreateNewDBIndex("ux_customers_email", []string{"email"}, true),
+			CreateNewDBIndex("ix_customers_name_city", []string{"name", "city"}, false),
+		},
+	})
+
+	// Act
+	diff := DiffSchemas(current, desired)
+
+	// Assert
+	if diff.HasChanges() {
+		t.Errorf("Expected no changes, got:\n%s", diff.String())
+	}
+}
+
+func TestDiffSchemas_ReportsChanges(t *testing.T) {
+	// Arrange
+	current := createTestSchema(
+		DBTableSchema{
+			name: "users",
+			columns: []DBColumn{
+				CreateNewDBColumnFromSchema("id", 1, "int", 0, false, WithColumnAutoIncrement()),
+				CreateNewDBColumnFromSchema("email", 2, "varchar", 100, false),
+				CreateNewDBColumnFromSchema("fax", 3, "varchar", 20, true),
+				CreateNewDBColumnFromSchema("status", 4, "int", 0, false, WithColumnDefault("0")),
+			},
+			primaryKey: []string{"id"},
+			indexes: []DBIndex{
+				CreateNewDBIndex("ix_users_email", []string{"email"}, false),
+				CreateNewDBIndex("ix_users_fax", []string{"fax"}, false),
+				CreateNewDBIndex("fk_users_team", []string{"team_id"}, false),
+			},
+			foreignKeys: []DBForeignKey{
+				{name: "fk_users_team", columns: []string{"team_id"}, referencedTable: "teams", referencedColumns: []string{"id"}, onDelete: "RESTRICT"},
+			},
+		},
+		DBTableSchema{name: "legacy"},
+	)
+
+	desired := createTestSchema(
+		DBTableSchema{
+			name: "users",
+			columns: []DBColumn{
+				CreateNewDBColumnFromSchema("id", 1, "INT", 0, false, WithColumnAutoIncrement()),
+				CreateNewDBColumnFromSchema("email", 2, "VARCHAR(320)", 320, false),
+				CreateNewDBColumnFromSchema("status", 3, "INT", 0, true, WithColumnDefault("1")),
+				CreateNewDBColumnFromSchema("team_id", 4, "BIGINT", 0, true),
+			},
+			primaryKey: []string{"id"},
+			indexes: []DBIndex{
+				CreateNewDBIndex("IX_USERS_EMAIL", []string{"email"}, true),
+			},
+			foreignKeys: []DBForeignKey{
+				{name: "fk_users_team", columns: []string{"team_id"}, referencedTable: "teams", referencedColumns: []string{"id"}},
+				CreateNewDBForeignKey("fk_users_org", []string{"org_id"}, "orgs", []string{"id"}),
+			},
+		},
+		DBTableSchema{name: "teams"},
+	)
+
+	// Act
+	diff := DiffSchemas(current, desired)
+
+	// Assert
+	if len(diff.GetAddedTables()) != 1 || diff.GetAddedTables()[0].GetName() != "teams" {
+		t.Errorf("Expected the teams table to be added, got: %v", diff.GetAddedTables())
+	}
+
+	if len(diff.GetRemovedTables()) != 1 || diff.GetRemovedTables()[0].GetName() != "legacy" {
+		t.Errorf("Expected the legacy table to be removed, got: %v", diff.GetRemovedTables())
+	}
+
+	if len(diff.GetChangedTables()) != 1 {
+		t.Fatalf("Expected one changed table, got: %d", len(diff.GetChangedTables()))
+	}
+
+	table := diff.GetChangedTables()[0]
+	if len(table.GetAddedColumns()) != 1 || table.GetAddedColumns()[0].GetName() != "team_id" {
+		t.Errorf("Expected the team_id column to be added, got: %v", table.GetAddedColumns())
+	}
+
+	if len(table.GetRemovedColumns()) != 1 || table.GetRemovedColumns()[0].GetName() != "fax" {
+		t.Errorf("Expected the fax column to be removed, got: %v", table.GetRemovedColumns())
+	}
+
+	changed := table.GetChangedColumns()
+	if len(changed) != 2 || changed[0].GetName() != "email" || changed[1].GetName() != "status" {
+		t.Fatalf("Expected the email and status columns to be changed, got: %v", changed)
+	}
+
+	if !reflect.DeepEqual(changed[0].GetChanges(), []ColumnChange{ColumnChangeType}) {
+		t.Errorf("Expected the type of email to be changed, got: %v", changed[0].GetChanges())
+	}
+
+	if !reflect.DeepEqual(changed[1].GetChanges(), []ColumnChange{ColumnChangeNullable, ColumnChangeDefault}) {
+		t.Errorf("Expected the nullability and the default of status to be changed, got: %v", changed[1].GetChanges())
+	}
+
+	removedIndexes := make([]string, 0)
+	for _, index := range table.GetRemovedIndexes() {
+		removedIndexes = append(removedIndexes, index.GetName())
+	}
+
+	if !slices.Equal(removedIndexes, []string{"ix_users_email", "ix_users_fax"}) {
+		t.Errorf("Expected the changed and the removed indexes to be removed, got: %v", removedIndexes)
+	}
+
+	if len(table.GetAddedIndexes()) != 1 || !table.GetAddedIndexes()[0].GetUnique() {
+		t.Errorf("Expected the unique index to be added, got: %v", table.GetAddedIndexes())
+	}
+
+	if len(table.GetRemovedForeignKeys()) != 0 || len(table.GetAddedForeignKeys()) != 1 {
+		t.Errorf("Expected only the org foreign key to be added, got: -%v +%v", table.GetRemovedForeignKeys(), table.GetAddedForeignKeys())
+	}
+
+	if _, _, changedKey := table.GetPrimaryKeyChange(); changedKey {
+		t.Error("Expected the primary key to be unchanged")
+	}
+}
+
+func TestSchemaDiff_SQL(t *testing.T) {
+	// Arrange
+	current := createTestSchema(
+		DBTableSchema{
+			name: "users",
+			columns: []DBColumn{
+				CreateNewDBColumnFromSchema("id", 1, "bigint", 0, false),
+				CreateNewDBColumnFromSchema("email", 2, "varchar", 100, false),
+				CreateNewDBColumnFromSchema("fax", 3, "varchar", 20, true),
+			},
+			primaryKey: []string{"id"},
+			indexes:    []DBIndex{CreateNewDBIndex("ix_users_fax", []string{"fax"}, false)},
+		},
+		DBTableSchema{
+			name:        "old_sessions",
+			foreignKeys: []DBForeignKey{CreateNewDBForeignKey("fk_old_sessions_user", []string{"user_id"}, "old_users", []string{"id"})},
+		},
+		DBTableSchema{name: "old_users"},
+	)
+
+	desired := createTestSchema(
+		DBTableSchema{
+			name: "users",
+			columns: []DBColumn{
+				CreateNewDBColumnFromSchema("id", 1, "BIGINT", 0, false),
+				CreateNewDBColumnFromSchema("email", 2, "VARCHAR(320)", 320, false),
+				CreateNewDBColumnFromSchema("team_id", 3, "BIGINT", 0, true),
+			},
+			primaryKey:  []string{"id"},
+			indexes:     []DBIndex{CreateNewDBIndex("ix_users_team", []string{"team_id"}, false)},
+			foreignKeys: []DBForeignKey{CreateNewDBForeignKey("fk_users_team", []string{"team_id"}, "teams", []string{"id"})},
+		},
+		DBTableSchema{
+			name:        "memberships",
+			columns:     []DBColumn{CreateNewDBColumnFromSchema("team_id", 1, "BIGINT", 0, false)},
+			foreignKeys: []DBForeignKey{CreateNewDBForeignKey("fk_memberships_team", []string{"team_id"}, "teams", []string{"id"})},
+		},
+		DBTableSchema{
+			name:       "teams",
+			columns:    []DBColumn{CreateNewDBColumnFromSchema("id", 1, "BIGINT", 0, false)},
+			primaryKey: []string{"id"},
+		},
+	)
+
+	diff := DiffSchemas(current, desired)
+
+	// Test cases
+	testCases := []struct {
+		name     string
+		dialect  Dialect
+		expected []string
+	}{
+		{
+			name:    "MySQL",
+			dialect: DialectMySQL,
+			expected: []string{
+				"DROP INDEX `ix_users_fax` ON `users`",
+				"CREATE TABLE `teams` (`id` BIGINT NOT NULL, PRIMARY KEY (`id`))",
+				"CREATE TABLE `memberships` (`team_id` BIGINT NOT NULL)",
+				"ALTER TABLE `users` ADD COLUMN `team_id` BIGINT NULL",
+				"ALTER TABLE `users` MODIFY COLUMN `email` VARCHAR(320) NOT NULL",
+				"ALTER TABLE `users` DROP COLUMN `fax`",
+				"CREATE INDEX `ix_users_team` ON `users` (`team_id`)",
+				"ALTER TABLE `memberships` ADD CONSTRAINT `fk_memberships_team` FOREIGN KEY (`team_id`) REFERENCES `teams` (`id`)",
+				"ALTER TABLE `users` ADD CONSTRAINT `fk_users_team` FOREIGN KEY (`team_id`) REFERENCES `teams` (`id`)",
+				"DROP TABLE `old_sessions`",
+				"DROP TABLE `old_users`",
+			},
+		},
+		{
+			name:    "PostgreSQL",
+			dialect: DialectPostgres,
+			expected: []string{
+				`DROP INDEX "ix_users_fax"`,
+				`CREATE TABLE "teams" ("id" BIGINT NOT NULL, PRIMARY KEY ("id"))`,
+				`CREATE TABLE "memberships" ("team_id" BIGINT NOT NULL)`,
+				`ALTER TABLE "users" ADD COLUMN "team_id" BIGINT NULL`,
+				`ALTER TABLE "users" ALTER COLUMN "email" TYPE VARCHAR(320)`,
+				`ALTER TABLE "users" DROP COLUMN "fax"`,
+				`CREATE INDEX "ix_users_team" ON "users" ("team_id")`,
+				`ALTER TABLE "memberships" ADD CONSTRAINT "fk_memberships_team" FOREIGN KEY ("team_id") REFERENCES "teams" ("id")`,
+				`ALTER TABLE "users" ADD CONSTRAINT "fk_users_team" FOREIGN KEY ("team_id") REFERENCES "teams" ("id")`,
+				`DROP TABLE "old_sessions"`,
+				`DROP TABLE "old_users"`,
+			},
+		},
+		{
+			name:    "SQLServer",
+			dialect: DialectSQLServer,
+			expected: []string{
+				"DROP INDEX [ix_users_fax] ON [users]",
+				"CREATE TABLE [teams] ([id] BIGINT NOT NULL, PRIMARY KEY ([id]))",
+				"CREATE TABLE [memberships] ([team_id] BIGINT NOT NULL)",
+				"ALTER TABLE [users] ADD [team_id] BIGINT NULL",
+				"ALTER TABLE [users] ALTER COLUMN [email] VARCHAR(320) NOT NULL",
+				"ALTER TABLE [users] DROP COLUMN [fax]",
+				"CREATE INDEX [ix_users_team] ON [users] ([team_id])",
+				"ALTER TABLE [memberships] ADD CONSTRAINT [fk_memberships_team] FOREIGN KEY ([team_id]) REFERENCES [teams] ([id])",
+				"ALTER TABLE [users] ADD CONSTRAINT [fk_users_team] FOREIGN KEY ([team_id]) REFERENCES [teams] ([id])",
+				"DROP TABLE [old_sessions]",
+				"DROP TABLE [old_users]",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			statements, err := diff.SQL(tc.dialect)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(statements, tc.expected) {
+				t.Errorf("Expected:\n%s\ngot:\n%s", strings.Join(tc.expected, "\n"), strings.Join(statements, "\n"))
+			}
+		})
+	}
+}
+
+func TestSchemaDiff_SQL_ForeignKeyCycle(t *testing.T) {
+	// Arrange
+	desired := createTestSchema(
+		DBTableSchema{
+			name:        "employees",
+			columns:     []DBColumn{CreateNewDBColumnFromSchema("id", 1, "BIGINT", 0, false), CreateNewDBColumnFromSchema("department_id", 2, "BIGINT", 0, true)},
+			primaryKey:  []string{"id"},
+			foreignKeys: []DBForeignKey{CreateNewDBForeignKey("fk_employees_department", []string{"department_id"}, "departments", []string{"id"})},
+		},
+		DBTableSchema{
+			name:        "departments",
+			columns:     []DBColumn{CreateNewDBColumnFromSchema("id", 1, "BIGINT", 0, false), CreateNewDBColumnFromSchema("manager_id", 2, "BIGINT", 0, true)},
+			primaryKey:  []string{"id"},
+			foreignKeys: []DBForeignKey{CreateNewDBForeignKey("fk_departments_manager", []string{"manager_id"}, "employees", []string{"id"})},
+		},
+	)
+
+	diff := DiffSchemas(createTestSchema(), desired)
+
+	// Act
+	postgres, postgresErr := diff.SQL(DialectPostgres)
+	sqlite, sqliteErr := diff.SQL(DialectSQLite)
+
+	// Assert
+	if postgresErr != nil || sqliteErr != nil {
+		t.Fatalf("Unexpected errors: %v %v", postgresErr, sqliteErr)
+	}
+
+	expected := []string{
+		`CREATE TABLE "departments" ("id" BIGINT NOT NULL, "manager_id" BIGINT NULL, PRIMARY KEY ("id"))`,
+		`CREATE TABLE "employees" ("id" BIGINT NOT NULL, "department_id" BIGINT NULL, PRIMARY KEY ("id"))`,
+		`ALTER TABLE "departments" ADD CONSTRAINT "fk_departments_manager" FOREIGN KEY ("manager_id") REFERENCES "employees" ("id")`,
+		`ALTER TABLE "employees" ADD CONSTRAINT "fk_employees_department" FOREIGN KEY ("department_id") REFERENCES "departments" ("id")`,
+	}
+	if !reflect.DeepEqual(postgres, expected) {
+		t.Errorf("Expected:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(postgres, "\n"))
+	}
+
+	if len(sqlite) != 2 || !strings.Contains(sqlite[0], "CONSTRAINT") || !strings.Contains(sqlite[1], "CONSTRAINT") {
+		t.Errorf("Expected the foreign keys to stay in the CREATE TABLE statements of SQLite, got:\n%s", strings.Join(sqlite, "\n"))
+	}
+}
+
+func TestSchemaDiff_SQL_UnsupportedChanges(t *testing.T) {
+	// Arrange
+	current := createTestSchema(DBTableSchema{
+		name:       "users",
+		columns:    []DBColumn{CreateNewDBColumnFromSchema("id", 1, "INTEGER", 0, false), CreateNewDBColumnFromSchema("tenant", 2, "TEXT", 0, false)},
+		primaryKey: []string{"id"},
+	})
+
+	desired := createTestSchema(DBTableSchema{
+		name:       "users",
+		columns:    []DBColumn{CreateNewDBColumnFromSchema("id", 1, "INTEGER", 0, false), CreateNewDBColumnFromSchema("tenant", 2, "TEXT", 0, false)},
+		primaryKey: []string{"tenant", "id"},
+	})
+
+	diff := DiffSchemas(current, desired)
+
+	// Act
+	_, sqliteErr := diff.SQL(DialectSQLite)
+	_, postgresErr := diff.SQL(DialectPostgres)
+	_, dialectErr := diff.SQL(DialectUnknown)
+	statements, mysqlErr := diff.SQL(DialectMySQL)
+
+	// Assert
+	assertError(t, sqliteErr, db_errors.Diff_UnsupportedChangeErrorMessage)
+	assertError(t, postgresErr, db_errors.Diff_UnsupportedChangeErrorMessage)
+	assertError(t, dialectErr, db_errors.DDL_UnsupportedDialectErrorMessage)
+
+	expected := []string{"ALTER TABLE `users` DROP PRIMARY KEY, ADD PRIMARY KEY (`tenant`, `id`)"}
+	if mysqlErr != nil || !reflect.DeepEqual(statements, expected) {
+		t.Errorf("Expected %v, got: %v %v", expected, statements, mysqlErr)
+	}
+}
+
+func TestDefaultKey(t *testing.T) {
+	// Test cases
+	testCases := []struct {
+		value    string
+		expected string
+	}{
+		{value: "((0))", expected: "0"},
+		{value: "'active'::character varying", expected: "active"},
+		{value: "'it''s'", expected: "it's"},
+		{value: "CURRENT_TIMESTAMP", expected: "current_timestamp"},
+		{value: "(now())", expected: "now()"},
+		{value: "(1) + (2)", expected: "(1) + (2)"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			// Act
+			key := defaultKey(&tc.value)
+
+			// Assert
+			if key != tc.expected {
+				t.Errorf("Expected %q, got: %q", tc.expected, key)
+			}
+		})
+	}
+}
+
+func TestSchemaDiff_String(t *testing.T) {
+	// Arrange
+	current := createTestSchema(DBTableSchema{
+		name:    "users",
+		columns: []DBColumn{CreateNewDBColumnFromSchema("email", 1, "varchar", 100, false)},
+	})
+
+	desired := createTestSchema(DBTableSchema{
+		name:    "users",
+		columns: []DBColumn{CreateNewDBColumnFromSchema("email", 1, "varchar", 320, true)},
+	})
+
+	// Act
+	report := DiffSchemas(current, desired).String()
+
+	// Assert
+	expected := "~ table users\n  ~ column email (type, nullable): varchar(100) NOT NULL -> varchar(320) NULL\n"
+	if report != expected {
+		t.Errorf("Expected %q, got: %q", expected, report)
+	}
+}
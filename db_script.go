@@ -0,0 +1,468 @@
+package db
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	db_errors "github.com/fatihtatoglu/db-go/error"
+)
+
+var (
+	// goSeparator matches the GO batch separator of SQL Server with its optional repeat count.
+	goSeparator = regexp.MustCompile(`(?i)^GO(?:\s+(\d+))?$`)
+
+	// dollarQuoteTag matches the opening tag of a PostgreSQL dollar quoted string, e.g. $$ or $body$.
+	dollarQuoteTag = regexp.MustCompile(`^\$(?:[A-Za-z_][A-Za-z0-9_]*)?\$`)
+)
+
+// ScriptOption configures ExecuteScript.
+type ScriptOption func(*scriptOptions)
+
+type scriptOptions struct {
+	transaction bool
+}
+
+// WithScriptTransaction runs the script in a transaction which is rolled back when a statement fails.
+// MySQL commits the DDL statements implicitly; they are not rolled back.
+func WithScriptTransaction() ScriptOption {
+	return func(o *scriptOptions) {
+		o.transaction = true
+	}
+}
+
+// ScriptResult is the outcome of ExecuteScript.
+type ScriptResult struct {
+	executed     int
+	rowsAffected int64
+}
+
+// GetExecuted returns the number of the statements which were executed successfully.
+func (r *ScriptResult) GetExecuted() int {
+	return r.executed
+}
+
+func (r *ScriptResult) GetRowsAffected() int64 {
+	return r.rowsAffected
+}
+
+// ScriptStatement is a statement of a script with the line it starts on.
+type ScriptStatement struct {
+	text string
+	line int
+}
+
+func (s ScriptStatement) GetText() string {
+	return s.text
+}
+
+// GetLine returns the 1-based line of the script the statement starts on.
+func (s ScriptStatement) GetLine() int {
+	return s.line
+}
+
+// ScriptError is the error of the statement of a script which starts on the line. The statement is empty
+// when the script could not be split.
+type ScriptError struct {
+	line      int
+	statement string
+	err       error
+}
+
+func (e ScriptError) GetLine() int {
+	return e.line
+}
+
+func (e ScriptError) GetStatement() string {
+	return e.statement
+}
+
+func (e ScriptError) Error() string {
+	return fmt.Sprintf("script: line %d: %v", e.line, e.err)
+}
+
+func (e ScriptError) Unwrap() error {
+	return e.err
+}
+
+// ExecuteScript splits the script into statements with SplitScript and executes them in order. The
+// statements run on a single connection, so the session settings of the script are kept between them.
+// When the command is bound to a transaction, the statements run in that transaction.
+//
+// The first failing statement stops the script and is returned as a ScriptError with its line.
+func (cmd *dbCommand) ExecuteScript(script io.Reader, options ...ScriptOption) (*ScriptResult, error) {
+	opts := scriptOptions{}
+	for _, option := range options {
+		option(&opts)
+	}
+
+	content, err := io.ReadAll(script)
+	if err != nil {
+		return nil, err
+	}
+
+	statements, err := SplitScript(string(content), cmd.GetDialect())
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ScriptResult{}
+	if cmd.tx != nil {
+		return result, cmd.executeStatements(statements, result)
+	}
+
+	pinned, release, err := cmd.pin()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	session := pinned.(*dbCommand)
+	if !opts.transaction {
+		return result, session.executeStatements(statements, result)
+	}
+
+	tx, err := session.Begin()
+	if err != nil {
+		return result, err
+	}
+
+	t := tx.(*dbTransaction)
+	err = t.executeStatements(statements, result)
+	if err != nil {
+		t.Rollback()
+		return result, err
+	}
+
+	return result, t.Commit()
+}
+
+// executeStatements sends the statements as they are; they are not prepared or cached.
+func (cmd *dbCommand) executeStatements(statements []ScriptStatement, result *ScriptResult) error {
+	err := cmd.open()
+	if err != nil {
+		return err
+	}
+	defer cmd.close()
+
+	for _, statement := range statements {
+		res, err := cmd.executor().ExecContext(cmd.ctx, statement.text)
+		if err != nil {
+			return ScriptError{line: statement.line, statement: statement.text, err: err}
+		}
+
+		result.executed++
+
+		n, err := res.RowsAffected()
+		if err == nil {
+			result.rowsAffected += n
+		}
+	}
+
+	return nil
+}
+
+// SplitScript splits the script into the statements of the dialect. The comments before a statement are
+// dropped and the statements without text are skipped.
+//
+// The statements end with a semicolon outside of the strings, the quoted identifiers and the comments.
+// MySQL changes the delimiter with the DELIMITER lines, e.g. for the bodies of the stored procedures, and
+// SQLite keeps the semicolons of a CREATE TRIGGER body up to the END of its BEGIN, counting the CASE
+// expressions which also end with END. PostgreSQL functions are written in dollar quoted strings, and its
+// E'...' strings escape with a backslash. SQL Server scripts are split into batches by the GO lines instead
+// of the semicolons; GO 3 repeats the batch three times.
+func SplitScript(script string, dialect Dialect) ([]ScriptStatement, error) {
+	s := &scriptSplitter{
+		script:     script,
+		dialect:    dialect,
+		delimiter:  ";",
+		line:       1,
+		statements: make([]ScriptStatement, 0),
+	}
+
+	if dialect == DialectSQLServer {
+		s.delimiter = ""
+	}
+
+	return s.split()
+}
+
+type scriptSplitter struct {
+	script    string
+	dialect   Dialect
+	delimiter string
+	pos       int
+	line      int
+	// start is the line of the first token of the current statement, 0 before the token.
+	start   int
+	current strings.Builder
+	// leading holds the first keywords of a SQLite statement, which tell a CREATE TRIGGER statement; depth
+	// counts the BEGIN and CASE keywords of its body which are not closed by an END yet.
+	leading    []string
+	trigger    bool
+	depth      int
+	statements []ScriptStatement
+}
+
+func (s *scriptSplitter) split() ([]ScriptStatement, error) {
+	lineStart := true
+	for s.pos < len(s.script) {
+		if lineStart && s.directive() {
+			continue
+		}
+
+		lineStart = false
+
+		c := s.script[s.pos]
+		rest := s.script[s.pos:]
+
+		var err error
+		switch {
+		case c == '\n':
+			s.write(1, false)
+			lineStart = true
+		case s.delimiter != "" && strings.HasPrefix(rest, s.delimiter) && !s.insideTrigger():
+			s.pos += len(s.delimiter)
+			s.flush(1)
+		case strings.HasPrefix(rest, "--") || (c == '#' && s.dialect == DialectMySQL):
+			end := strings.IndexByte(rest, '\n')
+			if end < 0 {
+				end = len(rest)
+			}
+
+			s.write(end, false)
+		case strings.HasPrefix(rest, "/*"):
+			err = s.blockComment(rest)
+		case c == '\'' || c == '"',
+			c == '`' && (s.dialect == DialectMySQL || s.dialect == DialectSQLite),
+			c == '[' && s.dialect == DialectSQLServer:
+			err = s.quoted(rest)
+		case c == '$' && s.dialect == DialectPostgres:
+			err = s.dollarQuoted(rest)
+		case s.dialect == DialectSQLite && isKeywordStart(c) && (s.pos == 0 || !isIdentifierByte(s.script[s.pos-1])):
+			s.keyword(rest)
+		default:
+			s.write(1, !isScriptSpace(c))
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	s.flush(1)
+
+	return s.statements, nil
+}
+
+// directive handles the DELIMITER lines of MySQL and the GO lines of SQL Server, which are not sent to the
+// database. It returns false when the line is not a directive.
+func (s *scriptSplitter) directive() bool {
+	rest := s.script[s.pos:]
+	end := strings.IndexByte(rest, '\n')
+	if end < 0 {
+		end = len(rest)
+	}
+
+	text := strings.TrimSpace(rest[:end])
+	switch s.dialect {
+	case DialectMySQL:
+		// The delimiter is changed only between the statements, as the mysql client does.
+		if s.start != 0 || len(text) < 10 || !strings.EqualFold(text[:10], "DELIMITER ") {
+			return false
+		}
+
+		s.delimiter = strings.TrimSpace(text[10:])
+	case DialectSQLServer:
+		match := goSeparator.FindStringSubmatch(text)
+		if match == nil {
+			return false
+		}
+
+		count := 1
+		if match[1] != "" {
+			count, _ = strconv.Atoi(match[1])
+		}
+
+		s.flush(count)
+	default:
+		return false
+	}
+
+	s.pos += end
+	if s.pos < len(s.script) {
+		s.pos++
+		s.line++
+	}
+
+	return true
+}
+
+// write consumes n bytes of the script. The bytes before the first significant token of a statement,
+// the white space and the comments, are not kept.
+func (s *scriptSplitter) write(n int, significant bool) {
+	text := s.script[s.pos : s.pos+n]
+	if significant && s.start == 0 {
+		s.start = s.line
+	}
+
+	if s.start != 0 {
+		s.current.WriteString(text)
+	}
+
+	s.line += strings.Count(text, "\n")
+	s.pos += n
+}
+
+// flush ends the current statement; count is the number of times it is run.
+func (s *scriptSplitter) flush(count int) {
+	text := strings.TrimSpace(s.current.String())
+	if s.start != 0 && text != "" {
+		for i := 0; i < count; i++ {
+			s.statements = append(s.statements, ScriptStatement{text: text, line: s.start})
+		}
+	}
+
+	s.current.Reset()
+	s.start = 0
+	s.leading = s.leading[:0]
+	s.trigger = false
+	s.depth = 0
+}
+
+// insideTrigger reports whether the splitter is inside the body of a SQLite CREATE TRIGGER statement,
+// whose semicolons do not end the statement.
+func (s *scriptSplitter) insideTrigger() bool {
+	return s.trigger && s.depth > 0
+}
+
+// keyword consumes a word of a SQLite statement. The BEGIN and CASE keywords of a trigger open a block
+// which is closed by an END; the words after a dot are names, e.g. NEW.end.
+func (s *scriptSplitter) keyword(rest string) {
+	n := 1
+	for n < len(rest) && isIdentifierByte(rest[n]) {
+		n++
+	}
+
+	word := strings.ToUpper(rest[:n])
+	qualified := s.pos > 0 && s.script[s.pos-1] == '.'
+	s.write(n, true)
+
+	if len(s.leading) < 3 {
+		s.leading = append(s.leading, word)
+		s.trigger = isCreateTrigger(s.leading)
+	}
+
+	if !s.trigger || qualified {
+		return
+	}
+
+	switch word {
+	case "BEGIN", "CASE":
+		s.depth++
+	case "END":
+		if s.depth > 0 {
+			s.depth--
+		}
+	}
+}
+
+// isCreateTrigger reports whether the first keywords are the ones of CREATE [TEMP | TEMPORARY] TRIGGER.
+func isCreateTrigger(leading []string) bool {
+	switch {
+	case len(leading) < 2 || leading[0] != "CREATE":
+		return false
+	case leading[1] == "TRIGGER":
+		return true
+	default:
+		return len(leading) == 3 && (leading[1] == "TEMP" || leading[1] == "TEMPORARY") && leading[2] == "TRIGGER"
+	}
+}
+
+// blockComment consumes a /* */ comment; the comments of PostgreSQL nest. The executable comments and the
+// optimizer hints of MySQL are kept as a part of the statement.
+func (s *scriptSplitter) blockComment(rest string) error {
+	significant := s.dialect == DialectMySQL && (strings.HasPrefix(rest, "/*!") || strings.HasPrefix(rest, "/*+"))
+
+	depth := 0
+	for i := 0; i+1 < len(rest); i++ {
+		switch {
+		case rest[i] == '/' && rest[i+1] == '*':
+			if depth == 0 || s.dialect == DialectPostgres {
+				depth++
+			}
+
+			i++
+		case rest[i] == '*' && rest[i+1] == '/':
+			depth--
+			i++
+
+			if depth == 0 {
+				s.write(i+1, significant)
+				return nil
+			}
+		}
+	}
+
+	return ScriptError{line: s.line, err: db_errors.ScriptUnterminatedError()}
+}
+
+// quoted consumes a string or a quoted identifier. A doubled closing quote is a part of the text; MySQL
+// strings and the E” escape strings of PostgreSQL also escape with a backslash.
+func (s *scriptSplitter) quoted(rest string) error {
+	closing := rest[0]
+	if closing == '[' {
+		closing = ']'
+	}
+
+	backslash := s.dialect == DialectMySQL && (closing == '\'' || closing == '"')
+	if s.dialect == DialectPostgres && closing == '\'' && s.pos > 0 && (s.script[s.pos-1] == 'E' || s.script[s.pos-1] == 'e') {
+		backslash = s.pos == 1 || !isIdentifierByte(s.script[s.pos-2])
+	}
+
+	for i := 1; i < len(rest); i++ {
+		switch {
+		case backslash && rest[i] == '\\':
+			i++
+		case rest[i] == closing && i+1 < len(rest) && rest[i+1] == closing:
+			i++
+		case rest[i] == closing:
+			s.write(i+1, true)
+			return nil
+		}
+	}
+
+	return ScriptError{line: s.line, err: db_errors.ScriptUnterminatedError()}
+}
+
+// dollarQuoted consumes a dollar quoted string of PostgreSQL. A $ which does not open a string, e.g. of
+// the $1 parameters or inside an identifier, is a part of the statement.
+func (s *scriptSplitter) dollarQuoted(rest string) error {
+	tag := dollarQuoteTag.FindString(rest)
+	if tag == "" || (s.pos > 0 && isIdentifierByte(s.script[s.pos-1])) {
+		s.write(1, true)
+		return nil
+	}
+
+	end := strings.Index(rest[len(tag):], tag)
+	if end < 0 {
+		return ScriptError{line: s.line, err: db_errors.ScriptUnterminatedError()}
+	}
+
+	s.write(len(tag)+end+len(tag), true)
+	return nil
+}
+
+func isScriptSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r' || c == '\n' || c == '\f' || c == '\v'
+}
+
+func isKeywordStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentifierByte(c byte) bool {
+	return c == '_' || c == '$' || (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
@@ -0,0 +1,171 @@
+package db
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	db_errors "github.com/fatihtatoglu/db-go/error"
+)
+
+func TestSplitScript(t *testing.T) {
+	// Test cases
+	testCases := []struct {
+		name     string
+		dialect  Dialect
+		script   string
+		expected []ScriptStatement
+	}{
+		{
+			name:    "Semicolons and comments",
+			dialect: DialectPostgres,
+			script: "-- seed the users\n" +
+				"INSERT INTO users (name) VALUES ('a;b');\n" +
+				"\n" +
+				"/* two\n   lines; */ UPDATE users SET note = 'it''s' -- trailing; comment\n" +
+				"  WHERE id = 1;\n" +
+				"SELECT \"odd;name\" FROM t",
+			expected: []ScriptStatement{
+				{text: "INSERT INTO users (name) VALUES ('a;b')", line: 2},
+				{text: "UPDATE users SET note = 'it''s' -- trailing; comment\n  WHERE id = 1", line: 5},
+				{text: `SELECT "odd;name" FROM t`, line: 7},
+			},
+		},
+		{
+			name:    "PostgreSQL dollar quotes and nested comments",
+			dialect: DialectPostgres,
+			script: "CREATE FUNCTION f() RETURNS int AS $body$ BEGIN RETURN 1; END; $body$ LANGUAGE plpgsql;\n" +
+				"/* outer /* inner; */ still; */ SELECT $1, a$b FROM t;",
+			expected: []ScriptStatement{
+				{text: "CREATE FUNCTION f() RETURNS int AS $body$ BEGIN RETURN 1; END; $body$ LANGUAGE plpgsql", line: 1},
+				{text: "SELECT $1, a$b FROM t", line: 2},
+			},
+		},
+		{
+			name:    "MySQL delimiter, backslash escapes and executable comments",
+			dialect: DialectMySQL,
+			script: "/*!40101 SET NAMES utf8mb4 */;\n" +
+				"# hash comment;\n" +
+				"INSERT INTO t VALUES ('a\\';b', `c;d`);\n" +
+				"DELIMITER //\n" +
+				"CREATE PROCEDURE p()\nBEGIN\n  SELECT 1;\nEND//\n" +
+				"DELIMITER ;\n" +
+				"SELECT 2;",
+			expected: []ScriptStatement{
+				{text: "/*!40101 SET NAMES utf8mb4 */", line: 1},
+				{text: "INSERT INTO t VALUES ('a\\';b', `c;d`)", line: 3},
+				{text: "CREATE PROCEDURE p()\nBEGIN\n  SELECT 1;\nEND", line: 5},
+				{text: "SELECT 2", line: 10},
+			},
+		},
+		{
+			name:    "SQL Server batches",
+			dialect: DialectSQLServer,
+			script: "CREATE TABLE [a;b] (id INT);\nINSERT INTO [a;b] VALUES (1);\nGO\n" +
+				"CREATE PROCEDURE p AS SELECT 1;\n go \n" +
+				"INSERT INTO log VALUES (1)\nGO 2\n",
+			expected: []ScriptStatement{
+				{text: "CREATE TABLE [a;b] (id INT);\nINSERT INTO [a;b] VALUES (1);", line: 1},
+				{text: "CREATE PROCEDURE p AS SELECT 1;", line: 4},
+				{text: "INSERT INTO log VALUES (1)", line: 6},
+				{text: "INSERT INTO log VALUES (1)", line: 6},
+			},
+		},
+		{
+			name:    "SQLite trigger",
+			dialect: DialectSQLite,
+			script: "CREATE TRIGGER tr AFTER INSERT ON t BEGIN\n  UPDATE c SET n = n + 1;\n  DELETE FROM d;\nEND;\n" +
+				"SELECT 1;",
+			expected: []ScriptStatement{
+				{text: "CREATE TRIGGER tr AFTER INSERT ON t BEGIN\n  UPDATE c SET n = n + 1;\n  DELETE FROM d;\nEND", line: 1},
+				{text: "SELECT 1", line: 5},
+			},
+		},
+		{
+			name:    "SQLite trigger with CASE",
+			dialect: DialectSQLite,
+			script: "CREATE TEMP TRIGGER tr AFTER UPDATE ON t BEGIN\n" +
+				"  UPDATE c SET n = CASE WHEN NEW.end > 0 THEN 1 ELSE 0 END;\n" +
+				"  INSERT INTO log VALUES ('END;'); -- END;\n" +
+				"END;\n" +
+				"SELECT 1;",
+			expected: []ScriptStatement{
+				{text: "CREATE TEMP TRIGGER tr AFTER UPDATE ON t BEGIN\n  UPDATE c SET n = CASE WHEN NEW.end > 0 THEN 1 ELSE 0 END;\n" +
+					"  INSERT INTO log VALUES ('END;'); -- END;\nEND", line: 1},
+				{text: "SELECT 1", line: 5},
+			},
+		},
+		{
+			name:    "PostgreSQL escape string",
+			dialect: DialectPostgres,
+			script:  "INSERT INTO t VALUES (E'it\\'s; fine');\nSELECT 'a\\';",
+			expected: []ScriptStatement{
+				{text: "INSERT INTO t VALUES (E'it\\'s; fine')", line: 1},
+				{text: "SELECT 'a\\'", line: 2},
+			},
+		},
+		{
+			name:     "Only comments",
+			dialect:  DialectSQLite,
+			script:   "-- nothing\n;;\n/* here */",
+			expected: []ScriptStatement{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			statements, err := SplitScript(tc.script, tc.dialect)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(statements, tc.expected) {
+				t.Errorf("Expected:\n%#v\ngot:\n%#v", tc.expected, statements)
+			}
+		})
+	}
+}
+
+func TestSplitScript_Unterminated(t *testing.T) {
+	// Test cases
+	testCases := []struct {
+		name   string
+		script string
+		line   int
+	}{
+		{name: "String", script: "SELECT 1;\nSELECT 'open;", line: 2},
+		{name: "Comment", script: "SELECT 1;\n\n/* open", line: 3},
+		{name: "Dollar quote", script: "SELECT $$ open", line: 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			_, err := SplitScript(tc.script, DialectPostgres)
+
+			// Assert
+			var scriptErr ScriptError
+			if !errors.As(err, &scriptErr) || scriptErr.GetLine() != tc.line {
+				t.Fatalf("Expected a script error on line %d, got: %v", tc.line, err)
+			}
+
+			assertError(t, errors.Unwrap(err), db_errors.Script_UnterminatedErrorMessage)
+		})
+	}
+}
+
+func TestScriptError(t *testing.T) {
+	// Arrange
+	err := ScriptError{line: 12, statement: "SELECT x", err: errors.New("unknown column")}
+
+	// Act
+	message := err.Error()
+
+	// Assert
+	if message != "script: line 12: unknown column" {
+		t.Errorf("Unexpected message: %s", message)
+	}
+}
@@ -0,0 +1,156 @@
+package db
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	db_criteria "github.com/fatihtatoglu/db-go/criteria"
+	db_errors "github.com/fatihtatoglu/db-go/error"
+)
+
+type testDocument struct {
+	ID        int64      `db:"id,pk,auto"`
+	Title     string     `db:"title"`
+	DeletedAt *time.Time `db:"deleted_at,softdelete"`
+}
+
+func createSoftDeleteRepository(t *testing.T, command *recordingCommand, now time.Time) *dbRepository[testDocument, int64] {
+	repository, err := CreateNewRepository[testDocument, int64](command, "documents", WithClock(func() time.Time { return now }))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	return repository.(*dbRepository[testDocument, int64])
+}
+
+func TestRepository_SoftDelete_Writes(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+
+	// Test cases
+	testCases := []struct {
+		testName      string
+		act           func(sut *dbRepository[testDocument, int64]) (bool, error)
+		expectedQuery string
+		expectedArgs  []interface{}
+	}{
+		{
+			testName:      "Delete",
+			act:           func(sut *dbRepository[testDocument, int64]) (bool, error) { return sut.Delete(3) },
+			expectedQuery: "UPDATE `documents` SET `deleted_at` = ? WHERE (`id` = ?) AND (`deleted_at` IS NULL)",
+			expectedArgs:  []interface{}{now, int64(3)},
+		},
+		{
+			testName:      "Restore",
+			act:           func(sut *dbRepository[testDocument, int64]) (bool, error) { return sut.Restore(3) },
+			expectedQuery: "UPDATE `documents` SET `deleted_at` = ? WHERE (`id` = ?) AND (`deleted_at` IS NOT NULL)",
+			expectedArgs:  []interface{}{nil, int64(3)},
+		},
+		{
+			testName:      "HardDelete",
+			act:           func(sut *dbRepository[testDocument, int64]) (bool, error) { return sut.HardDelete(3) },
+			expectedQuery: "DELETE FROM `documents` WHERE `id` = ?",
+			expectedArgs:  []interface{}{int64(3)},
+		},
+		{
+			testName: "Update skips deleted records",
+			act: func(sut *dbRepository[testDocument, int64]) (bool, error) {
+				return sut.Update(3, testDocument{Title: "Draft"})
+			},
+			expectedQuery: "UPDATE `documents` SET `title` = ? WHERE (`id` = ?) AND (`deleted_at` IS NULL)",
+			expectedArgs:  []interface{}{"Draft", int64(3)},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			command := &recordingCommand{dialect: DialectMySQL}
+			sut := createSoftDeleteRepository(t, command, now)
+
+			// Act
+			ok, err := tc.act(sut)
+
+			// Assert
+			if err != nil || !ok {
+				t.Fatalf("Expected success, got: %v, %v", ok, err)
+			}
+
+			if command.queries[0] != tc.expectedQuery {
+				t.Errorf("Expected query: %s, got: %s", tc.expectedQuery, command.queries[0])
+			}
+
+			if !reflect.DeepEqual(command.args[0], tc.expectedArgs) {
+				t.Errorf("Expected args: %v, got: %v", tc.expectedArgs, command.args[0])
+			}
+		})
+	}
+}
+
+func TestRepository_SoftDelete_Reads(t *testing.T) {
+	// Arrange
+	command := &recordingCommand{dialect: DialectMySQL}
+	sut := createSoftDeleteRepository(t, command, time.Now())
+
+	// Act
+	sut.Find(db_criteria.Like("title", "A%"))
+	sut.Count(nil)
+	sut.WithDeleted().Find(db_criteria.Like("title", "A%"))
+
+	// Assert
+	expectedQueries := []string{
+		"SELECT `id`, `title`, `deleted_at` FROM `documents` WHERE (`deleted_at` IS NULL) AND (`title` LIKE ?)",
+		"SELECT COUNT(*) FROM `documents` WHERE `deleted_at` IS NULL",
+		"SELECT `id`, `title`, `deleted_at` FROM `documents` WHERE `title` LIKE ?",
+	}
+
+	if !reflect.DeepEqual(command.queries, expectedQueries) {
+		t.Errorf("Expected queries: %v, got: %v", expectedQueries, command.queries)
+	}
+}
+
+func TestRepository_Restore_NotSupported(t *testing.T) {
+	// Arrange
+	command := &recordingCommand{dialect: DialectMySQL}
+	sut, _ := CreateNewRepository[testUser, int64](command, "users")
+
+	// Act
+	_, err := sut.Restore(1)
+	_, deleteErr := sut.Delete(1)
+
+	// Assert
+	assertError(t, err, db_errors.Repository_SoftDeleteNotSupportedErrorMessage)
+
+	if deleteErr != nil || command.queries[0] != "DELETE FROM `users` WHERE `id` = ?" {
+		t.Errorf("Expected a hard delete, got: %v", command.queries)
+	}
+}
+
+func TestRepository_SoftDelete_Upsert_Restores(t *testing.T) {
+	// Arrange
+	deletedAt := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	command, mock := createTestMockCommand(t, DialectMySQL)
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `documents` (`id`, `title`, `deleted_at`) VALUES (?, ?, ?)"+
+		" ON DUPLICATE KEY UPDATE `title` = VALUES(`title`), `deleted_at` = VALUES(`deleted_at`)").
+		WithArgs(int64(3), "Draft", nil).
+		WillReturnResult(0, 2)
+	mock.ExpectCommit()
+
+	sut, _ := CreateNewRepository[*testDocument, int64](command, "documents")
+	document := &testDocument{ID: 3, Title: "Draft", DeletedAt: &deletedAt}
+
+	// Act
+	ok, err := sut.Upsert(document)
+
+	// Assert
+	if err != nil || !ok {
+		t.Fatalf("Expected success, got: %v, %v", ok, err)
+	}
+
+	if document.DeletedAt != nil {
+		t.Errorf("Expected the soft delete field to be reset, got: %v", document.DeletedAt)
+	}
+
+	assertExpectationsWereMet(t, mock)
+}
@@ -0,0 +1,122 @@
+package db
+
+import (
+	"container/list"
+	"database/sql"
+)
+
+// statementCache is a least recently used cache of prepared statements keyed by the SQL text.
+// Evicted statements are closed once they are no longer in use.
+type statementCache struct {
+	size    int
+	items   map[string]*list.Element
+	entries *list.List
+}
+
+type statementCacheEntry struct {
+	query string
+	stmt  *sql.Stmt
+	// users is the number of the callers running the statement; a retired statement is closed by the last one.
+	users   int
+	retired bool
+}
+
+func newStatementCache(size int) *statementCache {
+	return &statementCache{
+		size:    size,
+		items:   make(map[string]*list.Element),
+		entries: list.New(),
+	}
+}
+
+// acquire returns the cached statement of the query and marks it as in use until it is released.
+func (c *statementCache) acquire(query string) (*statementCacheEntry, bool) {
+	element, ok := c.items[query]
+	if !ok {
+		return nil, false
+	}
+
+	c.entries.MoveToFront(element)
+
+	entry := element.Value.(*statementCacheEntry)
+	entry.users++
+	return entry, true
+}
+
+// release ends a use of the statement returned by acquire or put.
+func (c *statementCache) release(entry *statementCacheEntry) {
+	entry.users--
+	if entry.retired && entry.users == 0 {
+		closeStatement(entry.stmt)
+	}
+}
+
+// put caches the statement of the query and returns its entry, which is in use until it is released.
+func (c *statementCache) put(query string, stmt *sql.Stmt) *statementCacheEntry {
+	if element, ok := c.items[query]; ok {
+		entry := element.Value.(*statementCacheEntry)
+		if entry.stmt == stmt {
+			c.entries.MoveToFront(element)
+			entry.users++
+			return entry
+		}
+
+		c.entries.Remove(element)
+		delete(c.items, query)
+		entry.retire()
+	}
+
+	entry := &statementCacheEntry{
+		query: query,
+		stmt:  stmt,
+		users: 1,
+	}
+
+	c.items[query] = c.entries.PushFront(entry)
+	c.evict()
+
+	return entry
+}
+
+func (c *statementCache) resize(size int) {
+	c.size = size
+	c.evict()
+}
+
+func (c *statementCache) clear() {
+	for _, element := range c.items {
+		element.Value.(*statementCacheEntry).retire()
+	}
+
+	c.items = make(map[string]*list.Element)
+	c.entries.Init()
+}
+
+func (c *statementCache) len() int {
+	return c.entries.Len()
+}
+
+func (c *statementCache) evict() {
+	for c.entries.Len() > 0 && c.entries.Len() > c.size {
+		element := c.entries.Back()
+		entry := element.Value.(*statementCacheEntry)
+
+		c.entries.Remove(element)
+		delete(c.items, entry.query)
+		entry.retire()
+	}
+}
+
+// retire closes the statement of an entry removed from the cache, or leaves it to its last user.
+func (e *statementCacheEntry) retire() {
+	e.retired = true
+	if e.users == 0 {
+		closeStatement(e.stmt)
+	}
+}
+
+func closeStatement(stmt *sql.Stmt) {
+	if stmt != nil {
+		stmt.Close()
+	}
+}
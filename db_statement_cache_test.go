@@ -0,0 +1,143 @@
+package db
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	db_mock "github.com/fatihtatoglu/db-go/mock"
+)
+
+func TestStatementCache_Evicts_Least_Recently_Used(t *testing.T) {
+	// Arrange
+	cache := newStatementCache(2)
+	cache.put("SELECT 1", nil)
+	cache.put("SELECT 2", nil)
+
+	// Act
+	cache.acquire("SELECT 1")
+	cache.put("SELECT 3", nil)
+
+	// Assert
+	if _, ok := cache.acquire("SELECT 2"); ok {
+		t.Error("Expected least recently used statement to be evicted")
+	}
+
+	for _, query := range []string{"SELECT 1", "SELECT 3"} {
+		if _, ok := cache.acquire(query); !ok {
+			t.Errorf("Expected statement to be cached: %s", query)
+		}
+	}
+}
+
+func TestStatementCache_Resize(t *testing.T) {
+	// Arrange
+	cache := newStatementCache(3)
+	cache.put("SELECT 1", nil)
+	cache.put("SELECT 2", nil)
+	cache.put("SELECT 3", nil)
+
+	// Act
+	cache.resize(1)
+
+	// Assert
+	if cache.len() != 1 {
+		t.Errorf("Expected cache length: 1, got: %d", cache.len())
+	}
+
+	if _, ok := cache.acquire("SELECT 3"); !ok {
+		t.Error("Expected most recently used statement to be kept")
+	}
+}
+
+func TestStatementCache_Clear(t *testing.T) {
+	// Arrange
+	cache := newStatementCache(2)
+	cache.put("SELECT 1", nil)
+
+	// Act
+	cache.clear()
+
+	// Assert
+	if cache.len() != 0 {
+		t.Errorf("Expected empty cache, got length: %d", cache.len())
+	}
+}
+
+func TestStatementCache_Concurrent_Eviction(t *testing.T) {
+	// Arrange
+	mock := db_mock.CreateNewMock()
+	mock.MatchExpectationsInOrder(false)
+	RegisterDriverDialect(mock.GetDriverName(), DialectMySQL)
+
+	connection, _ := CreateNewDBConnection(mock.GetDriverName(), mock.GetDSN())
+	connection.SetStatementCacheSize(1)
+	command, _ := CreateNewDBCommand(connection)
+
+	const workers, iterations = 8, 50
+	queries := []string{"SELECT 1", "SELECT 2", "SELECT 3"}
+	for i := 0; i < workers*iterations; i++ {
+		mock.ExpectQuery(queries[i%len(queries)]).WillReturnRows(db_mock.CreateNewRows("n").AddRow(i))
+	}
+
+	connection.Open()
+	defer connection.Close()
+
+	// Act
+	var wg sync.WaitGroup
+	errs := make(chan error, workers*iterations)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				_, err := command.Query(queries[(w*iterations+i)%len(queries)])
+				if err != nil {
+					errs <- err
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	close(errs)
+
+	// Assert
+	for err := range errs {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestStatementCache_Evicted_Statement_In_Use(t *testing.T) {
+	// Arrange
+	mock := db_mock.CreateNewMock()
+	RegisterDriverDialect(mock.GetDriverName(), DialectMySQL)
+	mock.ExpectQuery("SELECT 1").WillReturnRows(db_mock.CreateNewRows("n").AddRow(1))
+
+	connection, _ := CreateNewDBConnection(mock.GetDriverName(), mock.GetDSN())
+	connection.SetStatementCacheSize(1)
+	connection.Open()
+	defer connection.Close()
+
+	stmt, release, err := connection.prepare(context.Background(), "SELECT 1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Act
+	_, releaseOther, _ := connection.prepare(context.Background(), "SELECT 2")
+	releaseOther()
+
+	rows, err := stmt.QueryContext(context.Background())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected the evicted statement to stay open while it is in use, got: %v", err)
+	}
+	rows.Close()
+
+	release()
+	_, err = stmt.QueryContext(context.Background())
+	if err == nil {
+		t.Error("Expected the evicted statement to be closed by its last user")
+	}
+}
@@ -59,4 +59,34 @@ func (dt *DBTable) GetColumns() []DBColumn {
 	return dt.columns
 }
 
-// TODO: UnmarshalJSON methodunu ekle.
+// UnmarshalJSON reads the table written by MarshalJSON; the values are converted to the types of the
+// columns, see DBRow.UnmarshalJSON.
+func (dt *DBTable) UnmarshalJSON(data []byte) error {
+	var table struct {
+		Columns []DBColumn        `json:"columns"`
+		Rows    []json.RawMessage `json:"rows"`
+	}
+
+	err := json.Unmarshal(data, &table)
+	if err != nil {
+		return err
+	}
+
+	dt.columns = make([]DBColumn, 0, len(table.Columns))
+	dt.rows = make([]DBRow, 0, len(table.Rows))
+	for _, column := range table.Columns {
+		dt.AddDBColumn(column)
+	}
+
+	for _, raw := range table.Rows {
+		row := dt.CreateNewDBRow()
+		err = json.Unmarshal(raw, &row)
+		if err != nil {
+			return err
+		}
+
+		dt.AddDBRow(row)
+	}
+
+	return nil
+}
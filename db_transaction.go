@@ -0,0 +1,167 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+
+	db_errors "github.com/fatihtatoglu/db-go/error"
+)
+
+// DBTransactionInterface is a command bound to a database transaction.
+// The connection stays open until the transaction is committed or rolled back.
+type DBTransactionInterface interface {
+	DBCommandInterface
+	Commit() error
+	Rollback() error
+}
+
+type dbTransaction struct {
+	dbCommand
+	state *transactionState
+}
+
+// transactionState is shared by a transaction and the clones of WithContext, so the transaction is
+// finished and its connection is released only once.
+type transactionState struct {
+	mutex sync.Mutex
+	done  bool
+}
+
+// finish marks the transaction as finished; false is returned when it was already finished.
+func (s *transactionState) finish() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.done {
+		return false
+	}
+
+	s.done = true
+	return true
+}
+
+func (cmd *dbCommand) Begin() (DBTransactionInterface, error) {
+	return cmd.beginTx(nil)
+}
+
+// snapshotReader is implemented by the commands which can read in a transaction that sees a single
+// snapshot of the database.
+type snapshotReader interface {
+	inSnapshot(fn func(DBCommandInterface) error) error
+}
+
+// inSnapshot runs the function in a read-only transaction, which is rolled back after it; the reads see
+// the database as it was when the transaction began. The command's own transaction is reused when it has one.
+//
+// PostgreSQL and MySQL read at the REPEATABLE READ level and SQLite transactions are serializable. SQL Server
+// has no read-only transactions and its SNAPSHOT level needs a database option, so it reads at the
+// SERIALIZABLE level, which blocks the writers of the rows read until the transaction ends.
+func (cmd *dbCommand) inSnapshot(fn func(DBCommandInterface) error) error {
+	if cmd.tx != nil {
+		return fn(cmd)
+	}
+
+	var opts *sql.TxOptions
+	switch cmd.GetDialect() {
+	case DialectMySQL, DialectPostgres:
+		opts = &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}
+	case DialectSQLServer:
+		opts = &sql.TxOptions{Isolation: sql.LevelSerializable}
+	}
+
+	tx, err := cmd.beginTx(opts)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	return fn(tx)
+}
+
+func (cmd *dbCommand) beginTx(opts *sql.TxOptions) (DBTransactionInterface, error) {
+	if cmd.tx != nil {
+		return nil, db_errors.TransactionNestedError()
+	}
+
+	err := cmd.connection.Open()
+	if err != nil {
+		return nil, err
+	}
+
+	var tx *sql.Tx
+	if cmd.conn != nil {
+		tx, err = cmd.conn.BeginTx(cmd.ctx, opts)
+	} else {
+		tx, err = cmd.connection.getConnection().BeginTx(cmd.ctx, opts)
+	}
+
+	if err != nil {
+		cmd.connection.Close()
+		return nil, err
+	}
+
+	return &dbTransaction{
+		dbCommand: dbCommand{
+			connection: cmd.connection,
+			ctx:        cmd.ctx,
+			tx:         tx,
+		},
+		state: &transactionState{},
+	}, nil
+}
+
+// WithContext returns a transaction which runs the statements with the context. It shares the database
+// transaction and its state with t, so either of them finishes the transaction once.
+func (t *dbTransaction) WithContext(ctx context.Context) DBCommandInterface {
+	return &dbTransaction{
+		dbCommand: dbCommand{
+			connection: t.connection,
+			ctx:        ctx,
+			tx:         t.tx,
+		},
+		state: t.state,
+	}
+}
+
+func (t *dbTransaction) Commit() error {
+	if !t.state.finish() {
+		return sql.ErrTxDone
+	}
+
+	defer t.connection.Close()
+
+	return t.tx.Commit()
+}
+
+func (t *dbTransaction) Rollback() error {
+	if !t.state.finish() {
+		return sql.ErrTxDone
+	}
+
+	defer t.connection.Close()
+
+	return t.tx.Rollback()
+}
+
+// inTransaction runs the function on a transaction bound command. The command's own transaction
+// is reused when it has one; otherwise a new transaction is committed or rolled back by the result.
+func (cmd *dbCommand) inTransaction(fn func(*dbCommand) error) error {
+	if cmd.tx != nil {
+		return fn(cmd)
+	}
+
+	tx, err := cmd.Begin()
+	if err != nil {
+		return err
+	}
+
+	t := tx.(*dbTransaction)
+	err = fn(&t.dbCommand)
+	if err != nil {
+		t.Rollback()
+		return err
+	}
+
+	return t.Commit()
+}
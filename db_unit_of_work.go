@@ -0,0 +1,331 @@
+package db
+
+import (
+	"reflect"
+	"slices"
+	"sort"
+
+	db_errors "github.com/fatihtatoglu/db-go/error"
+)
+
+// UnitOfWork groups the writes of several repositories into one transaction.
+//
+// The repositories handed out by the unit of work are bound to its transaction; their methods run
+// immediately, while the entities registered with RegisterNew, RegisterDirty and RegisterRemoved are
+// written by Flush or Commit. The inserts are ordered from the referenced tables to the referencing ones
+// by the ref tags of the entities, the updates keep the registration order and the deletes run in the
+// reverse table order. The updates and deletes which match no record fail the flush.
+type UnitOfWork struct {
+	tx           DBTransactionInterface
+	dependencies map[string][]string
+	operations   []unitOfWorkOperation
+	// newTables holds the tables of the new entities registered by pointer, which can be the parents of others.
+	newTables map[interface{}]string
+	done      bool
+	// failed is the error of the write which rolled the transaction back.
+	failed error
+}
+
+type unitOfWorkOperationKind int
+
+const (
+	unitOfWorkInsert unitOfWorkOperationKind = iota
+	unitOfWorkUpdate
+	unitOfWorkDelete
+)
+
+type unitOfWorkOperation struct {
+	kind    unitOfWorkOperationKind
+	table   string
+	execute func() error
+}
+
+// UnitOfWorkRepository is a repository bound to the transaction of a unit of work, which also registers
+// the entities written when the unit of work is flushed.
+type UnitOfWorkRepository[T any, K any] interface {
+	Repository[T, K]
+
+	// RegisterNew registers the entity to be inserted. The parents are new entities registered by pointer;
+	// their keys, generated when they are inserted, are copied into the ref fields of the entity which
+	// reference their tables before the entity is inserted.
+	RegisterNew(entity T, parents ...interface{})
+
+	// RegisterDirty registers the entity to be updated by the id value.
+	RegisterDirty(id K, entity T)
+
+	// RegisterRemoved registers the entity to be deleted by the id value.
+	RegisterRemoved(id K)
+}
+
+type unitOfWorkRepository[T any, K any] struct {
+	*dbRepository[T, K]
+	unitOfWork *UnitOfWork
+}
+
+// CreateNewUnitOfWork begins the transaction of a unit of work on the command.
+func CreateNewUnitOfWork(command DBCommandInterface) (*UnitOfWork, error) {
+	if command == nil {
+		return nil, db_errors.RepositoryNilCommandError()
+	}
+
+	tx, err := command.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	return &UnitOfWork{
+		tx:           tx,
+		dependencies: make(map[string][]string),
+		operations:   make([]unitOfWorkOperation, 0),
+		newTables:    make(map[interface{}]string),
+	}, nil
+}
+
+// CreateNewUnitOfWorkRepository creates a repository bound to the transaction of the unit of work.
+func CreateNewUnitOfWorkRepository[T any, K any](unitOfWork *UnitOfWork, tableName string, options ...RepositoryOption) (UnitOfWorkRepository[T, K], error) {
+	repository, err := CreateNewRepository[T, K](unitOfWork.tx, tableName, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	r := repository.(*dbRepository[T, K])
+	unitOfWork.dependencies[tableName] = r.metadata.referencedTables()
+
+	return &unitOfWorkRepository[T, K]{
+		dbRepository: r,
+		unitOfWork:   unitOfWork,
+	}, nil
+}
+
+// GetCommand returns the transaction of the unit of work.
+func (u *UnitOfWork) GetCommand() DBCommandInterface {
+	return u.tx
+}
+
+// Flush writes the registered entities in the transaction without committing it. A failed write rolls
+// the transaction back, so the writes before it are never committed; the later calls return its error.
+func (u *UnitOfWork) Flush() error {
+	if u.failed != nil {
+		return u.failed
+	}
+
+	if u.done {
+		return db_errors.UnitOfWorkCompletedError()
+	}
+
+	order, err := u.tableOrder()
+	if err != nil {
+		return err
+	}
+
+	operations := u.operations
+	u.operations = make([]unitOfWorkOperation, 0)
+
+	sort.SliceStable(operations, func(i, j int) bool {
+		a, b := operations[i], operations[j]
+		if a.kind != b.kind {
+			return a.kind < b.kind
+		}
+
+		switch a.kind {
+		case unitOfWorkInsert:
+			return order[a.table] < order[b.table]
+		case unitOfWorkDelete:
+			return order[a.table] > order[b.table]
+		default:
+			return false
+		}
+	})
+
+	for _, operation := range operations {
+		err = operation.execute()
+		if err != nil {
+			u.Rollback()
+			u.failed = err
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Commit flushes the registered entities and commits the transaction. The transaction is rolled back
+// when a write fails.
+func (u *UnitOfWork) Commit() error {
+	err := u.Flush()
+	if err != nil {
+		if !u.done {
+			u.Rollback()
+		}
+
+		return err
+	}
+
+	u.done = true
+	return u.tx.Commit()
+}
+
+// Rollback discards the registered entities and rolls the transaction back.
+func (u *UnitOfWork) Rollback() error {
+	if u.done {
+		return db_errors.UnitOfWorkCompletedError()
+	}
+
+	u.done = true
+	u.operations = nil
+	u.newTables = nil
+	return u.tx.Rollback()
+}
+
+// tableOrder ranks the tables so every table comes after the tables it references.
+// The referenced tables without a repository in the unit of work are ignored.
+func (u *UnitOfWork) tableOrder() (map[string]int, error) {
+	tables := make([]string, 0, len(u.dependencies))
+	for table := range u.dependencies {
+		tables = append(tables, table)
+	}
+	slices.Sort(tables)
+
+	order := make(map[string]int, len(tables))
+	visiting := make(map[string]bool)
+
+	var visit func(table string) error
+	visit = func(table string) error {
+		if _, ok := order[table]; ok {
+			return nil
+		}
+
+		if visiting[table] {
+			return db_errors.UnitOfWorkCyclicDependencyError()
+		}
+
+		visiting[table] = true
+		for _, referenced := range u.dependencies[table] {
+			if _, ok := u.dependencies[referenced]; !ok || referenced == table {
+				continue
+			}
+
+			err := visit(referenced)
+			if err != nil {
+				return err
+			}
+		}
+		visiting[table] = false
+
+		order[table] = len(order)
+		return nil
+	}
+
+	for _, table := range tables {
+		err := visit(table)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return order, nil
+}
+
+func (u *UnitOfWork) register(kind unitOfWorkOperationKind, table string, execute func() error) {
+	u.operations = append(u.operations, unitOfWorkOperation{
+		kind:    kind,
+		table:   table,
+		execute: execute,
+	})
+}
+
+func (r *unitOfWorkRepository[T, K]) RegisterNew(entity T, parents ...interface{}) {
+	if v := reflect.ValueOf(entity); v.Kind() == reflect.Pointer && !v.IsNil() {
+		r.unitOfWork.newTables[entity] = r.tableName
+	}
+
+	r.unitOfWork.register(unitOfWorkInsert, r.tableName, func() error {
+		target, err := r.hookTarget(entity)
+		if err != nil {
+			return err
+		}
+
+		for _, parent := range parents {
+			err = r.unitOfWork.copyParentKey(r.metadata, target, parent)
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = r.Insert(r.entityOf(target))
+		return err
+	})
+}
+
+func (r *unitOfWorkRepository[T, K]) RegisterDirty(id K, entity T) {
+	r.unitOfWork.register(unitOfWorkUpdate, r.tableName, func() error {
+		updated, err := r.Update(id, entity)
+		if err == nil && !updated {
+			return db_errors.RepositoryNotFoundError()
+		}
+
+		return err
+	})
+}
+
+func (r *unitOfWorkRepository[T, K]) RegisterRemoved(id K) {
+	r.unitOfWork.register(unitOfWorkDelete, r.tableName, func() error {
+		deleted, err := r.Delete(id)
+		if err == nil && !deleted {
+			return db_errors.RepositoryNotFoundError()
+		}
+
+		return err
+	})
+}
+
+// copyParentKey copies the referenced column of the inserted parent into the ref fields of the target
+// which reference the table of the parent. A ref without a column references the primary key.
+func (u *UnitOfWork) copyParentKey(metadata *entityMetadata, target reflect.Value, parent interface{}) error {
+	table, ok := u.newTables[parent]
+	if !ok {
+		return db_errors.UnitOfWorkInvalidParentError()
+	}
+
+	parentMetadata, err := getEntityMetadata(reflect.TypeOf(parent))
+	if err != nil {
+		return err
+	}
+
+	pv, err := parentMetadata.structValue(parent)
+	if err != nil {
+		return err
+	}
+
+	copied := false
+	for _, f := range metadata.fields {
+		referenced, column, ok := f.reference()
+		if !ok || referenced != table {
+			continue
+		}
+
+		var pf *entityField
+		if column != "" {
+			pf, _ = parentMetadata.fieldByColumn(column)
+		} else if keys := parentMetadata.primaryKeys(); len(keys) == 1 {
+			pf = keys[0]
+		}
+
+		if pf == nil {
+			return db_errors.UnitOfWorkInvalidParentError()
+		}
+
+		err = assignReflectValue(target.FieldByIndex(f.index), pv.FieldByIndex(pf.index).Interface())
+		if err != nil {
+			return err
+		}
+
+		copied = true
+	}
+
+	if !copied {
+		return db_errors.UnitOfWorkInvalidParentError()
+	}
+
+	return nil
+}
@@ -0,0 +1,235 @@
+package db
+
+import (
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"testing"
+
+	db_errors "github.com/fatihtatoglu/db-go/error"
+)
+
+type testCustomer struct {
+	ID   int64  `db:"id,pk"`
+	Name string `db:"name"`
+}
+
+type testOrder struct {
+	ID         int64 `db:"id,pk"`
+	CustomerID int64 `db:"customer_id,ref=customers(id)"`
+}
+
+type testOrderItem struct {
+	ID      int64 `db:"id,pk"`
+	OrderID int64 `db:"order_id,ref=orders(id)"`
+}
+
+func TestUnitOfWork_Commit(t *testing.T) {
+	// Arrange
+	command := &recordingCommand{dialect: DialectMySQL}
+	sut, _ := CreateNewUnitOfWork(command)
+
+	items, _ := CreateNewUnitOfWorkRepository[testOrderItem, int64](sut, "order_items")
+	orders, _ := CreateNewUnitOfWorkRepository[testOrder, int64](sut, "orders")
+	customers, _ := CreateNewUnitOfWorkRepository[testCustomer, int64](sut, "customers")
+
+	items.RegisterRemoved(7)
+	items.RegisterNew(testOrderItem{ID: 1, OrderID: 1})
+	orders.RegisterRemoved(3)
+	orders.RegisterNew(testOrder{ID: 1, CustomerID: 1})
+	customers.RegisterDirty(2, testCustomer{ID: 2, Name: "Ali"})
+	customers.RegisterNew(testCustomer{ID: 1, Name: "Fatih"})
+
+	// Act
+	err := sut.Commit()
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expectedQueries := []string{
+		"INSERT INTO `customers` (`id`, `name`) VALUES (?, ?)",
+		"INSERT INTO `orders` (`id`, `customer_id`) VALUES (?, ?)",
+		"INSERT INTO `order_items` (`id`, `order_id`) VALUES (?, ?)",
+		"UPDATE `customers` SET `name` = ? WHERE `id` = ?",
+		"DELETE FROM `order_items` WHERE `id` = ?",
+		"DELETE FROM `orders` WHERE `id` = ?",
+	}
+	if !reflect.DeepEqual(command.queries, expectedQueries) {
+		t.Errorf("Expected queries: %v, got: %v", expectedQueries, command.queries)
+	}
+
+	if !reflect.DeepEqual(command.events, []string{"begin", "commit"}) {
+		t.Errorf("Expected a committed transaction, got: %v", command.events)
+	}
+
+	assertError(t, sut.Commit(), db_errors.UnitOfWork_CompletedErrorMessage)
+}
+
+func TestUnitOfWork_Rollback(t *testing.T) {
+	// Arrange
+	command := &recordingCommand{dialect: DialectMySQL}
+	sut, _ := CreateNewUnitOfWork(command)
+	customers, _ := CreateNewUnitOfWorkRepository[testCustomer, int64](sut, "customers")
+	customers.RegisterNew(testCustomer{ID: 1})
+
+	// Act
+	err := sut.Rollback()
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(command.queries) != 0 || !reflect.DeepEqual(command.events, []string{"begin", "rollback"}) {
+		t.Errorf("Expected a rollback without statements, got: %v, %v", command.events, command.queries)
+	}
+}
+
+func TestUnitOfWork_CyclicDependency(t *testing.T) {
+	// Arrange
+	type testNode struct {
+		ID     int64 `db:"id,pk"`
+		EdgeID int64 `db:"edge_id,ref=edges(id)"`
+	}
+
+	type testEdge struct {
+		ID     int64 `db:"id,pk"`
+		NodeID int64 `db:"node_id,ref=nodes(id)"`
+	}
+
+	command := &recordingCommand{dialect: DialectMySQL}
+	sut, _ := CreateNewUnitOfWork(command)
+	CreateNewUnitOfWorkRepository[testNode, int64](sut, "nodes")
+	CreateNewUnitOfWorkRepository[testEdge, int64](sut, "edges")
+
+	// Act
+	err := sut.Commit()
+
+	// Assert
+	assertError(t, err, db_errors.UnitOfWork_CyclicDependencyErrorMessage)
+
+	if !reflect.DeepEqual(command.events, []string{"begin", "rollback"}) {
+		t.Errorf("Expected a rolled back transaction, got: %v", command.events)
+	}
+}
+
+func TestUnitOfWork_RegisterNew_Parents(t *testing.T) {
+	// Arrange
+	type testGeneratedCustomer struct {
+		ID   int64  `db:"id,pk,auto"`
+		Name string `db:"name"`
+	}
+
+	type testGeneratedOrder struct {
+		ID         int64 `db:"id,pk,auto"`
+		CustomerID int64 `db:"customer_id,ref=customers"`
+	}
+
+	command, mock := createTestMockCommand(t, DialectMySQL)
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `customers` (`name`) VALUES (?)").WithArgs("Fatih").WillReturnResult(41, 1)
+	mock.ExpectExec("INSERT INTO `orders` (`customer_id`) VALUES (?)").WithArgs(int64(41)).WillReturnResult(9, 1)
+	mock.ExpectCommit()
+
+	sut, _ := CreateNewUnitOfWork(command)
+	orders, _ := CreateNewUnitOfWorkRepository[*testGeneratedOrder, int64](sut, "orders")
+	customers, _ := CreateNewUnitOfWorkRepository[*testGeneratedCustomer, int64](sut, "customers")
+
+	customer := &testGeneratedCustomer{Name: "Fatih"}
+	order := &testGeneratedOrder{}
+	orders.RegisterNew(order, customer)
+	customers.RegisterNew(customer)
+
+	// Act
+	err := sut.Commit()
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if order.ID != 9 || order.CustomerID != 41 {
+		t.Errorf("Expected the generated keys to be written back, got: %+v", order)
+	}
+
+	assertExpectationsWereMet(t, mock)
+}
+
+func TestUnitOfWork_RegisterNew_InvalidParent(t *testing.T) {
+	// Arrange
+	command := &recordingCommand{dialect: DialectMySQL}
+	sut, _ := CreateNewUnitOfWork(command)
+	orders, _ := CreateNewUnitOfWorkRepository[testOrder, int64](sut, "orders")
+	orders.RegisterNew(testOrder{ID: 1}, &testCustomer{ID: 1})
+
+	// Act
+	err := sut.Commit()
+
+	// Assert
+	assertError(t, err, db_errors.UnitOfWork_InvalidParentErrorMessage)
+}
+
+func TestUnitOfWork_Commit_NotFound(t *testing.T) {
+	// Test cases
+	testCases := []struct {
+		testName string
+		register func(customers UnitOfWorkRepository[testCustomer, int64])
+	}{
+		{"RegisterDirty", func(customers UnitOfWorkRepository[testCustomer, int64]) {
+			customers.RegisterDirty(2, testCustomer{ID: 2, Name: "Ali"})
+		}},
+		{"RegisterRemoved", func(customers UnitOfWorkRepository[testCustomer, int64]) {
+			customers.RegisterRemoved(2)
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			// Arrange
+			command := &recordingCommand{dialect: DialectMySQL, result: driver.RowsAffected(0)}
+			sut, _ := CreateNewUnitOfWork(command)
+			customers, _ := CreateNewUnitOfWorkRepository[testCustomer, int64](sut, "customers")
+			tc.register(customers)
+
+			// Act
+			err := sut.Commit()
+
+			// Assert
+			if !errors.Is(err, db_errors.ErrRepositoryNotFound) {
+				t.Errorf("Expected the not found error, got: %v", err)
+			}
+
+			if !reflect.DeepEqual(command.events, []string{"begin", "rollback"}) {
+				t.Errorf("Expected a rolled back transaction, got: %v", command.events)
+			}
+		})
+	}
+}
+
+func TestUnitOfWork_Flush_Failed_Then_Commit(t *testing.T) {
+	// Arrange
+	command := &recordingCommand{dialect: DialectMySQL, result: driver.RowsAffected(0)}
+	sut, _ := CreateNewUnitOfWork(command)
+	customers, _ := CreateNewUnitOfWorkRepository[testCustomer, int64](sut, "customers")
+	customers.RegisterNew(testCustomer{ID: 1, Name: "Ann"})
+	customers.RegisterDirty(2, testCustomer{ID: 2, Name: "Ali"})
+
+	// Act
+	flushErr := sut.Flush()
+	commitErr := sut.Commit()
+
+	// Assert
+	if !errors.Is(flushErr, db_errors.ErrRepositoryNotFound) {
+		t.Errorf("Expected the not found error, got: %v", flushErr)
+	}
+
+	if commitErr != flushErr {
+		t.Errorf("Expected the error of the failed flush, got: %v", commitErr)
+	}
+
+	if !reflect.DeepEqual(command.events, []string{"begin", "rollback"}) {
+		t.Errorf("Expected a rolled back transaction, got: %v", command.events)
+	}
+}
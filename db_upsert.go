@@ -0,0 +1,230 @@
+package db
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	db_errors "github.com/fatihtatoglu/db-go/error"
+)
+
+// Upsert inserts the rows of a *DBTable, a struct or a slice of structs into the table and updates the
+// updateColumns of the rows which conflict on the conflictColumns. With no updateColumns the conflicting
+// rows are left untouched. The statement is generated for the dialect of the connection:
+// ON DUPLICATE KEY UPDATE for MySQL, ON CONFLICT for PostgreSQL and SQLite and MERGE for SQL Server.
+//
+// PostgreSQL and SQL Server reject a statement which affects a row twice, so of the rows repeating a conflict
+// key only the last one is written on every dialect.
+//
+// The returned row count is the one reported by the driver; MySQL counts an updated row twice.
+func (cmd *dbCommand) Upsert(tableName string, conflictColumns []string, updateColumns []string, values interface{}) (int64, error) {
+	if tableName == "" {
+		return 0, db_errors.TableEmptyNameError()
+	}
+
+	data, err := readBulkData(values, conflictColumns)
+	if err != nil {
+		return 0, err
+	}
+
+	return cmd.upsertData(tableName, conflictColumns, updateColumns, data)
+}
+
+func (cmd *dbCommand) upsertData(tableName string, conflictColumns []string, updateColumns []string, data *bulkData) (int64, error) {
+	dialect := cmd.connection.GetDialect()
+
+	// The statement is validated before touching the database.
+	_, err := buildUpsertSQL(dialect, tableName, data.columns, data.autoColumns, conflictColumns, updateColumns, 1)
+	if err != nil {
+		return 0, err
+	}
+
+	rows := uniqueConflictRows(data, conflictColumns)
+	if len(rows) == 0 {
+		return 0, nil
+	}
+
+	var affected int64
+	err = cmd.inTransaction(func(t *dbCommand) error {
+		rowsPerStatement := bulkRowsPerStatement(dialect, len(data.columns), 0)
+		for start := 0; start < len(rows); start += rowsPerStatement {
+			end := min(start+rowsPerStatement, len(rows))
+
+			query, _ := buildUpsertSQL(dialect, tableName, data.columns, data.autoColumns, conflictColumns, updateColumns, end-start)
+			args := make([]interface{}, 0, (end-start)*len(data.columns))
+			for _, row := range rows[start:end] {
+				args = append(args, row...)
+			}
+
+			res, err := t.Execute(query, args...)
+			if err != nil {
+				return err
+			}
+
+			n, err := (*res).RowsAffected()
+			if err == nil {
+				affected += n
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return affected, nil
+}
+
+// uniqueConflictRows returns the rows without the ones whose conflict key is repeated by a later row, so the
+// last row of a key wins. The rows with a NULL key value or a zero auto increment key are new rows which never
+// conflict and are kept.
+func uniqueConflictRows(data *bulkData, conflictColumns []string) [][]interface{} {
+	indexes := make([]int, 0, len(conflictColumns))
+	for _, column := range conflictColumns {
+		indexes = append(indexes, slices.Index(data.columns, column))
+	}
+
+	seen := make(map[string]bool, len(data.rows))
+	unique := make([][]interface{}, 0, len(data.rows))
+	for i := len(data.rows) - 1; i >= 0; i-- {
+		row := data.rows[i]
+
+		key := make([]interface{}, 0, len(indexes))
+		for _, index := range indexes {
+			value := row[index]
+			if value == nil || (slices.Contains(data.autoColumns, data.columns[index]) && reflect.ValueOf(value).IsZero()) {
+				key = nil
+				break
+			}
+
+			key = append(key, value)
+		}
+
+		if key != nil {
+			k := fmt.Sprintf("%#v", key)
+			if seen[k] {
+				continue
+			}
+
+			seen[k] = true
+		}
+
+		unique = append(unique, row)
+	}
+
+	slices.Reverse(unique)
+	return unique
+}
+
+// buildUpsertSQL returns the upsert statement of rowCount rows. The autoColumns are only used to match the
+// existing rows; SQL Server rejects explicit values for identity columns, so MERGE leaves them out of the insert.
+func buildUpsertSQL(dialect Dialect, tableName string, columns []string, autoColumns []string, conflictColumns []string, updateColumns []string, rowCount int) (string, error) {
+	if len(conflictColumns) == 0 {
+		return "", db_errors.UpsertEmptyConflictColumnsError()
+	}
+
+	for _, column := range append(append([]string{}, conflictColumns...), updateColumns...) {
+		if !slices.Contains(columns, column) {
+			return "", db_errors.UpsertUnknownColumnError()
+		}
+	}
+
+	q := dialect.QuoteIdentifier
+
+	switch dialect {
+	case DialectMySQL:
+		var b strings.Builder
+		b.WriteString(buildInsertSQL(dialect, tableName, columns, rowCount))
+		b.WriteString(" ON DUPLICATE KEY UPDATE ")
+		if len(updateColumns) == 0 {
+			b.WriteString(q(conflictColumns[0]) + " = " + q(conflictColumns[0]))
+			return b.String(), nil
+		}
+
+		for i, column := range updateColumns {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+
+			b.WriteString(q(column) + " = VALUES(" + q(column) + ")")
+		}
+
+		return b.String(), nil
+
+	case DialectPostgres, DialectSQLite:
+		var b strings.Builder
+		b.WriteString(buildInsertSQL(dialect, tableName, columns, rowCount))
+		b.WriteString(" ON CONFLICT (")
+		b.WriteString(joinQuoted(dialect, conflictColumns, ""))
+		if len(updateColumns) == 0 {
+			b.WriteString(") DO NOTHING")
+			return b.String(), nil
+		}
+
+		b.WriteString(") DO UPDATE SET ")
+		for i, column := range updateColumns {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+
+			b.WriteString(q(column) + " = EXCLUDED." + q(column))
+		}
+
+		return b.String(), nil
+
+	case DialectSQLServer:
+		var b strings.Builder
+		b.WriteString("MERGE INTO " + q(tableName) + " AS target USING (VALUES ")
+		writeValuesList(&b, dialect, len(columns), rowCount, 0)
+		b.WriteString(") AS source (" + joinQuoted(dialect, columns, "") + ") ON ")
+		for i, column := range conflictColumns {
+			if i > 0 {
+				b.WriteString(" AND ")
+			}
+
+			b.WriteString("target." + q(column) + " = source." + q(column))
+		}
+
+		if len(updateColumns) > 0 {
+			b.WriteString(" WHEN MATCHED THEN UPDATE SET ")
+			for i, column := range updateColumns {
+				if i > 0 {
+					b.WriteString(", ")
+				}
+
+				b.WriteString("target." + q(column) + " = source." + q(column))
+			}
+		}
+
+		insertColumns := make([]string, 0, len(columns))
+		for _, column := range columns {
+			if !slices.Contains(autoColumns, column) {
+				insertColumns = append(insertColumns, column)
+			}
+		}
+
+		if len(insertColumns) == 0 {
+			b.WriteString(" WHEN NOT MATCHED THEN INSERT DEFAULT VALUES;")
+			return b.String(), nil
+		}
+
+		b.WriteString(" WHEN NOT MATCHED THEN INSERT (" + joinQuoted(dialect, insertColumns, "") + ")")
+		b.WriteString(" VALUES (" + joinQuoted(dialect, insertColumns, "source.") + ");")
+		return b.String(), nil
+	}
+
+	return "", db_errors.UpsertNotSupportedError()
+}
+
+// joinQuoted quotes the names, prefixes them and joins them with commas.
+func joinQuoted(dialect Dialect, names []string, prefix string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = prefix + dialect.QuoteIdentifier(name)
+	}
+
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,150 @@
+package db
+
+import (
+	"testing"
+
+	db_errors "github.com/fatihtatoglu/db-go/error"
+)
+
+type testUpsertUser struct {
+	ID   int64  `db:"id,pk,auto"`
+	Name string `db:"name"`
+}
+
+func TestBuildUpsertSQL(t *testing.T) {
+	// Test cases
+	testCases := []struct {
+		dialect       Dialect
+		expectedQuery string
+	}{
+		{DialectMySQL, "INSERT INTO `users` (`id`, `name`) VALUES (?, ?) ON DUPLICATE KEY UPDATE `name` = VALUES(`name`)"},
+		{DialectPostgres, `INSERT INTO "users" ("id", "name") VALUES ($1, $2) ON CONFLICT ("id") DO UPDATE SET "name" = EXCLUDED."name"`},
+		{DialectSQLite, `INSERT INTO "users" ("id", "name") VALUES (?, ?) ON CONFLICT ("id") DO UPDATE SET "name" = EXCLUDED."name"`},
+		{DialectSQLServer, "MERGE INTO [users] AS target USING (VALUES (@p1, @p2)) AS source ([id], [name]) ON target.[id] = source.[id]" +
+			" WHEN MATCHED THEN UPDATE SET target.[name] = source.[name]" +
+			" WHEN NOT MATCHED THEN INSERT ([id], [name]) VALUES (source.[id], source.[name]);"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.dialect), func(t *testing.T) {
+			// Act
+			query, err := buildUpsertSQL(tc.dialect, "users", []string{"id", "name"}, nil, []string{"id"}, []string{"name"}, 1)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if query != tc.expectedQuery {
+				t.Errorf("Expected query: %s, got: %s", tc.expectedQuery, query)
+			}
+		})
+	}
+}
+
+func TestBuildUpsertSQL_Without_Update_Columns(t *testing.T) {
+	// Act
+	mysql, _ := buildUpsertSQL(DialectMySQL, "users", []string{"id"}, nil, []string{"id"}, nil, 1)
+	postgres, _ := buildUpsertSQL(DialectPostgres, "users", []string{"id"}, nil, []string{"id"}, nil, 1)
+
+	// Assert
+	if mysql != "INSERT INTO `users` (`id`) VALUES (?) ON DUPLICATE KEY UPDATE `id` = `id`" {
+		t.Errorf("Unexpected MySQL query: %s", mysql)
+	}
+
+	if postgres != `INSERT INTO "users" ("id") VALUES ($1) ON CONFLICT ("id") DO NOTHING` {
+		t.Errorf("Unexpected PostgreSQL query: %s", postgres)
+	}
+}
+
+func TestBuildUpsertSQL_Errors(t *testing.T) {
+	// Test cases
+	testCases := []struct {
+		testName        string
+		dialect         Dialect
+		conflictColumns []string
+		updateColumns   []string
+		expectedErrMsg  string
+	}{
+		{"Empty conflict columns", DialectMySQL, nil, []string{"name"}, db_errors.Upsert_EmptyConflictColumnsErrorMessage},
+		{"Unknown update column", DialectMySQL, []string{"id"}, []string{"age"}, db_errors.Upsert_UnknownColumnErrorMessage},
+		{"Unknown dialect", DialectUnknown, []string{"id"}, []string{"name"}, db_errors.Upsert_NotSupportedErrorMessage},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			// Act
+			_, err := buildUpsertSQL(tc.dialect, "users", []string{"id", "name"}, nil, tc.conflictColumns, tc.updateColumns, 1)
+
+			// Assert
+			assertError(t, err, tc.expectedErrMsg)
+		})
+	}
+}
+
+func TestDBCommand_Upsert_SQLServer_Identity(t *testing.T) {
+	// Arrange
+	command, mock := createTestMockCommand(t, DialectSQLServer)
+	mock.ExpectBegin()
+	mock.ExpectExec("MERGE INTO [users] AS target USING (VALUES (@p1, @p2), (@p3, @p4)) AS source ([id], [name]) ON target.[id] = source.[id]"+
+		" WHEN MATCHED THEN UPDATE SET target.[name] = source.[name]"+
+		" WHEN NOT MATCHED THEN INSERT ([name]) VALUES (source.[name]);").
+		WithArgs(int64(7), "Ann", int64(0), "Bob").
+		WillReturnResult(0, 2)
+	mock.ExpectCommit()
+
+	// Act
+	affected, err := command.Upsert("users", []string{"id"}, []string{"name"}, []testUpsertUser{{ID: 7, Name: "Ann"}, {Name: "Bob"}})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if affected != 2 {
+		t.Errorf("Expected rows affected: 2, got: %d", affected)
+	}
+
+	assertExpectationsWereMet(t, mock)
+}
+
+func TestDBCommand_Upsert_Duplicate_Keys(t *testing.T) {
+	// Arrange
+	command, mock := createTestMockCommand(t, DialectPostgres)
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO "users" ("id", "name") VALUES ($1, $2), ($3, $4), ($5, $6), ($7, $8) ON CONFLICT ("id") DO UPDATE SET "name" = EXCLUDED."name"`).
+		WithArgs(int64(2), "Bob", int64(0), "Cem", int64(0), "Dan", int64(1), "Ann Smith").
+		WillReturnResult(0, 4)
+	mock.ExpectCommit()
+
+	users := []testUpsertUser{{ID: 1, Name: "Ann"}, {ID: 2, Name: "Bob"}, {Name: "Cem"}, {Name: "Dan"}, {ID: 1, Name: "Ann Smith"}}
+
+	// Act
+	affected, err := command.Upsert("users", []string{"id"}, []string{"name"}, users)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if affected != 4 {
+		t.Errorf("Expected rows affected: 4, got: %d", affected)
+	}
+
+	assertExpectationsWereMet(t, mock)
+}
+
+func TestBuildUpsertSQL_SQLServer_Only_Identity(t *testing.T) {
+	// Act
+	query, err := buildUpsertSQL(DialectSQLServer, "tokens", []string{"id"}, []string{"id"}, []string{"id"}, nil, 1)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := "MERGE INTO [tokens] AS target USING (VALUES (@p1)) AS source ([id]) ON target.[id] = source.[id] WHEN NOT MATCHED THEN INSERT DEFAULT VALUES;"
+	if query != expected {
+		t.Errorf("Expected query: %s, got: %s", expected, query)
+	}
+}
@@ -0,0 +1,173 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+
+	db_errors "github.com/fatihtatoglu/db-go/error"
+)
+
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02",
+}
+
+// assignValue stores the value read from the database into the destination pointer.
+// Values are converted between the common driver types (strings, numbers, booleans, times) when needed.
+func assignValue(dest interface{}, value interface{}) error {
+	if scanner, ok := dest.(sql.Scanner); ok {
+		return scanner.Scan(value)
+	}
+
+	dv := reflect.ValueOf(dest)
+	if dv.Kind() != reflect.Pointer || dv.IsNil() {
+		return db_errors.ValueInvalidDestinationError()
+	}
+
+	return assignReflectValue(dv.Elem(), value)
+}
+
+func assignReflectValue(target reflect.Value, value interface{}) error {
+	if value == nil {
+		target.Set(reflect.Zero(target.Type()))
+		return nil
+	}
+
+	if target.CanAddr() {
+		if scanner, ok := target.Addr().Interface().(sql.Scanner); ok {
+			return scanner.Scan(value)
+		}
+	}
+
+	sv := reflect.ValueOf(value)
+	if sv.Type().AssignableTo(target.Type()) {
+		target.Set(sv)
+		return nil
+	}
+
+	if target.Kind() == reflect.Pointer {
+		v := reflect.New(target.Type().Elem())
+		err := assignReflectValue(v.Elem(), value)
+		if err != nil {
+			return err
+		}
+
+		target.Set(v)
+		return nil
+	}
+
+	if b, ok := value.([]byte); ok {
+		value = string(b)
+		sv = reflect.ValueOf(value)
+	}
+
+	if s, ok := value.(string); ok {
+		return assignString(target, s)
+	}
+
+	if target.Kind() == reflect.String {
+		target.SetString(fmt.Sprint(value))
+		return nil
+	}
+
+	if isNumericKind(sv.Kind()) && isNumericKind(target.Kind()) {
+		target.Set(sv.Convert(target.Type()))
+		return nil
+	}
+
+	if sv.Kind() == reflect.Bool && isNumericKind(target.Kind()) {
+		if sv.Bool() {
+			target.Set(reflect.ValueOf(1).Convert(target.Type()))
+		} else {
+			target.Set(reflect.Zero(target.Type()))
+		}
+
+		return nil
+	}
+
+	if target.Kind() == reflect.Bool && isNumericKind(sv.Kind()) {
+		target.SetBool(!sv.IsZero())
+		return nil
+	}
+
+	if sv.Type().ConvertibleTo(target.Type()) {
+		target.Set(sv.Convert(target.Type()))
+		return nil
+	}
+
+	return db_errors.ValueUnsupportedConversionError()
+}
+
+func assignString(target reflect.Value, s string) error {
+	switch target.Kind() {
+	case reflect.String:
+		target.SetString(s)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, target.Type().Bits())
+		if err != nil {
+			return db_errors.ValueUnsupportedConversionError()
+		}
+
+		target.SetInt(i)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(s, 10, target.Type().Bits())
+		if err != nil {
+			return db_errors.ValueUnsupportedConversionError()
+		}
+
+		target.SetUint(u)
+		return nil
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, target.Type().Bits())
+		if err != nil {
+			return db_errors.ValueUnsupportedConversionError()
+		}
+
+		target.SetFloat(f)
+		return nil
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return db_errors.ValueUnsupportedConversionError()
+		}
+
+		target.SetBool(b)
+		return nil
+	case reflect.Slice:
+		if target.Type().Elem().Kind() == reflect.Uint8 {
+			target.SetBytes([]byte(s))
+			return nil
+		}
+	}
+
+	if target.Type() == reflect.TypeOf(time.Time{}) {
+		for _, layout := range timeLayouts {
+			t, err := time.Parse(layout, s)
+			if err == nil {
+				target.Set(reflect.ValueOf(t))
+				return nil
+			}
+		}
+	}
+
+	return db_errors.ValueUnsupportedConversionError()
+}
+
+func isNumericKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+
+	return false
+}
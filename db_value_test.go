@@ -0,0 +1,82 @@
+package db
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	db_errors "github.com/fatihtatoglu/db-go/error"
+)
+
+func TestAssignValue(t *testing.T) {
+	// Arrange
+	var i int
+	var i64 int64
+	var f float64
+	var s string
+	var b bool
+	var p *string
+	var tm time.Time
+	var ns sql.NullString
+
+	// Act
+	errs := []error{
+		assignValue(&i, "42"),
+		assignValue(&i64, int32(7)),
+		assignValue(&f, []byte("1.5")),
+		assignValue(&s, int64(12)),
+		assignValue(&b, int64(1)),
+		assignValue(&p, "pointer"),
+		assignValue(&tm, "2024-01-02 03:04:05"),
+		assignValue(&ns, "nullable"),
+	}
+
+	// Assert
+	for _, err := range errs {
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	if i != 42 || i64 != 7 || f != 1.5 || s != "12" || !b {
+		t.Errorf("Unexpected values: %v %v %v %v %v", i, i64, f, s, b)
+	}
+
+	if p == nil || *p != "pointer" {
+		t.Errorf("Expected pointer value, got: %v", p)
+	}
+
+	if tm.Year() != 2024 || tm.Hour() != 3 {
+		t.Errorf("Unexpected time value: %v", tm)
+	}
+
+	if !ns.Valid || ns.String != "nullable" {
+		t.Errorf("Unexpected scanner value: %v", ns)
+	}
+}
+
+func TestAssignValue_Nil(t *testing.T) {
+	// Arrange
+	s := "value"
+
+	// Act
+	err := assignValue(&s, nil)
+
+	// Assert
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	if s != "" {
+		t.Errorf("Expected zero value, got: %s", s)
+	}
+}
+
+func TestAssignValue_Errors(t *testing.T) {
+	// Arrange
+	var i int
+
+	// Act & Assert
+	assertError(t, assignValue(i, 1), db_errors.Value_InvalidDestinationErrorMessage)
+	assertError(t, assignValue(&i, "not-a-number"), db_errors.Value_UnsupportedConversionErrorMessage)
+}
@@ -0,0 +1,52 @@
+package db
+
+import (
+	"reflect"
+	"time"
+)
+
+// isVersionType reports whether the field type can be used by optimistic locking.
+func isVersionType(t reflect.Type) bool {
+	if t == timeType || (t.Kind() == reflect.Pointer && t.Elem() == timeType) {
+		return true
+	}
+
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+
+	return false
+}
+
+// nextVersion returns the version written by an update: the integer versions are incremented
+// and the timestamp versions are set to the current time. The time is truncated to microseconds,
+// the precision kept by the databases, so the version of the entity matches the stored one.
+func nextVersion(current reflect.Value, now time.Time) interface{} {
+	now = now.Truncate(time.Microsecond)
+
+	switch {
+	case current.Kind() >= reflect.Int && current.Kind() <= reflect.Int64:
+		next := reflect.New(current.Type()).Elem()
+		next.SetInt(current.Int() + 1)
+		return next.Interface()
+	case current.Kind() >= reflect.Uint && current.Kind() <= reflect.Uint64:
+		next := reflect.New(current.Type()).Elem()
+		next.SetUint(current.Uint() + 1)
+		return next.Interface()
+	case current.Kind() == reflect.Pointer:
+		return &now
+	default:
+		return now
+	}
+}
+
+// initialVersion returns the version written by an insert; a zero version starts at 1 or the current time.
+func initialVersion(current reflect.Value, now time.Time) interface{} {
+	if !current.IsZero() {
+		return current.Interface()
+	}
+
+	return nextVersion(current, now)
+}
@@ -0,0 +1,208 @@
+package db
+
+import (
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	db_errors "github.com/fatihtatoglu/db-go/error"
+)
+
+type testVersionedProduct struct {
+	ID      int64  `db:"id,pk"`
+	Name    string `db:"name"`
+	Version int32  `db:"version,version"`
+}
+
+func TestRepository_Update_Version(t *testing.T) {
+	// Arrange
+	command := &recordingCommand{dialect: DialectMySQL}
+	sut, _ := CreateNewRepository[*testVersionedProduct, int64](command, "products")
+	product := &testVersionedProduct{ID: 1, Name: "Pen", Version: 4}
+
+	// Act
+	ok, err := sut.Update(1, product)
+
+	// Assert
+	if err != nil || !ok {
+		t.Fatalf("Expected success, got: %v, %v", ok, err)
+	}
+
+	expectedQuery := "UPDATE `products` SET `name` = ?, `version` = ? WHERE (`id` = ?) AND (`version` = ?)"
+	if command.queries[0] != expectedQuery {
+		t.Errorf("Expected query: %s, got: %s", expectedQuery, command.queries[0])
+	}
+
+	expectedArgs := []interface{}{"Pen", int32(5), int64(1), int32(4)}
+	if !reflect.DeepEqual(command.args[0], expectedArgs) {
+		t.Errorf("Expected args: %v, got: %v", expectedArgs, command.args[0])
+	}
+
+	if product.Version != 5 {
+		t.Errorf("Expected the version to be written back, got: %d", product.Version)
+	}
+}
+
+func TestRepository_Update_ConcurrencyConflict(t *testing.T) {
+	// Arrange
+	command := &recordingCommand{dialect: DialectMySQL, result: driver.RowsAffected(0)}
+	sut, _ := CreateNewRepository[*testVersionedProduct, int64](command, "products")
+	product := &testVersionedProduct{ID: 1, Name: "Pen", Version: 4}
+
+	// Act
+	ok, err := sut.Update(1, product)
+
+	// Assert
+	var conflict *db_errors.ConcurrencyConflictError
+	if ok || !errors.As(err, &conflict) {
+		t.Fatalf("Expected a ConcurrencyConflictError, got: %v", err)
+	}
+
+	if conflict.Table != "products" || conflict.Version != int32(4) || product.Version != 4 {
+		t.Errorf("Unexpected conflict: %+v, version: %d", conflict, product.Version)
+	}
+}
+
+func TestRepository_Insert_Version(t *testing.T) {
+	// Arrange
+	command := &recordingCommand{dialect: DialectMySQL}
+	sut, _ := CreateNewRepository[*testVersionedProduct, int64](command, "products")
+	product := &testVersionedProduct{ID: 1, Name: "Pen"}
+
+	// Act
+	_, err := sut.Insert(product)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expectedArgs := []interface{}{int64(1), "Pen", int32(1)}
+	if !reflect.DeepEqual(command.args[0], expectedArgs) || product.Version != 1 {
+		t.Errorf("Expected args: %v, got: %v", expectedArgs, command.args[0])
+	}
+}
+
+func TestNextVersion(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	nextInt := nextVersion(reflect.ValueOf(uint8(7)), now)
+	nextTime := nextVersion(reflect.ValueOf(now.Add(-time.Hour)), now)
+
+	// Assert
+	if nextInt != uint8(8) {
+		t.Errorf("Expected 8, got: %v", nextInt)
+	}
+
+	if nextTime != now {
+		t.Errorf("Expected %v, got: %v", now, nextTime)
+	}
+}
+
+func TestCreateNewRepository_InvalidVersionField(t *testing.T) {
+	// Arrange
+	type invalidVersion struct {
+		ID      int64  `db:"id,pk"`
+		Version string `db:"version,version"`
+	}
+
+	// Act
+	_, err := CreateNewRepository[invalidVersion, int64](&recordingCommand{}, "items")
+
+	// Assert
+	assertError(t, err, db_errors.Repository_InvalidVersionFieldErrorMessage)
+}
+
+type testStampedProduct struct {
+	ID        int64      `db:"id,pk"`
+	Name      string     `db:"name"`
+	UpdatedAt *time.Time `db:"updated_at,version"`
+}
+
+func TestRepository_Update_Timestamp_Version_Twice(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 1, 1, 10, 0, 0, 123456789, time.UTC)
+	clock := func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+
+	command := &recordingCommand{dialect: DialectPostgres}
+	sut, _ := CreateNewRepository[*testStampedProduct, int64](command, "products", WithClock(clock))
+	product := &testStampedProduct{ID: 1, Name: "Pen"}
+
+	// Act
+	_, firstErr := sut.Update(1, product)
+	_, secondErr := sut.Update(1, product)
+
+	// Assert
+	if firstErr != nil || secondErr != nil {
+		t.Fatalf("Unexpected errors: %v, %v", firstErr, secondErr)
+	}
+
+	expectedFirst := `UPDATE "products" SET "name" = $1, "updated_at" = $2 WHERE ("id" = $3) AND ("updated_at" IS NULL)`
+	if command.queries[0] != expectedFirst {
+		t.Errorf("Expected query: %s, got: %s", expectedFirst, command.queries[0])
+	}
+
+	written := command.args[0][1].(*time.Time)
+	if written.Nanosecond()%1000 != 0 {
+		t.Errorf("Expected the version to be truncated to microseconds, got: %v", written)
+	}
+
+	if compared := command.args[1][3].(*time.Time); !compared.Equal(*written) {
+		t.Errorf("Expected the second update to compare the written version %v, got: %v", written, compared)
+	}
+}
+
+func TestRepository_Upsert_Version(t *testing.T) {
+	// Test cases
+	testCases := []struct {
+		testName      string
+		version       int32
+		expectedQuery string
+		expectedArgs  []interface{}
+	}{
+		{
+			testName:      "Without version",
+			version:       0,
+			expectedQuery: "INSERT INTO `products` (`id`, `name`, `version`) VALUES (?, ?, ?)",
+			expectedArgs:  []interface{}{int64(1), "Pen", int32(1)},
+		},
+		{
+			testName:      "With version",
+			version:       4,
+			expectedQuery: "UPDATE `products` SET `name` = ?, `version` = ? WHERE (`id` = ?) AND (`version` = ?)",
+			expectedArgs:  []interface{}{"Pen", int32(5), int64(1), int32(4)},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			// Arrange
+			command := &recordingCommand{dialect: DialectMySQL}
+			sut, _ := CreateNewRepository[*testVersionedProduct, int64](command, "products")
+			product := &testVersionedProduct{ID: 1, Name: "Pen", Version: tc.version}
+
+			// Act
+			ok, err := sut.Upsert(product)
+
+			// Assert
+			if err != nil || !ok {
+				t.Fatalf("Expected success, got: %v, %v", ok, err)
+			}
+
+			if command.queries[0] != tc.expectedQuery {
+				t.Errorf("Expected query: %s, got: %s", tc.expectedQuery, command.queries[0])
+			}
+
+			if !reflect.DeepEqual(command.args[0], tc.expectedArgs) {
+				t.Errorf("Expected args: %v, got: %v", tc.expectedArgs, command.args[0])
+			}
+		})
+	}
+}
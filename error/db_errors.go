@@ -3,13 +3,112 @@ package db_errors
 import "errors"
 
 const (
+	Builder_EmptyColumnsErrorMessage          = "builder: the insert columns and values cannot be empty"
+	Builder_EmptySetErrorMessage              = "builder: the update must set at least one column"
+	Builder_ReturningNotSupportedErrorMessage = "builder: returning columns are not supported by the dialect"
+	Builder_ValueCountMismatchErrorMessage    = "builder: the value count does not match the column count"
+
 	Column_NotFoundErrorMessage        = "column: the column specified by columnName cannot be found"
 	Column_IndexOutOfRangeErrorMessage = "column: the columnIndex argument is out of range"
 
+	Concurrency_ConflictErrorMessage = "concurrency: the record has been changed or deleted since it was read"
+
 	Connection_InvalidDriverErrorMessage = "connection: the driver is invalid"
 	Connection_EmptyDSNErrorMessage      = "connection: the dsn cannot be empty"
+
+	Bulk_InvalidSourceErrorMessage = "bulk: the source must be a DBTable, a struct or a slice of structs"
+
+	DDL_InvalidSizeErrorMessage        = "ddl: the size option must be a positive integer"
+	DDL_UnsupportedDialectErrorMessage = "ddl: the dialect is not supported"
+	DDL_UnsupportedTypeErrorMessage    = "ddl: the column type cannot be derived from the field type, set it with the type option"
+
+	Diff_UnsupportedChangeErrorMessage = "diff: the change cannot be written as an ALTER statement for the dialect"
+
+	Dump_InvalidFormatErrorMessage = "dump: the format is not supported"
+	Dump_InvalidRecordErrorMessage = "dump: the record does not follow the header of its table"
+
+	Entity_InvalidTypeErrorMessage = "entity: the entity must be a struct or a pointer to a struct"
+
+	Fixture_DuplicateLabelErrorMessage    = "fixture: the row label is used more than once in the table"
+	Fixture_InvalidFileErrorMessage       = "fixture: the file must map the table names to lists or maps of rows"
+	Fixture_NilCommandErrorMessage        = "fixture: the command cannot be nil"
+	Fixture_ReferenceNotFoundErrorMessage = "fixture: the referenced row or column cannot be found"
+	Fixture_UnknownTemplateErrorMessage   = "fixture: the template is not known"
+
+	Inspector_NilCommandErrorMessage         = "inspector: the command cannot be nil"
+	Inspector_TableNotFoundErrorMessage      = "inspector: the table cannot be found"
+	Inspector_UnsupportedDialectErrorMessage = "inspector: the schema of the dialect cannot be inspected"
+
+	Migration_ChecksumMismatchErrorMessage = "migration: an applied migration has been changed since it was applied"
+	Migration_DuplicateVersionErrorMessage = "migration: the migration version is defined more than once"
+	Migration_InvalidFileNameErrorMessage  = "migration: the file name must be like 0001_name.up.sql or 0001_name.down.sql"
+	Migration_LockFailedErrorMessage       = "migration: the migration lock cannot be acquired"
+	Migration_MissingDownErrorMessage      = "migration: the migration cannot be reverted without a down script"
+	Migration_MissingUpErrorMessage        = "migration: the migration does not have an up script"
+	Migration_NilCommandErrorMessage       = "migration: the command cannot be nil"
+	Migration_VersionNotFoundErrorMessage  = "migration: the migration version cannot be found"
+
+	Mock_UnexpectedCallErrorMessage    = "mock: the call was not expected"
+	Mock_UnmetExpectationsErrorMessage = "mock: the expectations were not met"
+
+	Procedure_EmptyNameErrorMessage          = "procedure: the procedure name cannot be empty"
+	Procedure_EmptyParameterNameErrorMessage = "procedure: the parameter name cannot be empty"
+	Procedure_NotSupportedErrorMessage       = "procedure: stored procedures are not supported by the dialect"
+
+	Repository_InvalidCursorErrorMessage          = "repository: the cursor is invalid or does not match the sort order"
+	Repository_InvalidKeyErrorMessage             = "repository: the key does not match the primary key fields"
+	Repository_InvalidPageErrorMessage            = "repository: the page and the page size must be greater than zero"
+	Repository_InvalidRelationErrorMessage        = "repository: the relation must reference a struct with a single primary key"
+	Repository_InvalidSortColumnErrorMessage      = "repository: the sort column is not mapped to an entity field"
+	Repository_InvalidVersionFieldErrorMessage    = "repository: the version field must be an integer or a time"
+	Repository_MissingPrimaryKeyErrorMessage      = "repository: the entity must have a primary key field"
+	Repository_NilCommandErrorMessage             = "repository: the command cannot be nil"
+	Repository_NotFoundErrorMessage               = "repository: the entity cannot be found"
+	Repository_SoftDeleteNotSupportedErrorMessage = "repository: the entity does not have a softdelete field"
+	Repository_UnknownRelationErrorMessage        = "repository: the relation is not defined on the entity"
+
+	Row_ValueCountMismatchErrorMessage = "row: the value count does not match the column count"
+
+	Script_UnterminatedErrorMessage = "script: the string, quoted identifier or comment is not terminated"
+
+	Table_EmptyNameErrorMessage = "table: the table name cannot be empty"
+
+	Transaction_NestedErrorMessage = "transaction: nested transactions are not supported"
+
+	UnitOfWork_CompletedErrorMessage        = "unit of work: the unit of work has already been committed or rolled back"
+	UnitOfWork_CyclicDependencyErrorMessage = "unit of work: the table references form a cycle"
+	UnitOfWork_InvalidParentErrorMessage    = "unit of work: the parent must be a pointer registered as new and referenced by a ref field"
+
+	Upsert_EmptyConflictColumnsErrorMessage = "upsert: the conflict columns cannot be empty"
+	Upsert_NotSupportedErrorMessage         = "upsert: upsert is not supported by the dialect"
+	Upsert_UnknownColumnErrorMessage        = "upsert: the conflict and update columns must be written columns"
+
+	Value_InvalidDestinationErrorMessage    = "value: the destination must be a non-nil pointer"
+	Value_UnsupportedConversionErrorMessage = "value: the value cannot be converted to the destination type"
 )
 
+// The sentinel errors are returned as is by their functions, so they can be matched with errors.Is.
+var (
+	ErrInspectorTableNotFound = errors.New(Inspector_TableNotFoundErrorMessage)
+	ErrRepositoryNotFound     = errors.New(Repository_NotFoundErrorMessage)
+)
+
+func BuilderEmptyColumnsError() error {
+	return errors.New(Builder_EmptyColumnsErrorMessage)
+}
+
+func BuilderEmptySetError() error {
+	return errors.New(Builder_EmptySetErrorMessage)
+}
+
+func BuilderReturningNotSupportedError() error {
+	return errors.New(Builder_ReturningNotSupportedErrorMessage)
+}
+
+func BuilderValueCountMismatchError() error {
+	return errors.New(Builder_ValueCountMismatchErrorMessage)
+}
+
 func ColumnNotFoundError() error {
 	return errors.New(Column_NotFoundErrorMessage)
 }
@@ -25,3 +124,229 @@ func ConnectionInvalidDriverError() error {
 func ConnectionEmptyDSNError() error {
 	return errors.New(Connection_EmptyDSNErrorMessage)
 }
+
+func BulkInvalidSourceError() error {
+	return errors.New(Bulk_InvalidSourceErrorMessage)
+}
+
+func DDLInvalidSizeError() error {
+	return errors.New(DDL_InvalidSizeErrorMessage)
+}
+
+func DDLUnsupportedDialectError() error {
+	return errors.New(DDL_UnsupportedDialectErrorMessage)
+}
+
+func DDLUnsupportedTypeError() error {
+	return errors.New(DDL_UnsupportedTypeErrorMessage)
+}
+
+func DiffUnsupportedChangeError() error {
+	return errors.New(Diff_UnsupportedChangeErrorMessage)
+}
+
+func DumpInvalidFormatError() error {
+	return errors.New(Dump_InvalidFormatErrorMessage)
+}
+
+func DumpInvalidRecordError() error {
+	return errors.New(Dump_InvalidRecordErrorMessage)
+}
+
+func EntityInvalidTypeError() error {
+	return errors.New(Entity_InvalidTypeErrorMessage)
+}
+
+func FixtureDuplicateLabelError() error {
+	return errors.New(Fixture_DuplicateLabelErrorMessage)
+}
+
+func FixtureInvalidFileError() error {
+	return errors.New(Fixture_InvalidFileErrorMessage)
+}
+
+func FixtureNilCommandError() error {
+	return errors.New(Fixture_NilCommandErrorMessage)
+}
+
+func FixtureReferenceNotFoundError() error {
+	return errors.New(Fixture_ReferenceNotFoundErrorMessage)
+}
+
+func FixtureUnknownTemplateError() error {
+	return errors.New(Fixture_UnknownTemplateErrorMessage)
+}
+
+func InspectorNilCommandError() error {
+	return errors.New(Inspector_NilCommandErrorMessage)
+}
+
+func InspectorTableNotFoundError() error {
+	return ErrInspectorTableNotFound
+}
+
+func InspectorUnsupportedDialectError() error {
+	return errors.New(Inspector_UnsupportedDialectErrorMessage)
+}
+
+func MigrationChecksumMismatchError() error {
+	return errors.New(Migration_ChecksumMismatchErrorMessage)
+}
+
+func MigrationDuplicateVersionError() error {
+	return errors.New(Migration_DuplicateVersionErrorMessage)
+}
+
+func MigrationInvalidFileNameError() error {
+	return errors.New(Migration_InvalidFileNameErrorMessage)
+}
+
+func MigrationLockFailedError() error {
+	return errors.New(Migration_LockFailedErrorMessage)
+}
+
+func MigrationMissingDownError() error {
+	return errors.New(Migration_MissingDownErrorMessage)
+}
+
+func MigrationMissingUpError() error {
+	return errors.New(Migration_MissingUpErrorMessage)
+}
+
+func MigrationNilCommandError() error {
+	return errors.New(Migration_NilCommandErrorMessage)
+}
+
+func MigrationVersionNotFoundError() error {
+	return errors.New(Migration_VersionNotFoundErrorMessage)
+}
+
+func MockUnexpectedCallError() error {
+	return errors.New(Mock_UnexpectedCallErrorMessage)
+}
+
+func MockUnmetExpectationsError() error {
+	return errors.New(Mock_UnmetExpectationsErrorMessage)
+}
+
+func ProcedureEmptyNameError() error {
+	return errors.New(Procedure_EmptyNameErrorMessage)
+}
+
+func ProcedureEmptyParameterNameError() error {
+	return errors.New(Procedure_EmptyParameterNameErrorMessage)
+}
+
+func ProcedureNotSupportedError() error {
+	return errors.New(Procedure_NotSupportedErrorMessage)
+}
+
+func RepositoryInvalidCursorError() error {
+	return errors.New(Repository_InvalidCursorErrorMessage)
+}
+
+func RepositoryInvalidKeyError() error {
+	return errors.New(Repository_InvalidKeyErrorMessage)
+}
+
+func RepositoryInvalidPageError() error {
+	return errors.New(Repository_InvalidPageErrorMessage)
+}
+
+func RepositoryInvalidRelationError() error {
+	return errors.New(Repository_InvalidRelationErrorMessage)
+}
+
+func RepositoryInvalidSortColumnError() error {
+	return errors.New(Repository_InvalidSortColumnErrorMessage)
+}
+
+func RepositoryInvalidVersionFieldError() error {
+	return errors.New(Repository_InvalidVersionFieldErrorMessage)
+}
+
+func RepositoryMissingPrimaryKeyError() error {
+	return errors.New(Repository_MissingPrimaryKeyErrorMessage)
+}
+
+func RepositoryNilCommandError() error {
+	return errors.New(Repository_NilCommandErrorMessage)
+}
+
+func RepositoryNotFoundError() error {
+	return ErrRepositoryNotFound
+}
+
+func RepositorySoftDeleteNotSupportedError() error {
+	return errors.New(Repository_SoftDeleteNotSupportedErrorMessage)
+}
+
+func RepositoryUnknownRelationError() error {
+	return errors.New(Repository_UnknownRelationErrorMessage)
+}
+
+func RowValueCountMismatchError() error {
+	return errors.New(Row_ValueCountMismatchErrorMessage)
+}
+
+func ScriptUnterminatedError() error {
+	return errors.New(Script_UnterminatedErrorMessage)
+}
+
+func TableEmptyNameError() error {
+	return errors.New(Table_EmptyNameErrorMessage)
+}
+
+func TransactionNestedError() error {
+	return errors.New(Transaction_NestedErrorMessage)
+}
+
+func UnitOfWorkCompletedError() error {
+	return errors.New(UnitOfWork_CompletedErrorMessage)
+}
+
+func UnitOfWorkCyclicDependencyError() error {
+	return errors.New(UnitOfWork_CyclicDependencyErrorMessage)
+}
+
+func UnitOfWorkInvalidParentError() error {
+	return errors.New(UnitOfWork_InvalidParentErrorMessage)
+}
+
+func UpsertEmptyConflictColumnsError() error {
+	return errors.New(Upsert_EmptyConflictColumnsErrorMessage)
+}
+
+func UpsertNotSupportedError() error {
+	return errors.New(Upsert_NotSupportedErrorMessage)
+}
+
+func UpsertUnknownColumnError() error {
+	return errors.New(Upsert_UnknownColumnErrorMessage)
+}
+
+func ValueInvalidDestinationError() error {
+	return errors.New(Value_InvalidDestinationErrorMessage)
+}
+
+func ValueUnsupportedConversionError() error {
+	return errors.New(Value_UnsupportedConversionErrorMessage)
+}
+
+// ConcurrencyConflictError is returned when an update does not match the version of the record,
+// because the record was changed or deleted by another operation since it was read.
+type ConcurrencyConflictError struct {
+	Table   string
+	Version interface{}
+}
+
+func (e *ConcurrencyConflictError) Error() string {
+	return Concurrency_ConflictErrorMessage
+}
+
+func NewConcurrencyConflictError(table string, version interface{}) error {
+	return &ConcurrencyConflictError{
+		Table:   table,
+		Version: version,
+	}
+}
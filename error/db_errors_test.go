@@ -2,6 +2,7 @@ package db_errors
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 )
 
@@ -12,6 +13,26 @@ func TestErrors(t *testing.T) {
 		expectedError  error
 		expectedErrMsg string
 	}{
+		{
+			name:          "Builder_EmptyColumnsError",
+			errorFunc:     BuilderEmptyColumnsError,
+			expectedError: errors.New(Builder_EmptyColumnsErrorMessage),
+		},
+		{
+			name:          "Builder_EmptySetError",
+			errorFunc:     BuilderEmptySetError,
+			expectedError: errors.New(Builder_EmptySetErrorMessage),
+		},
+		{
+			name:          "Builder_ReturningNotSupportedError",
+			errorFunc:     BuilderReturningNotSupportedError,
+			expectedError: errors.New(Builder_ReturningNotSupportedErrorMessage),
+		},
+		{
+			name:          "Builder_ValueCountMismatchError",
+			errorFunc:     BuilderValueCountMismatchError,
+			expectedError: errors.New(Builder_ValueCountMismatchErrorMessage),
+		},
 		{
 			name:          "Column_NotFoundError",
 			errorFunc:     ColumnNotFoundError,
@@ -32,6 +53,266 @@ func TestErrors(t *testing.T) {
 			errorFunc:     ConnectionEmptyDSNError,
 			expectedError: errors.New(Connection_EmptyDSNErrorMessage),
 		},
+		{
+			name:          "Bulk_InvalidSourceError",
+			errorFunc:     BulkInvalidSourceError,
+			expectedError: errors.New(Bulk_InvalidSourceErrorMessage),
+		},
+		{
+			name:          "DDL_InvalidSizeError",
+			errorFunc:     DDLInvalidSizeError,
+			expectedError: errors.New(DDL_InvalidSizeErrorMessage),
+		},
+		{
+			name:          "DDL_UnsupportedDialectError",
+			errorFunc:     DDLUnsupportedDialectError,
+			expectedError: errors.New(DDL_UnsupportedDialectErrorMessage),
+		},
+		{
+			name:          "DDL_UnsupportedTypeError",
+			errorFunc:     DDLUnsupportedTypeError,
+			expectedError: errors.New(DDL_UnsupportedTypeErrorMessage),
+		},
+		{
+			name:          "Diff_UnsupportedChangeError",
+			errorFunc:     DiffUnsupportedChangeError,
+			expectedError: errors.New(Diff_UnsupportedChangeErrorMessage),
+		},
+		{
+			name:          "Dump_InvalidFormatError",
+			errorFunc:     DumpInvalidFormatError,
+			expectedError: errors.New(Dump_InvalidFormatErrorMessage),
+		},
+		{
+			name:          "Dump_InvalidRecordError",
+			errorFunc:     DumpInvalidRecordError,
+			expectedError: errors.New(Dump_InvalidRecordErrorMessage),
+		},
+		{
+			name:          "Entity_InvalidTypeError",
+			errorFunc:     EntityInvalidTypeError,
+			expectedError: errors.New(Entity_InvalidTypeErrorMessage),
+		},
+		{
+			name:          "Fixture_DuplicateLabelError",
+			errorFunc:     FixtureDuplicateLabelError,
+			expectedError: errors.New(Fixture_DuplicateLabelErrorMessage),
+		},
+		{
+			name:          "Fixture_InvalidFileError",
+			errorFunc:     FixtureInvalidFileError,
+			expectedError: errors.New(Fixture_InvalidFileErrorMessage),
+		},
+		{
+			name:          "Fixture_NilCommandError",
+			errorFunc:     FixtureNilCommandError,
+			expectedError: errors.New(Fixture_NilCommandErrorMessage),
+		},
+		{
+			name:          "Fixture_ReferenceNotFoundError",
+			errorFunc:     FixtureReferenceNotFoundError,
+			expectedError: errors.New(Fixture_ReferenceNotFoundErrorMessage),
+		},
+		{
+			name:          "Fixture_UnknownTemplateError",
+			errorFunc:     FixtureUnknownTemplateError,
+			expectedError: errors.New(Fixture_UnknownTemplateErrorMessage),
+		},
+		{
+			name:          "Inspector_NilCommandError",
+			errorFunc:     InspectorNilCommandError,
+			expectedError: errors.New(Inspector_NilCommandErrorMessage),
+		},
+		{
+			name:          "Inspector_TableNotFoundError",
+			errorFunc:     InspectorTableNotFoundError,
+			expectedError: errors.New(Inspector_TableNotFoundErrorMessage),
+		},
+		{
+			name:          "Inspector_UnsupportedDialectError",
+			errorFunc:     InspectorUnsupportedDialectError,
+			expectedError: errors.New(Inspector_UnsupportedDialectErrorMessage),
+		},
+		{
+			name:          "Migration_ChecksumMismatchError",
+			errorFunc:     MigrationChecksumMismatchError,
+			expectedError: errors.New(Migration_ChecksumMismatchErrorMessage),
+		},
+		{
+			name:          "Migration_DuplicateVersionError",
+			errorFunc:     MigrationDuplicateVersionError,
+			expectedError: errors.New(Migration_DuplicateVersionErrorMessage),
+		},
+		{
+			name:          "Migration_InvalidFileNameError",
+			errorFunc:     MigrationInvalidFileNameError,
+			expectedError: errors.New(Migration_InvalidFileNameErrorMessage),
+		},
+		{
+			name:          "Migration_LockFailedError",
+			errorFunc:     MigrationLockFailedError,
+			expectedError: errors.New(Migration_LockFailedErrorMessage),
+		},
+		{
+			name:          "Migration_MissingDownError",
+			errorFunc:     MigrationMissingDownError,
+			expectedError: errors.New(Migration_MissingDownErrorMessage),
+		},
+		{
+			name:          "Migration_MissingUpError",
+			errorFunc:     MigrationMissingUpError,
+			expectedError: errors.New(Migration_MissingUpErrorMessage),
+		},
+		{
+			name:          "Migration_NilCommandError",
+			errorFunc:     MigrationNilCommandError,
+			expectedError: errors.New(Migration_NilCommandErrorMessage),
+		},
+		{
+			name:          "Migration_VersionNotFoundError",
+			errorFunc:     MigrationVersionNotFoundError,
+			expectedError: errors.New(Migration_VersionNotFoundErrorMessage),
+		},
+		{
+			name:          "Mock_UnexpectedCallError",
+			errorFunc:     MockUnexpectedCallError,
+			expectedError: errors.New(Mock_UnexpectedCallErrorMessage),
+		},
+		{
+			name:          "Mock_UnmetExpectationsError",
+			errorFunc:     MockUnmetExpectationsError,
+			expectedError: errors.New(Mock_UnmetExpectationsErrorMessage),
+		},
+		{
+			name:          "Procedure_EmptyNameError",
+			errorFunc:     ProcedureEmptyNameError,
+			expectedError: errors.New(Procedure_EmptyNameErrorMessage),
+		},
+		{
+			name:          "Procedure_EmptyParameterNameError",
+			errorFunc:     ProcedureEmptyParameterNameError,
+			expectedError: errors.New(Procedure_EmptyParameterNameErrorMessage),
+		},
+		{
+			name:          "Procedure_NotSupportedError",
+			errorFunc:     ProcedureNotSupportedError,
+			expectedError: errors.New(Procedure_NotSupportedErrorMessage),
+		},
+		{
+			name:          "Repository_InvalidCursorError",
+			errorFunc:     RepositoryInvalidCursorError,
+			expectedError: errors.New(Repository_InvalidCursorErrorMessage),
+		},
+		{
+			name:          "Repository_InvalidKeyError",
+			errorFunc:     RepositoryInvalidKeyError,
+			expectedError: errors.New(Repository_InvalidKeyErrorMessage),
+		},
+		{
+			name:          "Repository_InvalidPageError",
+			errorFunc:     RepositoryInvalidPageError,
+			expectedError: errors.New(Repository_InvalidPageErrorMessage),
+		},
+		{
+			name:          "Repository_InvalidRelationError",
+			errorFunc:     RepositoryInvalidRelationError,
+			expectedError: errors.New(Repository_InvalidRelationErrorMessage),
+		},
+		{
+			name:          "Repository_InvalidSortColumnError",
+			errorFunc:     RepositoryInvalidSortColumnError,
+			expectedError: errors.New(Repository_InvalidSortColumnErrorMessage),
+		},
+		{
+			name:          "Repository_InvalidVersionFieldError",
+			errorFunc:     RepositoryInvalidVersionFieldError,
+			expectedError: errors.New(Repository_InvalidVersionFieldErrorMessage),
+		},
+		{
+			name:          "Repository_MissingPrimaryKeyError",
+			errorFunc:     RepositoryMissingPrimaryKeyError,
+			expectedError: errors.New(Repository_MissingPrimaryKeyErrorMessage),
+		},
+		{
+			name:          "Repository_NilCommandError",
+			errorFunc:     RepositoryNilCommandError,
+			expectedError: errors.New(Repository_NilCommandErrorMessage),
+		},
+		{
+			name:          "Repository_NotFoundError",
+			errorFunc:     RepositoryNotFoundError,
+			expectedError: errors.New(Repository_NotFoundErrorMessage),
+		},
+		{
+			name:          "Repository_SoftDeleteNotSupportedError",
+			errorFunc:     RepositorySoftDeleteNotSupportedError,
+			expectedError: errors.New(Repository_SoftDeleteNotSupportedErrorMessage),
+		},
+		{
+			name:          "Repository_UnknownRelationError",
+			errorFunc:     RepositoryUnknownRelationError,
+			expectedError: errors.New(Repository_UnknownRelationErrorMessage),
+		},
+		{
+			name:          "Row_ValueCountMismatchError",
+			errorFunc:     RowValueCountMismatchError,
+			expectedError: errors.New(Row_ValueCountMismatchErrorMessage),
+		},
+		{
+			name:          "Script_UnterminatedError",
+			errorFunc:     ScriptUnterminatedError,
+			expectedError: errors.New(Script_UnterminatedErrorMessage),
+		},
+		{
+			name:          "Table_EmptyNameError",
+			errorFunc:     TableEmptyNameError,
+			expectedError: errors.New(Table_EmptyNameErrorMessage),
+		},
+		{
+			name:          "Transaction_NestedError",
+			errorFunc:     TransactionNestedError,
+			expectedError: errors.New(Transaction_NestedErrorMessage),
+		},
+		{
+			name:          "UnitOfWork_CompletedError",
+			errorFunc:     UnitOfWorkCompletedError,
+			expectedError: errors.New(UnitOfWork_CompletedErrorMessage),
+		},
+		{
+			name:          "UnitOfWork_CyclicDependencyError",
+			errorFunc:     UnitOfWorkCyclicDependencyError,
+			expectedError: errors.New(UnitOfWork_CyclicDependencyErrorMessage),
+		},
+		{
+			name:          "UnitOfWork_InvalidParentError",
+			errorFunc:     UnitOfWorkInvalidParentError,
+			expectedError: errors.New(UnitOfWork_InvalidParentErrorMessage),
+		},
+		{
+			name:          "Upsert_EmptyConflictColumnsError",
+			errorFunc:     UpsertEmptyConflictColumnsError,
+			expectedError: errors.New(Upsert_EmptyConflictColumnsErrorMessage),
+		},
+		{
+			name:          "Upsert_NotSupportedError",
+			errorFunc:     UpsertNotSupportedError,
+			expectedError: errors.New(Upsert_NotSupportedErrorMessage),
+		},
+		{
+			name:          "Upsert_UnknownColumnError",
+			errorFunc:     UpsertUnknownColumnError,
+			expectedError: errors.New(Upsert_UnknownColumnErrorMessage),
+		},
+		{
+			name:          "Value_InvalidDestinationError",
+			errorFunc:     ValueInvalidDestinationError,
+			expectedError: errors.New(Value_InvalidDestinationErrorMessage),
+		},
+		{
+			name:          "Value_UnsupportedConversionError",
+			errorFunc:     ValueUnsupportedConversionError,
+			expectedError: errors.New(Value_UnsupportedConversionErrorMessage),
+		},
 	}
 
 	for _, test := range tests {
@@ -44,3 +325,46 @@ func TestErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestSentinelErrors(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name      string
+		errorFunc func() error
+		sentinel  error
+	}{
+		{name: "Inspector_TableNotFoundError", errorFunc: InspectorTableNotFoundError, sentinel: ErrInspectorTableNotFound},
+		{name: "Repository_NotFoundError", errorFunc: RepositoryNotFoundError, sentinel: ErrRepositoryNotFound},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Act
+			err := fmt.Errorf("wrapped: %w", test.errorFunc())
+
+			// Assert
+			if !errors.Is(err, test.sentinel) {
+				t.Errorf("Expected the error to match the sentinel: %v", test.sentinel)
+			}
+		})
+	}
+}
+
+func TestConcurrencyConflictError(t *testing.T) {
+	// Act
+	err := NewConcurrencyConflictError("users", int64(3))
+
+	// Assert
+	var conflict *ConcurrencyConflictError
+	if !errors.As(err, &conflict) {
+		t.Fatalf("Expected a ConcurrencyConflictError, got: %T", err)
+	}
+
+	if conflict.Table != "users" || conflict.Version != int64(3) {
+		t.Errorf("Unexpected conflict: %+v", conflict)
+	}
+
+	if err.Error() != Concurrency_ConflictErrorMessage {
+		t.Errorf("Expected error: %s, but got: %s", Concurrency_ConflictErrorMessage, err.Error())
+	}
+}
@@ -0,0 +1,362 @@
+package db_generator
+
+import (
+	"fmt"
+	"go/format"
+	"go/token"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	db "github.com/fatihtatoglu/db-go"
+)
+
+// Generate writes the Go source of the entity structs and the typed repositories of the schema tables.
+//
+// Every table gets an entity struct with db tags, a TableName method and, when the table has a primary key,
+// a repository which embeds Repository[*Entity, Key] and adds a FindBy method for every non-key column.
+// Composite primary keys get a key struct. The nullable columns are mapped to pointers. The entities and
+// the fields whose names are taken by an earlier table or column get a number suffix, e.g. User2.
+func Generate(schema *Schema, packageName string) ([]byte, error) {
+	tables := make([]tableModel, 0, len(schema.Tables))
+	imports := map[string]bool{}
+	declared := map[string]bool{}
+
+	for _, table := range schema.Tables {
+		model := newTableModel(table)
+		model.declare(declared)
+		for _, field := range model.fields {
+			if field.goImport != "" {
+				imports[field.goImport] = true
+			}
+		}
+
+		if len(model.keys) > 0 {
+			imports[`db "github.com/fatihtatoglu/db-go"`] = true
+			if len(model.finders()) > 0 {
+				imports[`db_criteria "github.com/fatihtatoglu/db-go/criteria"`] = true
+			}
+		}
+
+		tables = append(tables, model)
+	}
+
+	var b strings.Builder
+	b.WriteString("// Code generated by dbgo-gen. DO NOT EDIT.\n\n")
+	b.WriteString("package " + packageName + "\n\n")
+
+	if len(imports) > 0 {
+		paths := make([]string, 0, len(imports))
+		for path := range imports {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+
+		b.WriteString("import (\n")
+		for _, path := range paths {
+			if !strings.Contains(path, " ") {
+				path = `"` + path + `"`
+			}
+
+			b.WriteString("\t" + path + "\n")
+		}
+		b.WriteString(")\n")
+	}
+
+	for _, model := range tables {
+		model.write(&b)
+	}
+
+	return format.Source([]byte(b.String()))
+}
+
+type fieldModel struct {
+	name     string
+	column   string
+	goType   string
+	goImport string
+	tag      string
+	key      bool
+}
+
+type tableModel struct {
+	table  string
+	entity string
+	fields []fieldModel
+	keys   []fieldModel
+}
+
+func newTableModel(table Table) tableModel {
+	model := tableModel{
+		table:  table.Name,
+		entity: toTypeName(singularize(table.Name)),
+	}
+
+	columns := append([]db.DBColumn{}, table.Columns...)
+	sort.SliceStable(columns, func(i, j int) bool {
+		return columns[i].GetOrdinal() < columns[j].GetOrdinal()
+	})
+
+	names := map[string]bool{}
+	for _, column := range columns {
+		goType, goImport := toGoType(column.GetDBType(), column.GetNullable())
+
+		name := toTypeName(column.GetName())
+		if name == "TableName" {
+			// The name is taken by the TableName method of the entity.
+			name += "Value"
+		}
+
+		// The columns like user_id and UserID get the same name.
+		name = uniqueName(name, func(name string) bool { return names[name] })
+		names[name] = true
+
+		field := fieldModel{
+			name:     name,
+			column:   column.GetName(),
+			goType:   goType,
+			goImport: goImport,
+			tag:      column.GetName(),
+			key:      slices.Contains(table.PrimaryKey, column.GetName()),
+		}
+
+		if field.key {
+			field.tag += ",pk"
+		}
+
+		if column.GetAutoIncrement() || slices.Contains(table.AutoIncrement, column.GetName()) {
+			field.tag += ",auto"
+		}
+
+		model.fields = append(model.fields, field)
+	}
+
+	// The key fields follow the order of the primary key.
+	for _, column := range table.PrimaryKey {
+		for _, field := range model.fields {
+			if field.column == column {
+				model.keys = append(model.keys, field)
+			}
+		}
+	}
+
+	return model
+}
+
+// declare renames the entity when one of the names it declares is already declared and records its names.
+func (m *tableModel) declare(declared map[string]bool) {
+	m.entity = uniqueName(m.entity, func(entity string) bool {
+		for _, name := range m.declarations(entity) {
+			if declared[name] {
+				return true
+			}
+		}
+
+		return false
+	})
+
+	for _, name := range m.declarations(m.entity) {
+		declared[name] = true
+	}
+}
+
+// declarations returns the package level names declared for the table when its entity is named entity.
+func (m tableModel) declarations(entity string) []string {
+	names := []string{entity}
+	if len(m.keys) > 1 {
+		names = append(names, entity+"Key")
+	}
+
+	if len(m.keys) > 0 {
+		names = append(names, entity+"Repository", "CreateNew"+entity+"Repository")
+	}
+
+	return names
+}
+
+func (m tableModel) keyType() string {
+	if len(m.keys) == 1 {
+		return m.keys[0].goType
+	}
+
+	return m.entity + "Key"
+}
+
+// finders returns the fields which get a FindBy method.
+func (m tableModel) finders() []fieldModel {
+	finders := make([]fieldModel, 0, len(m.fields))
+	for _, field := range m.fields {
+		if !field.key && field.goType != "[]byte" {
+			finders = append(finders, field)
+		}
+	}
+
+	return finders
+}
+
+func (m tableModel) write(b *strings.Builder) {
+	fmt.Fprintf(b, "\n// %s is the entity of the %s table.\n", m.entity, m.table)
+	fmt.Fprintf(b, "type %s struct {\n", m.entity)
+	for _, field := range m.fields {
+		fmt.Fprintf(b, "\t%s %s `db:%q`\n", field.name, field.goType, field.tag)
+	}
+	b.WriteString("}\n")
+
+	fmt.Fprintf(b, "\nfunc (%s) TableName() string {\n\treturn %q\n}\n", m.entity, m.table)
+
+	if len(m.keys) == 0 {
+		return
+	}
+
+	if len(m.keys) > 1 {
+		fmt.Fprintf(b, "\n// %sKey is the primary key of the %s table.\n", m.entity, m.table)
+		fmt.Fprintf(b, "type %sKey struct {\n", m.entity)
+		for _, field := range m.keys {
+			fmt.Fprintf(b, "\t%s %s `db:%q`\n", field.name, field.goType, field.column)
+		}
+		b.WriteString("}\n")
+	}
+
+	repository := m.entity + "Repository"
+	fmt.Fprintf(b, "\n// %s is the repository of the %s table.\n", repository, m.table)
+	fmt.Fprintf(b, "type %s struct {\n\tdb.Repository[*%s, %s]\n}\n", repository, m.entity, m.keyType())
+
+	fmt.Fprintf(b, "\nfunc CreateNew%s(command db.DBCommandInterface, options ...db.RepositoryOption) (*%s, error) {\n", repository, repository)
+	fmt.Fprintf(b, "\trepository, err := db.CreateNewRepository[*%s, %s](command, %q, options...)\n", m.entity, m.keyType(), m.table)
+	b.WriteString("\tif err != nil {\n\t\treturn nil, err\n\t}\n\n")
+	fmt.Fprintf(b, "\treturn &%s{Repository: repository}, nil\n}\n", repository)
+
+	for _, field := range m.finders() {
+		parameter := toParameterName(field.name)
+		fmt.Fprintf(b, "\n// FindBy%s finds the %s records by the %s column.\n", field.name, m.table, field.column)
+		fmt.Fprintf(b, "func (r *%s) FindBy%s(%s %s) ([]*%s, error) {\n", repository, field.name, parameter, field.goType, m.entity)
+		fmt.Fprintf(b, "\treturn r.Find(db_criteria.Eq(%q, %s))\n}\n", field.column, parameter)
+	}
+}
+
+// toGoType maps the database type name to a Go type and the import path it needs.
+func toGoType(dbType string, nullable bool) (string, string) {
+	name := strings.ToLower(strings.TrimSpace(dbType))
+	unsigned := strings.Contains(name, "unsigned")
+	name, _, _ = strings.Cut(name, "(")
+	name = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(name), "unsigned"))
+
+	goType, goImport := "string", ""
+	switch name {
+	case "bool", "boolean", "bit":
+		goType = "bool"
+	case "tinyint":
+		goType = "int8"
+	case "smallint", "int2", "smallserial", "year":
+		goType = "int16"
+	case "int", "integer", "int4", "mediumint", "serial":
+		goType = "int32"
+	case "bigint", "int8", "bigserial":
+		goType = "int64"
+	case "real", "float4":
+		goType = "float32"
+	case "float", "float8", "double", "double precision":
+		goType = "float64"
+	case "decimal", "numeric", "money", "smallmoney":
+		// The exact values are kept as text; a float64 would round them.
+		goType = "string"
+	case "date", "datetime", "datetime2", "smalldatetime", "datetimeoffset", "time", "timetz",
+		"timestamp", "timestamptz", "timestamp with time zone", "timestamp without time zone":
+		goType, goImport = "time.Time", "time"
+	case "binary", "varbinary", "blob", "tinyblob", "mediumblob", "longblob", "bytea", "image":
+		return "[]byte", ""
+	}
+
+	if unsigned && strings.HasPrefix(goType, "int") {
+		goType = "u" + goType
+	}
+
+	if nullable {
+		goType = "*" + goType
+	}
+
+	return goType, goImport
+}
+
+var initialisms = map[string]string{
+	"api": "API", "html": "HTML", "http": "HTTP", "id": "ID", "ip": "IP", "json": "JSON",
+	"sql": "SQL", "uri": "URI", "url": "URL", "utc": "UTC", "uuid": "UUID", "xml": "XML",
+}
+
+// toTypeName converts the snake_case database name to an exported Go name.
+func toTypeName(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var b strings.Builder
+	for _, word := range words {
+		if initialism, ok := initialisms[strings.ToLower(word)]; ok {
+			b.WriteString(initialism)
+			continue
+		}
+
+		runes := []rune(word)
+		b.WriteString(strings.ToUpper(string(runes[0])) + string(runes[1:]))
+	}
+
+	result := b.String()
+	if result == "" || unicode.IsDigit([]rune(result)[0]) {
+		result = "T" + result
+	}
+
+	return result
+}
+
+// uniqueName returns the name, or the name with the first number suffix from 2 which is not taken.
+func uniqueName(name string, taken func(string) bool) string {
+	unique := name
+	for n := 2; taken(unique); n++ {
+		unique = name + strconv.Itoa(n)
+	}
+
+	return unique
+}
+
+// toParameterName converts the exported field name to a parameter name which is neither a keyword nor the
+// receiver r of the generated methods.
+func toParameterName(fieldName string) string {
+	runes := []rune(fieldName)
+
+	upper := 0
+	for upper < len(runes) && unicode.IsUpper(runes[upper]) {
+		upper++
+	}
+
+	// A leading initialism is lowered as a whole: ID becomes id and URLPath becomes urlPath.
+	if upper > 1 && upper < len(runes) {
+		upper--
+	}
+
+	parameter := strings.ToLower(string(runes[:upper])) + string(runes[upper:])
+	if token.IsKeyword(parameter) || parameter == "r" {
+		parameter += "Value"
+	}
+
+	return parameter
+}
+
+// singularize returns the singular form of the last word of the table name for the common English plurals.
+func singularize(name string) string {
+	lower := strings.ToLower(name)
+
+	switch {
+	case strings.HasSuffix(lower, "ies") && len(name) > 3:
+		return name[:len(name)-3] + "y"
+	case strings.HasSuffix(lower, "sses"), strings.HasSuffix(lower, "xes"), strings.HasSuffix(lower, "ches"), strings.HasSuffix(lower, "shes"):
+		return name[:len(name)-2]
+	case strings.HasSuffix(lower, "ss"), strings.HasSuffix(lower, "us"), strings.HasSuffix(lower, "is"):
+		return name
+	case strings.HasSuffix(lower, "s") && len(name) > 1:
+		return name[:len(name)-1]
+	}
+
+	return name
+}
@@ -0,0 +1,256 @@
+package db_generator
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+)
+
+const testSchemaJSON = `{"tables": [
+	{"name": "users", "primaryKey": ["id"], "autoIncrement": ["id"], "columns": [
+		{"name": "id", "ordinal": 1, "dBType": "bigint"},
+		{"name": "email", "ordinal": 2, "dBType": "varchar(255)"},
+		{"name": "type", "ordinal": 3, "dBType": "int", "nullable": true},
+		{"name": "created_at", "ordinal": 4, "dBType": "timestamp"}
+	]},
+	{"name": "user_roles", "primaryKey": ["user_id", "role_id"], "columns": [
+		{"name": "user_id", "ordinal": 1, "dBType": "bigint"},
+		{"name": "role_id", "ordinal": 2, "dBType": "integer"}
+	]},
+	{"name": "audit_logs", "columns": [
+		{"name": "payload", "ordinal": 1, "dBType": "bytea", "nullable": true}
+	]}
+]}`
+
+func TestGenerate(t *testing.T) {
+	// Arrange
+	schema, err := ReadSchemaJSON(strings.NewReader(testSchemaJSON))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Act
+	source, err := Generate(schema, "models")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expectedParts := []string{
+		"// Code generated by dbgo-gen. DO NOT EDIT.",
+		"package models",
+		"\t\"time\"",
+		"type User struct {",
+		"ID        int64     `db:\"id,pk,auto\"`",
+		"Type      *int32    `db:\"type\"`",
+		"CreatedAt time.Time `db:\"created_at\"`",
+		"func (User) TableName() string {",
+		"type UserRepository struct {\n\tdb.Repository[*User, int64]\n}",
+		"func CreateNewUserRepository(command db.DBCommandInterface, options ...db.RepositoryOption) (*UserRepository, error) {",
+		"func (r *UserRepository) FindByEmail(email string) ([]*User, error) {",
+		"func (r *UserRepository) FindByType(typeValue *int32) ([]*User, error) {",
+		"type UserRoleKey struct {",
+		"db.Repository[*UserRole, UserRoleKey]",
+		"type AuditLog struct {\n\tPayload []byte `db:\"payload\"`\n}",
+	}
+
+	for _, part := range expectedParts {
+		if !strings.Contains(string(source), part) {
+			t.Errorf("Expected the generated source to contain:\n%s\ngot:\n%s", part, source)
+		}
+	}
+
+	if strings.Contains(string(source), "AuditLogRepository") {
+		t.Errorf("Expected no repository for the table without a primary key")
+	}
+}
+
+func TestToTypeName(t *testing.T) {
+	// Test cases
+	testCases := []struct {
+		name     string
+		expected string
+	}{
+		{"user_id", "UserID"},
+		{"api_url", "APIURL"},
+		{"order-lines", "OrderLines"},
+		{"2fa_code", "T2faCode"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			actual := toTypeName(tc.name)
+
+			// Assert
+			if actual != tc.expected {
+				t.Errorf("Expected: %s, got: %s", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestToParameterName(t *testing.T) {
+	// Test cases
+	testCases := []struct {
+		name     string
+		expected string
+	}{
+		{"Email", "email"},
+		{"ID", "id"},
+		{"URLPath", "urlPath"},
+		{"Range", "rangeValue"},
+		{"R", "rValue"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			actual := toParameterName(tc.name)
+
+			// Assert
+			if actual != tc.expected {
+				t.Errorf("Expected: %s, got: %s", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestSingularize(t *testing.T) {
+	// Test cases
+	testCases := []struct {
+		name     string
+		expected string
+	}{
+		{"users", "user"},
+		{"categories", "category"},
+		{"addresses", "address"},
+		{"boxes", "box"},
+		{"status", "status"},
+		{"person", "person"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			actual := singularize(tc.name)
+
+			// Assert
+			if actual != tc.expected {
+				t.Errorf("Expected: %s, got: %s", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestToGoType(t *testing.T) {
+	// Test cases
+	testCases := []struct {
+		dbType       string
+		nullable     bool
+		expectedType string
+	}{
+		{"int(10) unsigned", false, "uint32"},
+		{"BIGINT UNSIGNED", true, "*uint64"},
+		{"tinyint unsigned", false, "uint8"},
+		{"double unsigned", false, "float64"},
+		{"decimal(10,2)", false, "string"},
+		{"numeric", true, "*string"},
+		{"money", false, "string"},
+		{"double precision", false, "float64"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.dbType, func(t *testing.T) {
+			// Act
+			goType, _ := toGoType(tc.dbType, tc.nullable)
+
+			// Assert
+			if goType != tc.expectedType {
+				t.Errorf("Expected type: %s, got: %s", tc.expectedType, goType)
+			}
+		})
+	}
+}
+
+func TestGenerate_Compiles(t *testing.T) {
+	// Arrange
+	schema, err := ReadSchemaJSON(strings.NewReader(testSchemaJSON))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	source, err := Generate(schema, "models")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Act
+	err = checkTestSource(source)
+
+	// Assert
+	if err != nil {
+		t.Errorf("Expected the generated source to compile, got: %v\n%s", err, source)
+	}
+}
+
+func TestGenerate_Name_Collisions(t *testing.T) {
+	// Arrange
+	schema, err := ReadSchemaJSON(strings.NewReader(`{"tables": [
+	{"name": "users", "primaryKey": ["id"], "columns": [
+		{"name": "id", "ordinal": 1, "dBType": "bigint"},
+		{"name": "user_id", "ordinal": 2, "dBType": "bigint"},
+		{"name": "UserID", "ordinal": 3, "dBType": "bigint"},
+		{"name": "r", "ordinal": 4, "dBType": "varchar(10)"}
+	]},
+	{"name": "user", "primaryKey": ["id"], "columns": [
+		{"name": "id", "ordinal": 1, "dBType": "bigint"}
+	]}
+]}`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Act
+	source, err := Generate(schema, "models")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expectedParts := []string{
+		"UserID2 int64  `db:\"UserID\"`",
+		"func (r *UserRepository) FindByR(rValue string) ([]*User, error) {",
+		"type User2 struct {",
+		"func CreateNewUser2Repository(",
+	}
+
+	for _, part := range expectedParts {
+		if !strings.Contains(string(source), part) {
+			t.Errorf("Expected the generated source to contain:\n%s\ngot:\n%s", part, source)
+		}
+	}
+
+	if err := checkTestSource(source); err != nil {
+		t.Errorf("Expected the generated source to compile, got: %v\n%s", err, source)
+	}
+}
+
+// checkTestSource type checks the generated source.
+func checkTestSource(source []byte) error {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "models_gen.go", source, 0)
+	if err != nil {
+		return err
+	}
+
+	config := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	_, err = config.Check("models", fset, []*ast.File{file}, nil)
+	return err
+}
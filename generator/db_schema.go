@@ -0,0 +1,67 @@
+package db_generator
+
+import (
+	"encoding/json"
+	"io"
+
+	db "github.com/fatihtatoglu/db-go"
+)
+
+// Schema is the description of the tables which the entities are generated for.
+//
+// The JSON form is:
+//
+//	{"tables": [{"name": "users", "primaryKey": ["id"], "autoIncrement": ["id"],
+//	  "columns": [{"name": "id", "ordinal": 1, "dBType": "bigint", "nullable": false}]}]}
+//
+// The auto increment columns are listed in autoIncrement or flagged on the column with "autoIncrement": true.
+type Schema struct {
+	Tables []Table `json:"tables"`
+}
+
+type Table struct {
+	Name          string        `json:"name"`
+	Columns       []db.DBColumn `json:"columns"`
+	PrimaryKey    []string      `json:"primaryKey"`
+	AutoIncrement []string      `json:"autoIncrement"`
+}
+
+// ReadSchemaJSON reads the schema from its JSON form.
+func ReadSchemaJSON(r io.Reader) (*Schema, error) {
+	var schema Schema
+	err := json.NewDecoder(r).Decode(&schema)
+	if err != nil {
+		return nil, err
+	}
+
+	return &schema, nil
+}
+
+// ReadSchema reads the base tables of the current schema with the inspector of the command.
+func ReadSchema(command db.DBCommandInterface) (*Schema, error) {
+	inspector, err := db.CreateNewInspector(command)
+	if err != nil {
+		return nil, err
+	}
+
+	names, err := inspector.GetTableNames()
+	if err != nil {
+		return nil, err
+	}
+
+	schema := &Schema{Tables: make([]Table, 0, len(names))}
+	for _, name := range names {
+		table, err := inspector.GetTable(name)
+		if err != nil {
+			return nil, err
+		}
+
+		schema.Tables = append(schema.Tables, Table{
+			Name:       table.GetName(),
+			Columns:    table.GetColumns(),
+			PrimaryKey: table.GetPrimaryKey(),
+		})
+	}
+
+	return schema, nil
+}
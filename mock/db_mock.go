@@ -0,0 +1,399 @@
+package db_mock
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	db_errors "github.com/fatihtatoglu/db-go/error"
+)
+
+// mockCount numbers the drivers registered by CreateNewMock.
+var mockCount atomic.Int64
+
+var whiteSpace = regexp.MustCompile(`\s+`)
+
+type expectationKind string
+
+const (
+	expectBegin    expectationKind = "BEGIN"
+	expectCommit   expectationKind = "COMMIT"
+	expectRollback expectationKind = "ROLLBACK"
+	expectExec     expectationKind = "EXEC"
+	expectQuery    expectationKind = "QUERY"
+	expectOpen     expectationKind = "OPEN"
+	expectPing     expectationKind = "PING"
+	expectClose    expectationKind = "CLOSE"
+)
+
+// Mock is a fake database/sql driver which answers the calls with the declared expectations. The calls
+// match the expectations in the order they were declared unless MatchExpectationsInOrder(false) is set.
+//
+//	mock := db_mock.CreateNewMock()
+//	db.RegisterDriverDialect(mock.GetDriverName(), db.DialectPostgres)
+//	connection, _ := db.CreateNewDBConnection(mock.GetDriverName(), mock.GetDSN())
+//
+//	mock.ExpectQuery(`SELECT "id" FROM "users" WHERE "name" = $1`).
+//		WithArgs("ann").
+//		WillReturnRows(db_mock.CreateNewRows("id").AddRow(1))
+//
+// The prepared statements are accepted without an expectation; their queries are matched when they run.
+type Mock struct {
+	driverName   string
+	ordered      bool
+	expectations []*Expectation
+	mutex        sync.Mutex
+}
+
+// CreateNewMock registers a new driver for the mock with a unique name. The drivers of database/sql cannot
+// be unregistered, so the tests create a mock for each connection they need.
+func CreateNewMock() *Mock {
+	m := &Mock{
+		driverName: fmt.Sprintf("db_mock_%d", mockCount.Add(1)),
+		ordered:    true,
+	}
+
+	sql.Register(m.driverName, &mockDriver{mock: m})
+
+	return m
+}
+
+// GetDriverName returns the name the driver of the mock is registered with.
+func (m *Mock) GetDriverName() string {
+	return m.driverName
+}
+
+// GetDSN returns a data source name for the driver of the mock; the driver ignores it.
+func (m *Mock) GetDSN() string {
+	return m.driverName
+}
+
+// MatchExpectationsInOrder sets whether a call matches only the first expectation which was not met,
+// or any of them.
+func (m *Mock) MatchExpectationsInOrder(ordered bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.ordered = ordered
+}
+
+func (m *Mock) ExpectBegin() *Expectation {
+	return m.expect(&Expectation{kind: expectBegin})
+}
+
+func (m *Mock) ExpectCommit() *Expectation {
+	return m.expect(&Expectation{kind: expectCommit})
+}
+
+func (m *Mock) ExpectRollback() *Expectation {
+	return m.expect(&Expectation{kind: expectRollback})
+}
+
+// ExpectExec expects a statement with the query; the runs of white space are compared as a single space.
+func (m *Mock) ExpectExec(query string) *Expectation {
+	return m.expect(&Expectation{kind: expectExec, query: normalizeQuery(query)})
+}
+
+// ExpectExecRegexp expects a statement whose query matches the regular expression.
+func (m *Mock) ExpectExecRegexp(pattern string) *Expectation {
+	return m.expect(&Expectation{kind: expectExec, pattern: regexp.MustCompile(pattern)})
+}
+
+// ExpectQuery expects a query; the runs of white space are compared as a single space.
+func (m *Mock) ExpectQuery(query string) *Expectation {
+	return m.expect(&Expectation{kind: expectQuery, query: normalizeQuery(query)})
+}
+
+// ExpectQueryRegexp expects a query which matches the regular expression.
+func (m *Mock) ExpectQueryRegexp(pattern string) *Expectation {
+	return m.expect(&Expectation{kind: expectQuery, pattern: regexp.MustCompile(pattern)})
+}
+
+// ExpectOpen expects the driver to open a new connection. The opens, the pings and the closes of the
+// connections match their expectations in any order and are accepted without one.
+func (m *Mock) ExpectOpen() *Expectation {
+	return m.expect(&Expectation{kind: expectOpen})
+}
+
+// ExpectPing expects a ping of a connection, e.g. the one of DBConnection.Open.
+func (m *Mock) ExpectPing() *Expectation {
+	return m.expect(&Expectation{kind: expectPing})
+}
+
+// ExpectClose expects a connection to be closed, e.g. when the last DBConnection.Close closes the pool.
+func (m *Mock) ExpectClose() *Expectation {
+	return m.expect(&Expectation{kind: expectClose})
+}
+
+// ExpectationsWereMet returns an error for the first expectation which was not met.
+func (m *Mock) ExpectationsWereMet() error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for _, e := range m.expectations {
+		if !e.triggered {
+			return fmt.Errorf("%w: %s", db_errors.MockUnmetExpectationsError(), e)
+		}
+	}
+
+	return nil
+}
+
+func (m *Mock) expect(e *Expectation) *Expectation {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.expectations = append(m.expectations, e)
+	return e
+}
+
+// match marks the expectation of the call as met and returns it.
+func (m *Mock) match(kind expectationKind, query string, args []driver.Value) (*Expectation, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	call := &Expectation{kind: kind, query: normalizeQuery(query), args: make([]interface{}, len(args)), hasArgs: true}
+	for i, arg := range args {
+		call.args[i] = arg
+	}
+
+	for _, e := range m.expectations {
+		if e.triggered || e.isConnection() {
+			continue
+		}
+
+		if e.matches(kind, call.query, args) {
+			e.triggered = true
+			return e, nil
+		}
+
+		if m.ordered {
+			return nil, fmt.Errorf("%w: %s, the next expectation is %s", db_errors.MockUnexpectedCallError(), call, e)
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", db_errors.MockUnexpectedCallError(), call)
+}
+
+// matchConnection marks the first open, ping or close expectation of the kind which was not met as met and
+// returns its error; the call is accepted without an expectation.
+func (m *Mock) matchConnection(kind expectationKind) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for _, e := range m.expectations {
+		if !e.triggered && e.kind == kind {
+			e.triggered = true
+			return e.err
+		}
+	}
+
+	return nil
+}
+
+// Expectation is a call the mock expects and its answer.
+type Expectation struct {
+	kind      expectationKind
+	query     string
+	pattern   *regexp.Regexp
+	args      []interface{}
+	hasArgs   bool
+	rows      []*Rows
+	result    driver.Result
+	err       error
+	triggered bool
+}
+
+// WithArgs sets the arguments of the call. An argument is either an ArgumentMatcher or a value, which is
+// compared after the conversion of database/sql, e.g. an int is compared as an int64. The arguments are
+// not checked without WithArgs.
+func (e *Expectation) WithArgs(args ...interface{}) *Expectation {
+	e.args = args
+	e.hasArgs = true
+	return e
+}
+
+// WillReturnRows sets the result sets of a query.
+func (e *Expectation) WillReturnRows(rows ...*Rows) *Expectation {
+	e.rows = rows
+	return e
+}
+
+// WillReturnResult sets the result of a statement; it is 0 for both values by default.
+func (e *Expectation) WillReturnResult(lastInsertID int64, rowsAffected int64) *Expectation {
+	e.result = mockResult{lastInsertID: lastInsertID, rowsAffected: rowsAffected}
+	return e
+}
+
+// WillReturnError fails the call with the error.
+func (e *Expectation) WillReturnError(err error) *Expectation {
+	e.err = err
+	return e
+}
+
+func (e *Expectation) String() string {
+	var sb strings.Builder
+	sb.WriteString(string(e.kind))
+
+	switch {
+	case e.pattern != nil:
+		sb.WriteString(" matching " + e.pattern.String())
+	case e.kind == expectExec || e.kind == expectQuery:
+		sb.WriteString(" " + e.query)
+	}
+
+	if e.hasArgs {
+		sb.WriteString(fmt.Sprintf(" with %v", e.args))
+	}
+
+	return sb.String()
+}
+
+func (e *Expectation) isConnection() bool {
+	return e.kind == expectOpen || e.kind == expectPing || e.kind == expectClose
+}
+
+func (e *Expectation) matches(kind expectationKind, query string, args []driver.Value) bool {
+	if e.kind != kind {
+		return false
+	}
+
+	if kind != expectExec && kind != expectQuery {
+		return true
+	}
+
+	if e.pattern != nil {
+		if !e.pattern.MatchString(query) {
+			return false
+		}
+	} else if e.query != query {
+		return false
+	}
+
+	if !e.hasArgs {
+		return true
+	}
+
+	if len(e.args) != len(args) {
+		return false
+	}
+
+	for i, expected := range e.args {
+		if !matchArgument(expected, args[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ArgumentMatcher matches an argument of a call.
+type ArgumentMatcher interface {
+	Match(value driver.Value) bool
+}
+
+// ArgumentMatcherFunc is a function which matches an argument of a call.
+type ArgumentMatcherFunc func(value driver.Value) bool
+
+func (f ArgumentMatcherFunc) Match(value driver.Value) bool {
+	return f(value)
+}
+
+// AnyArg matches any argument.
+func AnyArg() ArgumentMatcher {
+	return ArgumentMatcherFunc(func(driver.Value) bool { return true })
+}
+
+func matchArgument(expected interface{}, actual driver.Value) bool {
+	if matcher, ok := expected.(ArgumentMatcher); ok {
+		return matcher.Match(actual)
+	}
+
+	value, err := driver.DefaultParameterConverter.ConvertValue(expected)
+	if err != nil {
+		return false
+	}
+
+	if t, ok := value.(time.Time); ok {
+		actualTime, ok := actual.(time.Time)
+		return ok && t.Equal(actualTime)
+	}
+
+	return reflect.DeepEqual(value, actual)
+}
+
+func normalizeQuery(query string) string {
+	return strings.TrimSpace(whiteSpace.ReplaceAllString(query, " "))
+}
+
+// Rows is a result set returned by a query expectation.
+type Rows struct {
+	columns []string
+	types   []string
+	values  [][]driver.Value
+	err     error
+	// rowErrors are the errors returned instead of the rows by their index.
+	rowErrors map[int]error
+}
+
+func CreateNewRows(columns ...string) *Rows {
+	return &Rows{columns: columns}
+}
+
+// AddRow adds a row with a value for each column; the query of the rows fails when the count differs.
+func (r *Rows) AddRow(values ...interface{}) *Rows {
+	if len(values) != len(r.columns) {
+		r.err = db_errors.RowValueCountMismatchError()
+		return r
+	}
+
+	row := make([]driver.Value, len(values))
+	for i, value := range values {
+		converted, err := driver.DefaultParameterConverter.ConvertValue(value)
+		if err != nil {
+			r.err = err
+			return r
+		}
+
+		row[i] = converted
+	}
+
+	r.values = append(r.values, row)
+	return r
+}
+
+// RowError fails the read of the row at the 0-based index with the error, which is returned by Rows.Err
+// after the rows before it were read. An index after the last row fails the read of the end of the rows.
+func (r *Rows) RowError(row int, err error) *Rows {
+	if r.rowErrors == nil {
+		r.rowErrors = make(map[int]error)
+	}
+
+	r.rowErrors[row] = err
+	return r
+}
+
+// WithDatabaseTypes sets the database type names of the columns, e.g. "BIGINT".
+func (r *Rows) WithDatabaseTypes(types ...string) *Rows {
+	r.types = types
+	return r
+}
+
+type mockResult struct {
+	lastInsertID int64
+	rowsAffected int64
+}
+
+func (r mockResult) LastInsertId() (int64, error) {
+	return r.lastInsertID, nil
+}
+
+func (r mockResult) RowsAffected() (int64, error) {
+	return r.rowsAffected, nil
+}
@@ -0,0 +1,228 @@
+package db_mock
+
+import (
+	"context"
+	"database/sql/driver"
+	"io"
+	"reflect"
+)
+
+type mockDriver struct {
+	mock *Mock
+}
+
+func (d *mockDriver) Open(name string) (driver.Conn, error) {
+	err := d.mock.matchConnection(expectOpen)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mockConn{mock: d.mock}, nil
+}
+
+type mockConn struct {
+	mock *Mock
+}
+
+func (c *mockConn) Prepare(query string) (driver.Stmt, error) {
+	return &mockStmt{conn: c, query: query}, nil
+}
+
+func (c *mockConn) Close() error {
+	return c.mock.matchConnection(expectClose)
+}
+
+func (c *mockConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *mockConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	e, err := c.mock.match(expectBegin, "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if e.err != nil {
+		return nil, e.err
+	}
+
+	return &mockTx{conn: c}, nil
+}
+
+func (c *mockConn) Ping(ctx context.Context) error {
+	return c.mock.matchConnection(expectPing)
+}
+
+func (c *mockConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	return c.exec(query, namedValues(args))
+}
+
+func (c *mockConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	return c.query(query, namedValues(args))
+}
+
+func (c *mockConn) exec(query string, args []driver.Value) (driver.Result, error) {
+	e, err := c.mock.match(expectExec, query, args)
+	if err != nil {
+		return nil, err
+	}
+
+	if e.err != nil {
+		return nil, e.err
+	}
+
+	if e.result == nil {
+		return mockResult{}, nil
+	}
+
+	return e.result, nil
+}
+
+func (c *mockConn) query(query string, args []driver.Value) (driver.Rows, error) {
+	e, err := c.mock.match(expectQuery, query, args)
+	if err != nil {
+		return nil, err
+	}
+
+	if e.err != nil {
+		return nil, e.err
+	}
+
+	for _, rows := range e.rows {
+		if rows.err != nil {
+			return nil, rows.err
+		}
+	}
+
+	sets := e.rows
+	if len(sets) == 0 {
+		sets = []*Rows{CreateNewRows()}
+	}
+
+	return &mockRows{sets: sets}, nil
+}
+
+func namedValues(args []driver.NamedValue) []driver.Value {
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		values[i] = arg.Value
+	}
+
+	return values
+}
+
+type mockTx struct {
+	conn *mockConn
+}
+
+func (t *mockTx) Commit() error {
+	return t.finish(expectCommit)
+}
+
+func (t *mockTx) Rollback() error {
+	return t.finish(expectRollback)
+}
+
+func (t *mockTx) finish(kind expectationKind) error {
+	e, err := t.conn.mock.match(kind, "", nil)
+	if err != nil {
+		return err
+	}
+
+	return e.err
+}
+
+// mockStmt is a prepared statement; its query is matched when it runs.
+type mockStmt struct {
+	conn  *mockConn
+	query string
+}
+
+func (s *mockStmt) Close() error {
+	return nil
+}
+
+// NumInput returns -1, so database/sql does not check the argument count.
+func (s *mockStmt) NumInput() int {
+	return -1
+}
+
+func (s *mockStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.conn.exec(s.query, args)
+}
+
+func (s *mockStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.conn.query(s.query, args)
+}
+
+func (s *mockStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	return s.conn.exec(s.query, namedValues(args))
+}
+
+func (s *mockStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	return s.conn.query(s.query, namedValues(args))
+}
+
+// mockRows reads the result sets of a query expectation.
+type mockRows struct {
+	sets []*Rows
+	set  int
+	row  int
+}
+
+func (r *mockRows) Columns() []string {
+	return r.sets[r.set].columns
+}
+
+func (r *mockRows) Close() error {
+	return nil
+}
+
+func (r *mockRows) Next(dest []driver.Value) error {
+	current := r.sets[r.set]
+	if err, ok := current.rowErrors[r.row]; ok {
+		return err
+	}
+
+	if r.row >= len(current.values) {
+		return io.EOF
+	}
+
+	copy(dest, current.values[r.row])
+	r.row++
+	return nil
+}
+
+func (r *mockRows) HasNextResultSet() bool {
+	return r.set+1 < len(r.sets)
+}
+
+func (r *mockRows) NextResultSet() error {
+	if !r.HasNextResultSet() {
+		return io.EOF
+	}
+
+	r.set++
+	r.row = 0
+	return nil
+}
+
+// ColumnTypeScanType returns the type of the first value of the column which is not nil.
+func (r *mockRows) ColumnTypeScanType(index int) reflect.Type {
+	for _, values := range r.sets[r.set].values {
+		if values[index] != nil {
+			return reflect.TypeOf(values[index])
+		}
+	}
+
+	return reflect.TypeOf(new(interface{})).Elem()
+}
+
+func (r *mockRows) ColumnTypeDatabaseTypeName(index int) string {
+	types := r.sets[r.set].types
+	if index < len(types) {
+		return types[index]
+	}
+
+	return ""
+}
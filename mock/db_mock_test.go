@@ -0,0 +1,304 @@
+package db_mock
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	db_errors "github.com/fatihtatoglu/db-go/error"
+)
+
+func openTestMock(t *testing.T) (*Mock, *sql.DB) {
+	mock := CreateNewMock()
+
+	db, err := sql.Open(mock.GetDriverName(), mock.GetDSN())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	t.Cleanup(func() { db.Close() })
+
+	return mock, db
+}
+
+func assertErrorPrefix(t *testing.T, err error, prefix string) {
+	t.Helper()
+
+	if err == nil || !strings.HasPrefix(err.Error(), prefix) {
+		t.Errorf("Expected an error starting with %q, got: %v", prefix, err)
+	}
+}
+
+func TestMock_Query(t *testing.T) {
+	// Arrange
+	mock, db := openTestMock(t)
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery("SELECT id, name\n  FROM users WHERE created_at > ? AND id <> ?").
+		WithArgs(created, AnyArg()).
+		WillReturnRows(CreateNewRows("id", "name").WithDatabaseTypes("BIGINT", "TEXT").AddRow(1, "Ann").AddRow(2, nil))
+
+	// Act
+	rows, err := db.Query("SELECT id, name FROM users WHERE created_at > ? AND id <> ?", created, 7)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer rows.Close()
+
+	types, _ := rows.ColumnTypes()
+
+	values := make([][]interface{}, 0)
+	for rows.Next() {
+		var id, name interface{}
+		rows.Scan(&id, &name)
+		values = append(values, []interface{}{id, name})
+	}
+
+	// Assert
+	expected := [][]interface{}{{int64(1), "Ann"}, {int64(2), nil}}
+	if !reflect.DeepEqual(values, expected) {
+		t.Errorf("Expected %v, got: %v", expected, values)
+	}
+
+	if types[0].DatabaseTypeName() != "BIGINT" || types[1].ScanType() != reflect.TypeOf("") {
+		t.Errorf("Unexpected column types: %s %v", types[0].DatabaseTypeName(), types[1].ScanType())
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestMock_Exec(t *testing.T) {
+	// Arrange
+	mock, db := openTestMock(t)
+	positive := ArgumentMatcherFunc(func(value driver.Value) bool {
+		n, ok := value.(int64)
+		return ok && n > 0
+	})
+
+	mock.ExpectExecRegexp(`^UPDATE users SET name = \? WHERE id = \?$`).WithArgs("Bob", positive).WillReturnResult(0, 3)
+
+	// Act
+	stmt, err := db.Prepare("UPDATE users SET name = ? WHERE id = ?")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer stmt.Close()
+
+	result, err := stmt.Exec("Bob", 5)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	affected, _ := result.RowsAffected()
+	if affected != 3 {
+		t.Errorf("Expected 3 rows affected, got: %d", affected)
+	}
+}
+
+func TestMock_Transaction(t *testing.T) {
+	// Arrange
+	mock, db := openTestMock(t)
+	failure := errors.New("deadlock")
+
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM users").WillReturnError(failure)
+	mock.ExpectRollback()
+
+	// Act
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	_, execErr := tx.Exec("DELETE FROM users")
+	rollbackErr := tx.Rollback()
+
+	// Assert
+	if execErr != failure || rollbackErr != nil {
+		t.Errorf("Unexpected errors: %v %v", execErr, rollbackErr)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestMock_ResultSets(t *testing.T) {
+	// Arrange
+	mock, db := openTestMock(t)
+	mock.ExpectQuery("CALL report()").WillReturnRows(CreateNewRows("a").AddRow(1), CreateNewRows("b", "c").AddRow("x", true))
+
+	// Act
+	rows, err := db.Query("CALL report()")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer rows.Close()
+
+	columns := make([][]string, 0)
+	for {
+		names, _ := rows.Columns()
+		columns = append(columns, names)
+
+		if !rows.NextResultSet() {
+			break
+		}
+	}
+
+	// Assert
+	if !reflect.DeepEqual(columns, [][]string{{"a"}, {"b", "c"}}) {
+		t.Errorf("Expected two result sets, got: %v", columns)
+	}
+}
+
+func TestMock_Order(t *testing.T) {
+	// Test cases
+	testCases := []struct {
+		name    string
+		ordered bool
+		failed  bool
+	}{
+		{name: "In order", ordered: true, failed: true},
+		{name: "In any order", ordered: false, failed: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			mock, db := openTestMock(t)
+			mock.MatchExpectationsInOrder(tc.ordered)
+			mock.ExpectExec("DELETE FROM a")
+			mock.ExpectExec("DELETE FROM b")
+
+			// Act
+			_, err := db.Exec("DELETE FROM b")
+
+			// Assert
+			if tc.failed {
+				assertErrorPrefix(t, err, db_errors.Mock_UnexpectedCallErrorMessage)
+			} else if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+
+			assertErrorPrefix(t, mock.ExpectationsWereMet(), db_errors.Mock_UnmetExpectationsErrorMessage)
+		})
+	}
+}
+
+func TestMock_UnexpectedCall(t *testing.T) {
+	// Arrange
+	mock, db := openTestMock(t)
+	mock.ExpectQuery("SELECT 1").WithArgs(1)
+
+	// Act
+	_, argsErr := db.Query("SELECT 1", 2)
+	_, queryErr := db.Exec("SELECT 1", 1)
+	_, beginErr := db.Begin()
+
+	// Assert
+	assertErrorPrefix(t, argsErr, db_errors.Mock_UnexpectedCallErrorMessage)
+	assertErrorPrefix(t, queryErr, db_errors.Mock_UnexpectedCallErrorMessage)
+	assertErrorPrefix(t, beginErr, db_errors.Mock_UnexpectedCallErrorMessage)
+
+	expected := "mock: the expectations were not met: QUERY SELECT 1 with [1]"
+	if err := mock.ExpectationsWereMet(); err == nil || err.Error() != expected {
+		t.Errorf("Expected %q, got: %v", expected, err)
+	}
+}
+
+func TestMock_Connection(t *testing.T) {
+	// Arrange
+	mock, db := openTestMock(t)
+	mock.ExpectExec("DELETE FROM a")
+	mock.ExpectClose().WillReturnError(errors.New("close failed"))
+	mock.ExpectPing().WillReturnError(errors.New("ping failed"))
+	mock.ExpectOpen()
+
+	// Act
+	pingErr := db.Ping()
+	_, execErr := db.Exec("DELETE FROM a")
+	closeErr := db.Close()
+
+	// Assert
+	if pingErr == nil || pingErr.Error() != "ping failed" {
+		t.Errorf("Expected the ping error, got: %v", pingErr)
+	}
+
+	if execErr != nil {
+		t.Errorf("Unexpected error: %v", execErr)
+	}
+
+	if closeErr == nil || closeErr.Error() != "close failed" {
+		t.Errorf("Expected the close error, got: %v", closeErr)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestRows_RowError(t *testing.T) {
+	// Test cases
+	testCases := []struct {
+		name     string
+		row      int
+		expected []int64
+	}{
+		{name: "First row", row: 0, expected: []int64{}},
+		{name: "Middle row", row: 1, expected: []int64{1}},
+		{name: "After the last row", row: 2, expected: []int64{1, 2}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			mock, db := openTestMock(t)
+			mock.ExpectQuery("SELECT id").WillReturnRows(CreateNewRows("id").AddRow(1).AddRow(2).RowError(tc.row, errors.New("read failed")))
+
+			// Act
+			rows, err := db.Query("SELECT id")
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			defer rows.Close()
+
+			ids := make([]int64, 0)
+			for rows.Next() {
+				var id int64
+				rows.Scan(&id)
+				ids = append(ids, id)
+			}
+
+			// Assert
+			if !reflect.DeepEqual(ids, tc.expected) {
+				t.Errorf("Expected %v, got: %v", tc.expected, ids)
+			}
+
+			if err := rows.Err(); err == nil || err.Error() != "read failed" {
+				t.Errorf("Expected the row error, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestRows_AddRow(t *testing.T) {
+	// Arrange
+	mock, db := openTestMock(t)
+	mock.ExpectQuery("SELECT a, b").WillReturnRows(CreateNewRows("a", "b").AddRow(1))
+
+	// Act
+	_, err := db.Query("SELECT a, b")
+
+	// Assert
+	if err == nil || err.Error() != db_errors.Row_ValueCountMismatchErrorMessage {
+		t.Errorf("Expected %q, got: %v", db_errors.Row_ValueCountMismatchErrorMessage, err)
+	}
+}